@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"log"
 	"os"
 
@@ -19,23 +20,41 @@ var generateCmd = &cobra.Command{
 	Short: "Generate code from GraphQL schema",
 	Long:  `Generate code from GraphQL schema`,
 	Run: func(cmd *cobra.Command, args []string) {
-		yamlFile, err := os.ReadFile("goliteql.yaml")
+		config := readConfig()
+
+		g, err := generator.NewGenerator(config)
 		if err != nil {
-			log.Fatalf("error reading config file: %v", err)
+			log.Fatalf("error creating generator: %v", err)
 		}
 
-		var config generator.Config
-		if err := yaml.Unmarshal(yamlFile, &config); err != nil {
-			log.Fatalf("error unmarshalling config file: %v", err)
+		if err := g.Generate(); err != nil {
+			log.Fatalf("error generating code: %v", err)
 		}
+	},
+}
 
-		g, err := generator.NewGenerator(&config)
+var manifestCmd = &cobra.Command{
+	Use:   "manifest",
+	Short: "Build a trusted document manifest from GraphQL operation files",
+	Long:  `Build a trusted document manifest of {"hash": "query"} entries from GraphQL operation files, validating each of them against the schema`,
+	Run: func(cmd *cobra.Command, args []string) {
+		config := readConfig()
+
+		operations, _ := cmd.Flags().GetString("operations")
+		output, _ := cmd.Flags().GetString("output")
+
+		documents, err := generator.BuildTrustedDocuments(config, operations)
 		if err != nil {
-			log.Fatalf("error creating generator: %v", err)
+			log.Fatalf("error building trusted documents:\n%v", err)
 		}
 
-		if err := g.Generate(); err != nil {
-			log.Fatalf("error generating code: %v", err)
+		b, err := json.MarshalIndent(documents, "", "  ")
+		if err != nil {
+			log.Fatalf("error encoding trusted documents: %v", err)
+		}
+
+		if err := os.WriteFile(output, append(b, '\n'), 0644); err != nil {
+			log.Fatalf("error writing trusted documents: %v", err)
 		}
 	},
 }
@@ -50,29 +69,46 @@ var initCmd = &cobra.Command{
 }
 
 func main() {
+	manifestCmd.Flags().String("operations", "./graphql/operations", "directory of the GraphQL operation files")
+	manifestCmd.Flags().String("output", "./graphql/trusted-documents.json", "path of the manifest to write")
+
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(generateCmd)
+	rootCmd.AddCommand(manifestCmd)
 	if err := rootCmd.Execute(); err != nil {
 		log.Fatalf("error executing command: %v", err)
 	}
 }
 
+func readConfig() *generator.Config {
+	yamlFile, err := os.ReadFile("goliteql.yaml")
+	if err != nil {
+		log.Fatalf("error reading config file: %v", err)
+	}
+
+	var config generator.Config
+	if err := yaml.Unmarshal(yamlFile, &config); err != nil {
+		log.Fatalf("error unmarshalling config file: %v", err)
+	}
+
+	return &config
+}
+
 var initConfig = generator.Config{
-	SchemaDirectory:             "./graphql/schema",
-	ModelOutputFile:             "./graphql/model/models.go",
-	ScalarOutputFile:            "./graphql/model/scalar.go",
-	QueryResolverOutputFile:     "./graphql/resolver/query.resolver.go",
-	MutationResolverOutputFile:  "./graphql/resolver/mutate.resolver.go",
-	RootResolverOutputFile:      "./graphql/resolver/resolver.go",
-	ResolverGeneratedOutputFile: "./graphql/resolver/generated.go",
-	EnumOutputFile:              "./graphql/model/enum.go",
-	ModelPackageName:            "example.com/graphql/model",
-	ResolverPackageName:         "example.com/graphql/resolver",
+	SchemaDirectory:                "./graphql/schema",
+	ModelOutputFile:                "./graphql/model/models.go",
+	ScalarOutputFile:               "./graphql/model/scalar.go",
+	QueryResolverOutputFile:        "./graphql/resolver/query.resolver.go",
+	MutationResolverOutputFile:     "./graphql/resolver/mutate.resolver.go",
+	SubscriptionResolverOutputFile: "./graphql/resolver/subscription.resolver.go",
+	RootResolverOutputFile:         "./graphql/resolver/resolver.go",
+	ResolverGeneratedOutputFile:    "./graphql/resolver/generated.go",
+	EnumOutputFile:                 "./graphql/model/enum.go",
+	ModelPackageName:               "example.com/graphql/model",
+	ResolverPackageName:            "example.com/graphql/resolver",
 	Scalars: []generator.ScalarConfig{
 		{
-			Name:    "DateTime",
-			Package: "time",
-			Type:    "time.Time",
+			Name: "DateTime",
 		},
 	},
 }
@@ -111,8 +147,11 @@ type Post implements Node {
 	content: String!
 	alt: String! @deprecated(reason: "use description")
 	description: String
+	createdAt: DateTime!
 }
 
+scalar DateTime
+
 type User implements Node {
 	id: ID!
 	name: String!
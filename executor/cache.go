@@ -1,44 +1,193 @@
 package executor
 
 import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/n9te9/goliteql/query"
 )
 
+const DefaultCacheMaxEntries = 1000
+
+type CacheOption func(*cacheOptions)
+
+type cacheOptions struct {
+	maxEntries int
+	ttl        time.Duration
+}
+
+// WithCacheMaxEntries bounds the number of documents and plans the cache holds, the least recently used one being evicted first.
+// A non-positive maxEntries disables caching.
+func WithCacheMaxEntries(maxEntries int) CacheOption {
+	return func(o *cacheOptions) {
+		o.maxEntries = maxEntries
+	}
+}
+
+// WithCacheTTL expires entries ttl after they were stored. Entries never expire when ttl is zero.
+func WithCacheTTL(ttl time.Duration) CacheOption {
+	return func(o *cacheOptions) {
+		o.ttl = ttl
+	}
+}
+
+type CacheStats struct {
+	Hits    uint64
+	Misses  uint64
+	Entries int
+}
+
+type cacheKind uint8
+
+const (
+	documentCacheKind cacheKind = iota
+	planCacheKind
+	persistedQueryCacheKind
+)
+
+type cacheKey struct {
+	kind cacheKind
+	hash string
+}
+
+type cacheEntry struct {
+	key   cacheKey
+	value any
+	exp   time.Time
+}
+
+// Cache is a size-bounded LRU cache of parsed documents and execution plans shared by every request.
+// Cached values are shared, so they must not be mutated.
 type Cache struct {
-	Nodes []*Node
-	Exp   time.Time
+	options cacheOptions
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[cacheKey]*list.Element
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
 }
 
-type CacheMap map[string]*Cache
+func NewCache(opts ...CacheOption) *Cache {
+	o := cacheOptions{
+		maxEntries: DefaultCacheMaxEntries,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
 
-func (c CacheMap) Get(key string) []*Node {
-	cache, exists := c[key]
-	if !exists || time.Now().After(cache.Exp) {
-		return nil
+	return &Cache{
+		options: o,
+		order:   list.New(),
+		entries: make(map[cacheKey]*list.Element),
 	}
+}
 
-	return cache.Nodes
+// QueryHash returns the hex encoded SHA-256 hash of a query document.
+func QueryHash(document string) string {
+	sum := sha256.Sum256([]byte(document))
+	return hex.EncodeToString(sum[:])
 }
 
-func (c CacheMap) Set(key string, nodes []*Node, duration time.Duration) {
-	c[key] = &Cache{
-		Nodes: nodes,
-		Exp:   time.Now().Add(duration),
+// Parse returns the parsed document of source, parsing it with parser only when it is not cached.
+func (c *Cache) Parse(parser *query.Parser, source string) (*query.Document, error) {
+	key := cacheKey{kind: documentCacheKind, hash: QueryHash(source)}
+	if v, ok := c.get(key); ok {
+		return v.(*query.Document), nil
 	}
+
+	doc, err := parser.Parse([]byte(source))
+	if err != nil {
+		return nil, err
+	}
+	c.set(key, doc)
+
+	return doc, nil
 }
 
-func NewCache(node []*Node, duration time.Duration) *Cache {
-	return &Cache{
-		Nodes: node,
-		Exp:   time.Now().Add(duration),
+// PlanExecution returns the plan cached under planKey, see PlanKey, planning the selections only when it is not cached.
+func (c *Cache) PlanExecution(planKey string, selections []query.Selection, fragmentDefinitions query.FragmentDefinitions, variables map[string]json.RawMessage) ([]*Node, error) {
+	key := cacheKey{kind: planCacheKind, hash: QueryHash(planKey)}
+	if v, ok := c.get(key); ok {
+		return v.([]*Node), nil
+	}
+
+	nodes, err := PlanExecution(selections, fragmentDefinitions, variables)
+	if err != nil {
+		return nil, err
+	}
+	c.set(key, nodes)
+
+	return nodes, nil
+}
+
+func (c *Cache) Stats() CacheStats {
+	c.mu.Lock()
+	entries := c.order.Len()
+	c.mu.Unlock()
+
+	return CacheStats{
+		Hits:    c.hits.Load(),
+		Misses:  c.misses.Load(),
+		Entries: entries,
+	}
+}
+
+func (c *Cache) get(key cacheKey) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	entry := elem.Value.(*cacheEntry)
+	if !entry.exp.IsZero() && time.Now().After(entry.exp) {
+		c.remove(elem)
+		c.misses.Add(1)
+		return nil, false
 	}
+
+	c.order.MoveToFront(elem)
+	c.hits.Add(1)
+	return entry.value, true
 }
 
-func NewPool() *sync.Pool {
-	return &sync.Pool{
-		New: func() any {
-			return CacheMap{}
-		},
+func (c *Cache) set(key cacheKey, value any) {
+	if c.options.maxEntries <= 0 {
+		return
 	}
+
+	var exp time.Time
+	if c.options.ttl > 0 {
+		exp = time.Now().Add(c.options.ttl)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*cacheEntry)
+		entry.value, entry.exp = value, exp
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, value: value, exp: exp})
+	for c.order.Len() > c.options.maxEntries {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *Cache) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*cacheEntry).key)
 }
@@ -0,0 +1,117 @@
+package executor_test
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor"
+	"github.com/n9te9/goliteql/query"
+)
+
+func TestCache_Parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []executor.CacheOption
+		queries []string
+		wait    time.Duration
+		want    executor.CacheStats
+	}{
+		{
+			name:    "repeated query is parsed once",
+			queries: []string{"query { a }", "query { a }", "query { a }"},
+			want:    executor.CacheStats{Hits: 2, Misses: 1, Entries: 1},
+		},
+		{
+			name:    "least recently used document is evicted",
+			opts:    []executor.CacheOption{executor.WithCacheMaxEntries(2)},
+			queries: []string{"query { a }", "query { b }", "query { a }", "query { c }", "query { b }"},
+			want:    executor.CacheStats{Hits: 1, Misses: 4, Entries: 2},
+		},
+		{
+			name:    "expired document is parsed again",
+			opts:    []executor.CacheOption{executor.WithCacheTTL(time.Millisecond)},
+			queries: []string{"query { a }", "query { a }"},
+			wait:    5 * time.Millisecond,
+			want:    executor.CacheStats{Hits: 0, Misses: 2, Entries: 1},
+		},
+		{
+			name:    "zero max entries disables caching",
+			opts:    []executor.CacheOption{executor.WithCacheMaxEntries(0)},
+			queries: []string{"query { a }", "query { a }"},
+			want:    executor.CacheStats{Hits: 0, Misses: 2, Entries: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := executor.NewCache(tt.opts...)
+			for _, q := range tt.queries {
+				if _, err := cache.Parse(query.NewParserWithLexer(), q); err != nil {
+					t.Fatalf("Parse() error = %v", err)
+				}
+				time.Sleep(tt.wait)
+			}
+
+			if d := cmp.Diff(tt.want, cache.Stats()); d != "" {
+				t.Errorf("Stats() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestCache_PlanExecution(t *testing.T) {
+	doc, err := query.NewParserWithLexer().Parse([]byte(`query ($skip: Boolean!) { a b @skip(if: $skip) }`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	selections := doc.Operations[0].Selections
+
+	cache := executor.NewCache()
+	plan := func(skip string) []*executor.Node {
+		variables := map[string]json.RawMessage{"skip": json.RawMessage(skip)}
+		key := executor.PlanKey("", executor.QueryHash("doc"), selections, doc.FragmentDefinitions, variables)
+		nodes, err := cache.PlanExecution(key, selections, doc.FragmentDefinitions, variables)
+		if err != nil {
+			t.Fatalf("PlanExecution() error = %v", err)
+		}
+		return nodes
+	}
+
+	first := plan("true")
+	if got := plan("true"); got[0] != first[0] {
+		t.Errorf("PlanExecution() did not return the cached plan")
+	}
+
+	if got := plan("false"); len(got) != 2 {
+		t.Errorf("PlanExecution() returned %d nodes, want 2", len(got))
+	}
+
+	if d := cmp.Diff(executor.CacheStats{Hits: 1, Misses: 2, Entries: 2}, cache.Stats()); d != "" {
+		t.Errorf("Stats() mismatch (-want +got):\n%s", d)
+	}
+}
+
+func TestCache_Concurrent(t *testing.T) {
+	cache := executor.NewCache(executor.WithCacheMaxEntries(2))
+	queries := []string{"query { a }", "query { b }", "query { c }"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 30; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := cache.Parse(query.NewParserWithLexer(), queries[i%len(queries)]); err != nil {
+				t.Errorf("Parse() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := cache.Stats()
+	if stats.Hits+stats.Misses != 30 || stats.Entries > 2 {
+		t.Errorf("Stats() = %+v, want 30 lookups and at most 2 entries", stats)
+	}
+}
@@ -0,0 +1,59 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+type variablesContextKey struct{}
+
+func WithVariables(ctx context.Context, variables map[string]json.RawMessage) context.Context {
+	return context.WithValue(ctx, variablesContextKey{}, variables)
+}
+
+func VariablesFromContext(ctx context.Context) map[string]json.RawMessage {
+	variables, _ := ctx.Value(variablesContextKey{}).(map[string]json.RawMessage)
+	return variables
+}
+
+type pathContextKey struct{}
+
+type responsePath struct {
+	parent *responsePath
+	key    any
+}
+
+// WithPath returns a context whose response path is extended by key, which is either a response key or a list index.
+func WithPath(ctx context.Context, key any) context.Context {
+	parent, _ := ctx.Value(pathContextKey{}).(*responsePath)
+	return context.WithValue(ctx, pathContextKey{}, &responsePath{parent: parent, key: key})
+}
+
+func PathFromContext(ctx context.Context) []any {
+	p, _ := ctx.Value(pathContextKey{}).(*responsePath)
+
+	n := 0
+	for cur := p; cur != nil; cur = cur.parent {
+		n++
+	}
+
+	ret := make([]any, n)
+	for cur := p; cur != nil; cur = cur.parent {
+		n--
+		ret[n] = cur.key
+	}
+
+	return ret
+}
+
+type fieldErrorsContextKey struct{}
+
+type fieldErrors struct {
+	mu   sync.Mutex
+	errs []GraphQLError
+}
+
+func withFieldErrors(ctx context.Context, errs *fieldErrors) context.Context {
+	return context.WithValue(ctx, fieldErrorsContextKey{}, errs)
+}
@@ -0,0 +1,61 @@
+package executor_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor"
+)
+
+func TestVariablesFromContext(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want map[string]json.RawMessage
+	}{
+		{
+			name: "with variables",
+			ctx: executor.WithVariables(context.Background(), map[string]json.RawMessage{
+				"id": json.RawMessage(`"1"`),
+			}),
+			want: map[string]json.RawMessage{
+				"id": json.RawMessage(`"1"`),
+			},
+		},
+		{
+			name: "without variables",
+			ctx:  context.Background(),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := executor.VariablesFromContext(tt.ctx)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("VariablesFromContext() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPathFromContext(t *testing.T) {
+	ctx := executor.WithPath(context.Background(), "users")
+	ctx = executor.WithPath(ctx, 1)
+	sibling := executor.WithPath(ctx, "id")
+	ctx = executor.WithPath(ctx, "name")
+
+	if d := cmp.Diff([]any{"users", 1, "name"}, executor.PathFromContext(ctx)); d != "" {
+		t.Errorf("PathFromContext() mismatch (-want +got):\n%s", d)
+	}
+
+	if d := cmp.Diff([]any{"users", 1, "id"}, executor.PathFromContext(sibling)); d != "" {
+		t.Errorf("PathFromContext() mismatch (-want +got):\n%s", d)
+	}
+
+	if d := cmp.Diff([]any{}, executor.PathFromContext(context.Background())); d != "" {
+		t.Errorf("PathFromContext() mismatch (-want +got):\n%s", d)
+	}
+}
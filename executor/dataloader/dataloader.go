@@ -0,0 +1,239 @@
+package dataloader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const DefaultWait = time.Millisecond
+
+var ErrBatchLength = errors.New("batch function returned unexpected number of results")
+
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) ([]V, []error)
+
+type Option func(*options)
+
+type options struct {
+	wait     time.Duration
+	maxBatch int
+	cache    bool
+}
+
+func WithWait(wait time.Duration) Option {
+	return func(o *options) {
+		o.wait = wait
+	}
+}
+
+func WithMaxBatch(maxBatch int) Option {
+	return func(o *options) {
+		o.maxBatch = maxBatch
+	}
+}
+
+func WithoutCache() Option {
+	return func(o *options) {
+		o.cache = false
+	}
+}
+
+type result[V any] struct {
+	value V
+	err   error
+	done  chan struct{}
+}
+
+func (r *result[V]) wait(ctx context.Context) (V, error) {
+	select {
+	case <-r.done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+type batch[K comparable, V any] struct {
+	keys    []K
+	results []*result[V]
+	closed  bool
+}
+
+type Loader[K comparable, V any] struct {
+	batchFn BatchFunc[K, V]
+	options options
+
+	mu    sync.Mutex
+	cache map[K]*result[V]
+	batch *batch[K, V]
+}
+
+func NewLoader[K comparable, V any](batchFn BatchFunc[K, V], opts ...Option) *Loader[K, V] {
+	o := options{
+		wait:  DefaultWait,
+		cache: true,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &Loader[K, V]{
+		batchFn: batchFn,
+		options: o,
+		cache:   make(map[K]*result[V]),
+	}
+}
+
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	return l.LoadThunk(ctx, key)()
+}
+
+func (l *Loader[K, V]) LoadThunk(ctx context.Context, key K) func() (V, error) {
+	r := l.enqueue(ctx, key)
+
+	return func() (V, error) {
+		return r.wait(ctx)
+	}
+}
+
+func (l *Loader[K, V]) LoadMany(ctx context.Context, keys []K) ([]V, []error) {
+	results := make([]*result[V], len(keys))
+	for i, key := range keys {
+		results[i] = l.enqueue(ctx, key)
+	}
+
+	values := make([]V, len(keys))
+	var errs []error
+	for i, r := range results {
+		v, err := r.wait(ctx)
+		values[i] = v
+		if err != nil {
+			if errs == nil {
+				errs = make([]error, len(keys))
+			}
+			errs[i] = err
+		}
+	}
+
+	return values, errs
+}
+
+func (l *Loader[K, V]) Prime(key K, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, exists := l.cache[key]; exists {
+		return
+	}
+
+	r := &result[V]{value: value, done: make(chan struct{})}
+	close(r.done)
+	l.cache[key] = r
+}
+
+func (l *Loader[K, V]) Clear(key K) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.cache, key)
+}
+
+func (l *Loader[K, V]) ClearAll() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.cache = make(map[K]*result[V])
+}
+
+func (l *Loader[K, V]) enqueue(ctx context.Context, key K) *result[V] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.options.cache {
+		if r, exists := l.cache[key]; exists {
+			return r
+		}
+	}
+
+	r := &result[V]{done: make(chan struct{})}
+	if l.options.cache {
+		l.cache[key] = r
+	}
+
+	// a batch is shared by the callers that join it, so it is dispatched without the cancellation of the first one
+	ctx = context.WithoutCancel(ctx)
+	if l.batch == nil {
+		l.batch = &batch[K, V]{}
+		b := l.batch
+		time.AfterFunc(l.options.wait, func() {
+			l.dispatch(ctx, b)
+		})
+	}
+
+	l.batch.keys = append(l.batch.keys, key)
+	l.batch.results = append(l.batch.results, r)
+
+	if l.options.maxBatch > 0 && len(l.batch.keys) >= l.options.maxBatch {
+		b := l.batch
+		l.batch = nil
+		go l.dispatch(ctx, b)
+	}
+
+	return r
+}
+
+func (l *Loader[K, V]) dispatch(ctx context.Context, b *batch[K, V]) {
+	l.mu.Lock()
+	if b.closed {
+		l.mu.Unlock()
+		return
+	}
+	b.closed = true
+	if l.batch == b {
+		l.batch = nil
+	}
+	l.mu.Unlock()
+
+	values, errs := l.call(ctx, b.keys)
+
+	for i, r := range b.results {
+		switch {
+		case len(values) != len(b.keys) && len(errs) == 1:
+			r.err = errs[0]
+		case len(values) != len(b.keys):
+			r.err = fmt.Errorf("%w: want %d, got %d", ErrBatchLength, len(b.keys), len(values))
+		case len(errs) == len(b.keys):
+			r.value, r.err = values[i], errs[i]
+		case len(errs) == 1:
+			r.value, r.err = values[i], errs[0]
+		case len(errs) > 0:
+			r.err = fmt.Errorf("%w: want %d errors, got %d", ErrBatchLength, len(b.keys), len(errs))
+		default:
+			r.value = values[i]
+		}
+
+		if r.err != nil && l.options.cache {
+			l.mu.Lock()
+			if l.cache[b.keys[i]] == r {
+				delete(l.cache, b.keys[i])
+			}
+			l.mu.Unlock()
+		}
+
+		close(r.done)
+	}
+}
+
+func (l *Loader[K, V]) call(ctx context.Context, keys []K) (values []V, errs []error) {
+	defer func() {
+		if p := recover(); p != nil {
+			values = nil
+			errs = []error{fmt.Errorf("panic in batch function: %v", p)}
+		}
+	}()
+
+	return l.batchFn(ctx, keys)
+}
@@ -0,0 +1,219 @@
+package dataloader_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/n9te9/goliteql/executor/dataloader"
+)
+
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches [][]int
+}
+
+func (b *batchRecorder) batchFn(ctx context.Context, keys []int) ([]string, []error) {
+	b.mu.Lock()
+	b.batches = append(b.batches, append([]int(nil), keys...))
+	b.mu.Unlock()
+
+	values := make([]string, len(keys))
+	for i, key := range keys {
+		values[i] = strconv.Itoa(key)
+	}
+
+	return values, nil
+}
+
+func TestLoader_Load(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []dataloader.Option
+		keys        []int
+		want        []string
+		wantBatches [][]int
+	}{
+		{
+			name:        "concurrent loads are batched",
+			opts:        []dataloader.Option{dataloader.WithWait(10 * time.Millisecond)},
+			keys:        []int{1, 2, 3},
+			want:        []string{"1", "2", "3"},
+			wantBatches: [][]int{{1, 2, 3}},
+		},
+		{
+			name:        "duplicate keys are memoized",
+			opts:        []dataloader.Option{dataloader.WithWait(10 * time.Millisecond)},
+			keys:        []int{1, 1, 2},
+			want:        []string{"1", "1", "2"},
+			wantBatches: [][]int{{1, 2}},
+		},
+		{
+			name: "batches are split by max batch size",
+			opts: []dataloader.Option{
+				dataloader.WithWait(time.Hour),
+				dataloader.WithMaxBatch(2),
+			},
+			keys:        []int{1, 2, 3, 4},
+			want:        []string{"1", "2", "3", "4"},
+			wantBatches: [][]int{{1, 2}, {3, 4}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &batchRecorder{}
+			loader := dataloader.NewLoader(recorder.batchFn, tt.opts...)
+
+			thunks := make([]func() (string, error), len(tt.keys))
+			for i, key := range tt.keys {
+				thunks[i] = loader.LoadThunk(context.Background(), key)
+			}
+
+			got := make([]string, len(thunks))
+			for i, thunk := range thunks {
+				v, err := thunk()
+				if err != nil {
+					t.Fatal(err)
+				}
+				got[i] = v
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Load() mismatch (-want +got):\n%s", diff)
+			}
+
+			sortBatches := cmpopts.SortSlices(func(a, b []int) bool { return a[0] < b[0] })
+			if diff := cmp.Diff(tt.wantBatches, recorder.batches, sortBatches); diff != "" {
+				t.Errorf("batches mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoader_LoadCancel(t *testing.T) {
+	loader := dataloader.NewLoader(func(ctx context.Context, keys []int) ([]string, []error) {
+		if err := ctx.Err(); err != nil {
+			return nil, []error{err}
+		}
+
+		values := make([]string, len(keys))
+		for i, key := range keys {
+			values[i] = strconv.Itoa(key)
+		}
+
+		return values, nil
+	}, dataloader.WithWait(10*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := loader.LoadThunk(ctx, 1)
+	thunk := loader.LoadThunk(context.Background(), 2)
+	cancel()
+
+	if _, err := cancelled(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Load() error = %v, want %v", err, context.Canceled)
+	}
+
+	got, err := thunk()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got != "2" {
+		t.Errorf("Load() = %q, want %q", got, "2")
+	}
+}
+
+func TestLoader_LoadMemoization(t *testing.T) {
+	recorder := &batchRecorder{}
+	loader := dataloader.NewLoader(recorder.batchFn)
+	loader.Prime(3, "primed")
+
+	ctx := context.Background()
+	for _, key := range []int{1, 1, 3} {
+		if _, err := loader.Load(ctx, key); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	loader.Clear(1)
+	if _, err := loader.Load(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := loader.Load(ctx, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "primed" {
+		t.Errorf("Load() = %q, want %q", got, "primed")
+	}
+
+	if diff := cmp.Diff([][]int{{1}, {1}}, recorder.batches); diff != "" {
+		t.Errorf("batches mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoader_LoadMany(t *testing.T) {
+	errNotFound := errors.New("not found")
+
+	tests := []struct {
+		name     string
+		batchFn  dataloader.BatchFunc[int, string]
+		keys     []int
+		want     []string
+		wantErrs []error
+	}{
+		{
+			name: "per key errors",
+			batchFn: func(ctx context.Context, keys []int) ([]string, []error) {
+				return []string{"1", ""}, []error{nil, errNotFound}
+			},
+			keys:     []int{1, 2},
+			want:     []string{"1", ""},
+			wantErrs: []error{nil, errNotFound},
+		},
+		{
+			name: "single error is applied to every key",
+			batchFn: func(ctx context.Context, keys []int) ([]string, []error) {
+				return nil, []error{errNotFound}
+			},
+			keys:     []int{1, 2},
+			want:     []string{"", ""},
+			wantErrs: []error{errNotFound, errNotFound},
+		},
+		{
+			name: "unexpected number of results",
+			batchFn: func(ctx context.Context, keys []int) ([]string, []error) {
+				return []string{"1"}, nil
+			},
+			keys:     []int{1, 2},
+			want:     []string{"", ""},
+			wantErrs: []error{dataloader.ErrBatchLength, dataloader.ErrBatchLength},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader := dataloader.NewLoader(tt.batchFn)
+
+			got, errs := loader.LoadMany(context.Background(), tt.keys)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("LoadMany() mismatch (-want +got):\n%s", diff)
+			}
+
+			if len(errs) != len(tt.wantErrs) {
+				t.Fatalf("LoadMany() returned %d errors, want %d", len(errs), len(tt.wantErrs))
+			}
+			for i, err := range errs {
+				if !errors.Is(err, tt.wantErrs[i]) {
+					t.Errorf("LoadMany() error[%d] = %v, want %v", i, err, tt.wantErrs[i])
+				}
+			}
+		})
+	}
+}
@@ -1,99 +1,134 @@
 package executor
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"reflect"
 
 	"github.com/n9te9/goliteql/query"
 )
 
-func isIncludeDirective(dir *query.Directive) bool {
-	if dir == nil {
-		return false
-	}
+// DirectiveFunc executes a schema directive around next, which resolves the value the directive is applied to.
+type DirectiveFunc func(ctx context.Context, next func(ctx context.Context) (any, error)) (any, error)
 
-	if dir.Name != nil && string(dir.Name) == "include" {
-		return true
+// WithDirectives resolves a value through the directives applied to it, the first directive being the outermost one.
+// The value returned by the outermost directive has to be a T, or nil when T is nillable.
+func WithDirectives[T any](ctx context.Context, resolve func(ctx context.Context) (T, error), directives ...DirectiveFunc) (T, error) {
+	next := func(ctx context.Context) (any, error) {
+		return resolve(ctx)
 	}
 
-	return false
-}
-
-func IsIncluded(directives []*query.Directive, v json.RawMessage) bool {
-	for _, dir := range directives {
-		if isIncludeDirective(dir) {
-			if len(dir.Arguments) != 1 {
-				return false
-			}
-
-			if dir.Arguments[0].IsVariable {
-				if string(dir.Arguments[0].Name) != "if" {
-					return false
-				}
-
-				variables := make(map[string]any)
-				if err := json.Unmarshal(v, &variables); err != nil {
-					return false
-				}
-
-				flag, ok := variables[string(dir.Arguments[0].Name)].(bool)
-				if !ok {
-					return true
-				}
+	for i := len(directives) - 1; i >= 0; i-- {
+		directive, inner := directives[i], next
+		next = func(ctx context.Context) (any, error) {
+			return directive(ctx, inner)
+		}
+	}
 
-				return flag
-			}
+	var zero T
+	ret, err := next(ctx)
+	if err != nil {
+		return zero, err
+	}
 
-			if string(dir.Arguments[0].Value) == "true" {
-				return true
-			}
+	if v, ok := ret.(T); ok {
+		return v, nil
+	}
 
-			return false
+	typ := reflect.TypeOf(&zero).Elem()
+	if ret == nil {
+		switch typ.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface, reflect.Chan, reflect.Func:
+			return zero, nil
 		}
 	}
 
-	return true
+	return zero, fmt.Errorf("directive returned %T, expected %s", ret, typ)
 }
 
-func isSkipDirective(dir *query.Directive) bool {
-	if dir == nil {
-		return false
+// UnmarshalDirectiveArgument decodes the JSON encoded value of an argument of a directive applied in the schema.
+func UnmarshalDirectiveArgument[T any](data string) (T, error) {
+	var v T
+	if err := json.Unmarshal([]byte(data), &v); err != nil {
+		return v, fmt.Errorf("error decoding directive argument: %w", err)
 	}
 
-	if dir.Name != nil && string(dir.Name) == "skip" {
-		return true
+	return v, nil
+}
+
+// IsSkipped evaluates @skip on a selection with the coerced variables of the operation.
+func IsSkipped(directives []*query.Directive, variables map[string]json.RawMessage) (bool, error) {
+	return evaluateCondition(directives, "skip", false, variables)
+}
+
+// IsIncluded evaluates @include on a selection with the coerced variables of the operation.
+func IsIncluded(directives []*query.Directive, variables map[string]json.RawMessage) (bool, error) {
+	return evaluateCondition(directives, "include", true, variables)
+}
+
+// ShouldInclude reports whether a field, fragment spread or inline fragment is part of the response, following CollectFields of the spec.
+func ShouldInclude(directives []*query.Directive, variables map[string]json.RawMessage) (bool, error) {
+	skipped, err := IsSkipped(directives, variables)
+	if err != nil || skipped {
+		return false, err
 	}
 
-	return false
+	return IsIncluded(directives, variables)
 }
 
-func IsSkipped(directives []*query.Directive, v json.RawMessage) bool {
+// evaluateCondition returns the Boolean! value of the if argument of the directive named name, or fallback when the directive is absent.
+func evaluateCondition(directives []*query.Directive, name string, fallback bool, variables map[string]json.RawMessage) (bool, error) {
 	for _, dir := range directives {
-		if isSkipDirective(dir) {
-			if len(dir.Arguments) != 1 {
-				return false
-			}
-
-			if dir.Arguments[0].IsVariable {
-				if string(dir.Arguments[0].Name) != "if" {
-					return true
-				}
+		if dir == nil || string(dir.Name) != name {
+			continue
+		}
 
-				variables := make(map[string]any)
-				if err := json.Unmarshal(v, &variables); err != nil {
-					return false
-				}
+		var arg *query.DirectiveArgument
+		for _, a := range dir.Arguments {
+			if string(a.Name) == "if" {
+				arg = a
+				break
+			}
+		}
 
-				flag, ok := variables[string(dir.Arguments[0].Name)].(bool)
-				if !ok {
-					return true
-				}
+		if arg == nil {
+			return false, newDirectiveError(dir.Position, fmt.Sprintf("Directive \"@%s\" argument \"if\" of type \"Boolean!\" is required, but it was not provided.", name))
+		}
 
-				return flag
+		value := arg.Value
+		if arg.IsVariable {
+			raw, ok := variables[string(arg.Value)]
+			if !ok {
+				return false, newDirectiveError(arg.Position, fmt.Sprintf("Argument \"if\" of required type \"Boolean!\" was provided the variable \"$%s\" which was not provided a runtime value.", arg.Value))
 			}
+			value = bytes.TrimSpace(raw)
+		}
 
-			return string(dir.Arguments[0].Value) == "true"
+		switch string(value) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return false, newDirectiveError(arg.Position, fmt.Sprintf("Argument \"if\" of non-null type \"Boolean!\" of directive \"@%s\" must not be null.", name))
 		}
+
+		return false, newDirectiveError(arg.Position, fmt.Sprintf("Argument \"if\" of directive \"@%s\" has invalid value %s. Expected type \"Boolean!\".", name, value))
 	}
 
-	return false
+	return fallback, nil
+}
+
+func newDirectiveError(pos query.Position, message string) GraphQLError {
+	return GraphQLError{
+		Message: message,
+		Locations: []Location{
+			{Line: pos.Line, Column: pos.Column},
+		},
+		Extensions: map[string]any{
+			"code": BadUserInputCode,
+		},
+	}
 }
@@ -0,0 +1,189 @@
+package executor_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor"
+	"github.com/n9te9/goliteql/query"
+)
+
+func TestShouldInclude(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]json.RawMessage
+		want      bool
+		wantErr   string
+	}{
+		{
+			name:  "no directives",
+			query: `query { user { id } }`,
+			want:  true,
+		},
+		{
+			name:  "skip literal",
+			query: `query { user @skip(if: true) { id } }`,
+			want:  false,
+		},
+		{
+			name:  "include literal",
+			query: `query { user @include(if: false) { id } }`,
+			want:  false,
+		},
+		{
+			name:      "skip variable",
+			query:     `query ($s: Boolean!) { user @skip(if: $s) { id } }`,
+			variables: map[string]json.RawMessage{"s": json.RawMessage(`false`)},
+			want:      true,
+		},
+		{
+			name:      "include variable",
+			query:     `query ($i: Boolean!) { user @include(if: $i) { id } }`,
+			variables: map[string]json.RawMessage{"i": json.RawMessage(`false`)},
+			want:      false,
+		},
+		{
+			name:      "skip wins over include",
+			query:     `query ($s: Boolean!, $i: Boolean!) { user @skip(if: $s) @include(if: $i) { id } }`,
+			variables: map[string]json.RawMessage{"s": json.RawMessage(`true`), "i": json.RawMessage(`true`)},
+			want:      false,
+		},
+		{
+			name:    "variable without runtime value",
+			query:   `query ($s: Boolean) { user @skip(if: $s) { id } }`,
+			wantErr: `Argument "if" of required type "Boolean!" was provided the variable "$s" which was not provided a runtime value.`,
+		},
+		{
+			name:      "null variable",
+			query:     `query ($i: Boolean) { user @include(if: $i) { id } }`,
+			variables: map[string]json.RawMessage{"i": json.RawMessage(`null`)},
+			wantErr:   `Argument "if" of non-null type "Boolean!" of directive "@include" must not be null.`,
+		},
+		{
+			name:    "invalid literal",
+			query:   `query { user @skip(if: 1) { id } }`,
+			wantErr: `Argument "if" of directive "@skip" has invalid value 1. Expected type "Boolean!".`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := query.NewParserWithLexer().Parse([]byte(tt.query))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			field := doc.Operations[0].Selections[0].(*query.Field)
+			got, err := executor.ShouldInclude(field.Directives, tt.variables)
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatalf("ShouldInclude() error = nil, want %q", tt.wantErr)
+				}
+
+				if d := cmp.Diff(tt.wantErr, err.Error()); d != "" {
+					t.Errorf("ShouldInclude() error mismatch (-want +got):\n%s", d)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ShouldInclude() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("ShouldInclude() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithDirectives(t *testing.T) {
+	upper := func(ctx context.Context, next func(ctx context.Context) (any, error)) (any, error) {
+		v, err := next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return strings.ToUpper(v.(string)), nil
+	}
+
+	exclaim := func(ctx context.Context, next func(ctx context.Context) (any, error)) (any, error) {
+		v, err := next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return v.(string) + "!", nil
+	}
+
+	forbidden := func(ctx context.Context, next func(ctx context.Context) (any, error)) (any, error) {
+		return nil, errors.New("forbidden")
+	}
+
+	resolve := func(ctx context.Context) (string, error) {
+		return "hello", nil
+	}
+
+	tests := []struct {
+		name       string
+		directives []executor.DirectiveFunc
+		want       string
+		wantErr    string
+	}{
+		{
+			name: "no directives",
+			want: "hello",
+		},
+		{
+			name:       "first directive is outermost",
+			directives: []executor.DirectiveFunc{exclaim, upper},
+			want:       "HELLO!",
+		},
+		{
+			name:       "directive stops resolution",
+			directives: []executor.DirectiveFunc{upper, forbidden},
+			wantErr:    "forbidden",
+		},
+		{
+			name: "unexpected value type",
+			directives: []executor.DirectiveFunc{func(ctx context.Context, next func(ctx context.Context) (any, error)) (any, error) {
+				return 1, nil
+			}},
+			wantErr: "directive returned int, expected string",
+		},
+		{
+			name: "nil for non-nillable type",
+			directives: []executor.DirectiveFunc{func(ctx context.Context, next func(ctx context.Context) (any, error)) (any, error) {
+				return nil, nil
+			}},
+			wantErr: "directive returned <nil>, expected string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := executor.WithDirectives(context.Background(), resolve, tt.directives...)
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatalf("WithDirectives() error = nil, want %q", tt.wantErr)
+				}
+
+				if d := cmp.Diff(tt.wantErr, err.Error()); d != "" {
+					t.Errorf("WithDirectives() error mismatch (-want +got):\n%s", d)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("WithDirectives() error = %v", err)
+			}
+
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("WithDirectives() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
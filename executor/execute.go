@@ -0,0 +1,229 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// FieldExecutor resolves a single root field of an operation.
+type FieldExecutor func(ctx context.Context, node *Node, variables map[string]json.RawMessage) (any, error)
+
+var errNullPropagation = errors.New("null propagated from a non-nullable field")
+
+// AddFieldError records err as a field error at the response path of ctx.
+// The returned error tells the caller that the field resolved to null; a nullable parent discards it, a non-nullable parent returns it to propagate the null.
+func AddFieldError(ctx context.Context, err error) error {
+	if errors.Is(err, errNullPropagation) {
+		return err
+	}
+
+	gqlErr := NewGraphQLError(err)
+	gqlErr.Path = PathFromContext(ctx)
+
+	if errs, ok := ctx.Value(fieldErrorsContextKey{}).(*fieldErrors); ok {
+		errs.mu.Lock()
+		errs.errs = append(errs.errs, gqlErr)
+		errs.mu.Unlock()
+	}
+
+	return errNullPropagation
+}
+
+// NewNonNullError reports that a non-nullable field resolved to null.
+func NewNonNullError(coordinate string) error {
+	return fmt.Errorf("cannot return null for non-nullable field %s", coordinate)
+}
+
+// CompleteList completes every item of a list value with its index appended to the response path.
+// A failed item becomes null when items are nullable, otherwise the whole list fails.
+func CompleteList[T any](ctx context.Context, items []T, nullableItems bool, complete func(context.Context, T) (Nullable, error)) ([]Nullable, error) {
+	ret := make([]Nullable, 0, len(items))
+	for i, item := range items {
+		itemCtx := WithPath(ctx, i)
+		v, err := complete(itemCtx, item)
+		if err != nil {
+			if !nullableItems {
+				return nil, AddFieldError(itemCtx, err)
+			}
+
+			AddFieldError(itemCtx, err)
+			v = NewNullable(nil)
+		}
+		ret = append(ret, v)
+	}
+
+	return ret, nil
+}
+
+// ListConcurrency bounds the number of items CompleteListConcurrently completes at once.
+var ListConcurrency = 64
+
+// CompleteListConcurrently completes the items of a list value like CompleteList, but with up to ListConcurrency items at once, so that the loads of the items are batched together.
+func CompleteListConcurrently[T any](ctx context.Context, items []T, nullableItems bool, complete func(context.Context, T) (Nullable, error)) ([]Nullable, error) {
+	ret := make([]Nullable, len(items))
+	errs := make([]error, len(items))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(len(items), max(ListConcurrency, 1)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				ret[i], errs[i] = completeListItem(WithPath(ctx, i), items[i], i, complete)
+			}
+		}()
+	}
+
+	for i := range items {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		itemCtx := WithPath(ctx, i)
+		if !nullableItems {
+			return nil, AddFieldError(itemCtx, err)
+		}
+
+		AddFieldError(itemCtx, err)
+		ret[i] = NewNullable(nil)
+	}
+
+	return ret, nil
+}
+
+func completeListItem[T any](ctx context.Context, item T, i int, complete func(context.Context, T) (Nullable, error)) (ret Nullable, err error) {
+	defer recoverPanic(&err, fmt.Sprintf("list item %d", i))
+
+	return complete(ctx, item)
+}
+
+// FieldResolver resolves the value of a selected field of an object.
+type FieldResolver func(ctx context.Context, node *Node) (any, error)
+
+// ResolvedField is the value of a field resolved by ResolveFields.
+type ResolvedField struct {
+	value any
+	err   error
+}
+
+// ResolveFields calls the resolvers of the selected fields concurrently, so that the loads of sibling fields are batched together.
+// The results are in the order of fields, and a field without a resolver resolves to nothing.
+func ResolveFields(ctx context.Context, fields []*Node, resolvers map[string]FieldResolver) []ResolvedField {
+	ret := make([]ResolvedField, len(fields))
+
+	var wg sync.WaitGroup
+	for i, field := range fields {
+		resolve, ok := resolvers[field.Name]
+		if !ok {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer recoverPanic(&ret[i].err, fmt.Sprintf("field %q", field.Name))
+
+			ret[i].value, ret[i].err = resolve(WithPath(ctx, field.ResponseKey()), field)
+		}()
+	}
+	wg.Wait()
+
+	return ret
+}
+
+// ResolvedValue returns the value of a field resolved by ResolveFields.
+func ResolvedValue[T any](field ResolvedField) (T, error) {
+	v, _ := field.value.(T)
+	return v, field.err
+}
+
+// recoverPanic reports a panic of a goroutine completing a value as its error, since no caller up the stack of the goroutine recovers it.
+func recoverPanic(err *error, target string) {
+	if p := recover(); p != nil {
+		*err = fmt.Errorf("panic in %s: %v", target, p)
+	}
+}
+
+type fieldResult struct {
+	value any
+	errs  []GraphQLError
+	err   error
+}
+
+// ExecuteParallel executes the root fields of a query concurrently and merges their results into one response.
+func ExecuteParallel(ctx context.Context, nodes []*Node, variables map[string]json.RawMessage, execute FieldExecutor) *GraphQLResponse {
+	results := make([]fieldResult, len(nodes))
+
+	var wg sync.WaitGroup
+	for i, node := range nodes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = executeField(ctx, node, variables, execute)
+		}()
+	}
+	wg.Wait()
+
+	return mergeFieldResults(nodes, results)
+}
+
+// ExecuteSerially executes the root fields of a mutation one after another, so that each field observes the side effects of the previous ones.
+func ExecuteSerially(ctx context.Context, nodes []*Node, variables map[string]json.RawMessage, execute FieldExecutor) *GraphQLResponse {
+	results := make([]fieldResult, len(nodes))
+	for i, node := range nodes {
+		results[i] = executeField(ctx, node, variables, execute)
+	}
+
+	return mergeFieldResults(nodes, results)
+}
+
+func executeField(ctx context.Context, node *Node, variables map[string]json.RawMessage, execute FieldExecutor) (ret fieldResult) {
+	errs := &fieldErrors{}
+	ctx = withFieldErrors(WithPath(ctx, node.ResponseKey()), errs)
+
+	defer func() {
+		if p := recover(); p != nil {
+			ret.value, ret.err = nil, AddFieldError(ctx, fmt.Errorf("panic in field %q: %v", node.Name, p))
+		}
+
+		if ret.err != nil {
+			ret.err = AddFieldError(ctx, ret.err)
+		}
+		ret.errs = errs.errs
+	}()
+
+	value, err := execute(ctx, node, variables)
+	return fieldResult{value: value, err: err}
+}
+
+// mergeFieldResults builds the response in the order of the root fields.
+// A root field that failed is non-nullable, so its null propagates to data.
+func mergeFieldResults(nodes []*Node, results []fieldResult) *GraphQLResponse {
+	resp := &GraphQLResponse{
+		Data: NewObject(),
+	}
+
+	for i, node := range nodes {
+		resp.Errors = append(resp.Errors, results[i].errs...)
+		if results[i].err != nil {
+			resp.Data = nil
+		}
+
+		if resp.Data != nil {
+			resp.Data.Set(node.ResponseKey(), NewNullable(results[i].value))
+		}
+	}
+
+	return resp
+}
@@ -0,0 +1,382 @@
+package executor_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor"
+)
+
+func TestExecuteParallel(t *testing.T) {
+	nodes := []*executor.Node{
+		{Name: "a"},
+		{Name: "b", Alias: "c"},
+	}
+
+	var started sync.WaitGroup
+	started.Add(2)
+	execute := func(ctx context.Context, node *executor.Node, variables map[string]json.RawMessage) (any, error) {
+		// both fields have to be in flight at the same time to return
+		started.Done()
+		started.Wait()
+		return node.Name + string(variables["v"]), nil
+	}
+
+	done := make(chan *executor.GraphQLResponse)
+	go func() {
+		done <- executor.ExecuteParallel(context.Background(), nodes, map[string]json.RawMessage{"v": json.RawMessage(`1`)}, execute)
+	}()
+
+	var resp *executor.GraphQLResponse
+	select {
+	case resp = <-done:
+	case <-time.After(time.Second):
+		t.Fatal("ExecuteParallel() did not execute root fields concurrently")
+	}
+
+	got, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d := cmp.Diff(`{"data":{"a":"a1","c":"b1"}}`, string(got)); d != "" {
+		t.Errorf("ExecuteParallel() mismatch (-want +got):\n%s", d)
+	}
+}
+
+func TestExecuteParallel_FieldErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		nodes   []*executor.Node
+		execute executor.FieldExecutor
+		want    string
+	}{
+		{
+			name:  "nullable field error keeps sibling data",
+			nodes: []*executor.Node{{Name: "user"}, {Name: "posts"}},
+			execute: func(ctx context.Context, node *executor.Node, variables map[string]json.RawMessage) (any, error) {
+				if node.Name == "user" {
+					return "u1", nil
+				}
+
+				items, err := executor.CompleteList(ctx, []string{"p1", "p2"}, true, func(ctx context.Context, v string) (executor.Nullable, error) {
+					if v == "p2" {
+						return nil, errors.New("failed to resolve post")
+					}
+					return executor.NewNullable(v), nil
+				})
+				return items, err
+			},
+			want: `{"data":{"user":"u1","posts":["p1",null]},"errors":[{"message":"failed to resolve post","path":["posts",1]}]}`,
+		},
+		{
+			name:  "data keeps the selection order of root fields",
+			nodes: []*executor.Node{{Name: "me", Alias: "zz"}, {Name: "now", Alias: "aa"}, {Name: "posts"}},
+			execute: func(ctx context.Context, node *executor.Node, variables map[string]json.RawMessage) (any, error) {
+				return node.Name, nil
+			},
+			want: `{"data":{"zz":"me","aa":"now","posts":"posts"}}`,
+		},
+		{
+			name:  "non-null root field error nulls data",
+			nodes: []*executor.Node{{Name: "user"}, {Name: "viewer", Alias: "me"}},
+			execute: func(ctx context.Context, node *executor.Node, variables map[string]json.RawMessage) (any, error) {
+				if node.Name == "user" {
+					return "u1", nil
+				}
+
+				return nil, executor.GraphQLError{Message: "forbidden", Extensions: map[string]any{"code": "FORBIDDEN"}}
+			},
+			want: `{"data":null,"errors":[{"message":"forbidden","path":["me"],"extensions":{"code":"FORBIDDEN"}}]}`,
+		},
+		{
+			name:  "panic is reported as a field error",
+			nodes: []*executor.Node{{Name: "panic"}},
+			execute: func(ctx context.Context, node *executor.Node, variables map[string]json.RawMessage) (any, error) {
+				panic("boom")
+			},
+			want: `{"data":null,"errors":[{"message":"panic in field \"panic\": boom","path":["panic"]}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(executor.ExecuteParallel(context.Background(), tt.nodes, nil, tt.execute))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if d := cmp.Diff(tt.want, string(got)); d != "" {
+				t.Errorf("ExecuteParallel() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestCompleteList(t *testing.T) {
+	complete := func(ctx context.Context, v []string) (executor.Nullable, error) {
+		items, err := executor.CompleteList(ctx, v, false, func(ctx context.Context, v string) (executor.Nullable, error) {
+			if v == "" {
+				return nil, executor.AddFieldError(ctx, errors.New("empty"))
+			}
+			return executor.NewNullable(v), nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return executor.NewNullable(items), nil
+	}
+
+	tests := []struct {
+		name          string
+		items         [][]string
+		nullableItems bool
+		want          string
+		wantErr       bool
+	}{
+		{
+			name:          "nullable outer items absorb inner failures",
+			items:         [][]string{{"a"}, {"b", ""}},
+			nullableItems: true,
+			want:          `[["a"],null]`,
+		},
+		{
+			name:    "non-null outer items propagate inner failures",
+			items:   [][]string{{"a"}, {"b", ""}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := executor.CompleteList(executor.WithPath(context.Background(), "lists"), tt.items, tt.nullableItems, complete)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("CompleteList() error = nil, want error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("CompleteList() error = %v", err)
+			}
+
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if d := cmp.Diff(tt.want, string(b)); d != "" {
+				t.Errorf("CompleteList() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestCompleteListConcurrently(t *testing.T) {
+	t.Run("items are completed concurrently", func(t *testing.T) {
+		items := []string{"a", "b", "c"}
+
+		var started sync.WaitGroup
+		started.Add(len(items))
+		complete := func(ctx context.Context, v string) (executor.Nullable, error) {
+			// every item has to be in flight at the same time to return
+			started.Done()
+			started.Wait()
+			return executor.NewNullable(v), nil
+		}
+
+		done := make(chan []executor.Nullable)
+		go func() {
+			got, _ := executor.CompleteListConcurrently(context.Background(), items, false, complete)
+			done <- got
+		}()
+
+		var got []executor.Nullable
+		select {
+		case got = <-done:
+		case <-time.After(time.Second):
+			t.Fatal("CompleteListConcurrently() did not complete items concurrently")
+		}
+
+		b, err := json.Marshal(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if d := cmp.Diff(`["a","b","c"]`, string(b)); d != "" {
+			t.Errorf("CompleteListConcurrently() mismatch (-want +got):\n%s", d)
+		}
+	})
+
+	t.Run("items in flight are bounded by ListConcurrency", func(t *testing.T) {
+		listConcurrency := executor.ListConcurrency
+		executor.ListConcurrency = 2
+		t.Cleanup(func() { executor.ListConcurrency = listConcurrency })
+
+		var inFlight, maxInFlight atomic.Int32
+		complete := func(ctx context.Context, v int) (executor.Nullable, error) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+
+			for {
+				m := maxInFlight.Load()
+				if n <= m || maxInFlight.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+
+			return executor.NewNullable(v), nil
+		}
+
+		got, err := executor.CompleteListConcurrently(context.Background(), []int{1, 2, 3, 4, 5}, false, complete)
+		if err != nil {
+			t.Fatalf("CompleteListConcurrently() error = %v", err)
+		}
+
+		b, err := json.Marshal(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if d := cmp.Diff(`[1,2,3,4,5]`, string(b)); d != "" {
+			t.Errorf("CompleteListConcurrently() mismatch (-want +got):\n%s", d)
+		}
+
+		if n := maxInFlight.Load(); n > 2 {
+			t.Errorf("CompleteListConcurrently() completed %d items at once, want at most 2", n)
+		}
+	})
+
+	t.Run("failed items", func(t *testing.T) {
+		complete := func(ctx context.Context, v string) (executor.Nullable, error) {
+			if v == "" {
+				return nil, errors.New("empty")
+			}
+			return executor.NewNullable(v), nil
+		}
+
+		got, err := executor.CompleteListConcurrently(context.Background(), []string{"a", ""}, true, complete)
+		if err != nil {
+			t.Fatalf("CompleteListConcurrently() error = %v", err)
+		}
+
+		b, err := json.Marshal(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if d := cmp.Diff(`["a",null]`, string(b)); d != "" {
+			t.Errorf("CompleteListConcurrently() mismatch (-want +got):\n%s", d)
+		}
+
+		if _, err := executor.CompleteListConcurrently(context.Background(), []string{"a", ""}, false, complete); err == nil {
+			t.Error("CompleteListConcurrently() error = nil, want error")
+		}
+	})
+}
+
+func TestResolveFields(t *testing.T) {
+	fields := []*executor.Node{
+		{Name: "id"},
+		{Name: "author"},
+		{Name: "reviewer", Alias: "editor"},
+		{Name: "broken"},
+	}
+
+	var started sync.WaitGroup
+	started.Add(2)
+	resolveUser := func(ctx context.Context, node *executor.Node) (any, error) {
+		// sibling resolvers have to be in flight at the same time to return
+		started.Done()
+		started.Wait()
+		return node.ResponseKey(), nil
+	}
+	resolvers := map[string]executor.FieldResolver{
+		"author":   resolveUser,
+		"reviewer": resolveUser,
+		"broken": func(ctx context.Context, node *executor.Node) (any, error) {
+			panic("boom")
+		},
+	}
+
+	done := make(chan []executor.ResolvedField)
+	go func() {
+		done <- executor.ResolveFields(context.Background(), fields, resolvers)
+	}()
+
+	var resolved []executor.ResolvedField
+	select {
+	case resolved = <-done:
+	case <-time.After(time.Second):
+		t.Fatal("ResolveFields() did not resolve sibling fields concurrently")
+	}
+
+	tests := []struct {
+		name    string
+		field   executor.ResolvedField
+		want    string
+		wantErr string
+	}{
+		{name: "field without resolver", field: resolved[0]},
+		{name: "resolved field", field: resolved[1], want: "author"},
+		{name: "aliased field", field: resolved[2], want: "editor"},
+		{name: "panicking resolver", field: resolved[3], wantErr: `panic in field "broken": boom`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := executor.ResolvedValue[string](tt.field)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("ResolvedValue() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ResolvedValue() error = %v", err)
+			}
+
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("ResolvedValue() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestExecuteSerially(t *testing.T) {
+	nodes := []*executor.Node{
+		{Name: "first"},
+		{Name: "second"},
+		{Name: "third"},
+	}
+
+	order := make([]string, 0, len(nodes))
+	execute := func(ctx context.Context, node *executor.Node, variables map[string]json.RawMessage) (any, error) {
+		order = append(order, node.Name)
+		return len(order), nil
+	}
+
+	resp := executor.ExecuteSerially(context.Background(), nodes, nil, execute)
+
+	if d := cmp.Diff([]string{"first", "second", "third"}, order); d != "" {
+		t.Errorf("ExecuteSerially() order mismatch (-want +got):\n%s", d)
+	}
+
+	got, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d := cmp.Diff(`{"data":{"first":1,"second":2,"third":3}}`, string(got)); d != "" {
+		t.Errorf("ExecuteSerially() mismatch (-want +got):\n%s", d)
+	}
+}
@@ -0,0 +1,609 @@
+package executor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/n9te9/goliteql/schema"
+)
+
+type Introspection struct {
+	schema    *schema.Schema
+	types     []*introspectionType
+	typeIndex map[string]*introspectionType
+}
+
+type introspectionType struct {
+	kind           string
+	name           []byte
+	description    []byte
+	specifiedByURL []byte
+	fields         schema.FieldDefinitions
+	interfaces     [][]byte
+	possibleTypes  [][]byte
+	enumValues     []*schema.EnumElement
+	inputFields    schema.FieldDefinitions
+	ofType         *introspectionType
+}
+
+type introspectionInputValue struct {
+	name         []byte
+	description  []byte
+	defaultValue []byte
+	fieldType    *schema.FieldType
+	directives   schema.Directives
+}
+
+func NewIntrospection(s *schema.Schema) *Introspection {
+	i := &Introspection{
+		schema:    s,
+		types:     make([]*introspectionType, 0),
+		typeIndex: make(map[string]*introspectionType),
+	}
+
+	for _, op := range s.Operations {
+		i.addType(&introspectionType{kind: "OBJECT", name: rootTypeName(s.Definition, op.OperationType), description: op.Description, fields: op.Fields})
+	}
+
+	for _, t := range s.Types {
+		i.addType(&introspectionType{kind: "OBJECT", name: t.Name, description: t.Description, fields: t.Fields, interfaces: t.Interfaces})
+	}
+
+	for _, iface := range s.Interfaces {
+		possibleTypes := make([][]byte, 0)
+		for _, t := range s.Types {
+			for _, name := range t.Interfaces {
+				if bytes.Equal(name, iface.Name) {
+					possibleTypes = append(possibleTypes, t.Name)
+				}
+			}
+		}
+
+		i.addType(&introspectionType{kind: "INTERFACE", name: iface.Name, description: iface.Description, fields: iface.Fields, interfaces: iface.Interfaces, possibleTypes: possibleTypes})
+	}
+
+	for _, u := range s.Unions {
+		i.addType(&introspectionType{kind: "UNION", name: u.Name, description: u.Description, possibleTypes: u.Types})
+	}
+
+	for _, e := range s.Enums {
+		i.addType(&introspectionType{kind: "ENUM", name: e.Name, description: e.Description, enumValues: e.Values})
+	}
+
+	for _, input := range s.Inputs {
+		i.addType(&introspectionType{kind: "INPUT_OBJECT", name: input.Name, description: input.Description, inputFields: input.Fields})
+	}
+
+	for _, scalar := range s.Scalars {
+		i.addType(&introspectionType{kind: "SCALAR", name: scalar.Name, description: scalar.Description, specifiedByURL: specifiedByURL(scalar.Directives)})
+	}
+
+	return i
+}
+
+func (i *Introspection) addType(t *introspectionType) {
+	if _, ok := i.typeIndex[string(t.name)]; ok {
+		return
+	}
+
+	i.types = append(i.types, t)
+	i.typeIndex[string(t.name)] = t
+}
+
+func (i *Introspection) Resolve(node *Node, variables map[string]json.RawMessage) (any, error) {
+	switch node.Name {
+	case "__schema":
+		return i.resolveSchema(node, variables)
+	case "__type":
+		name, err := stringArgument(node, "name", variables)
+		if err != nil {
+			return nil, err
+		}
+
+		t, ok := i.typeIndex[name]
+		if !ok {
+			return nil, nil
+		}
+
+		return i.resolveType(t, node, variables)
+	}
+
+	return nil, fmt.Errorf("unknown introspection field %s", node.Name)
+}
+
+func (i *Introspection) resolveSchema(node *Node, variables map[string]json.RawMessage) (any, error) {
+	ret := NewObject()
+	for _, child := range collectIntrospectionFields(node) {
+		switch child.Name {
+		case "__typename":
+			ret.Set(child.ResponseKey(), "__Schema")
+		case "description":
+			ret.Set(child.ResponseKey(), nullableString(i.schema.Definition.Description))
+		case "types":
+			types := make([]any, 0, len(i.types))
+			for _, t := range i.types {
+				v, err := i.resolveType(t, child, variables)
+				if err != nil {
+					return nil, err
+				}
+				types = append(types, v)
+			}
+			ret.Set(child.ResponseKey(), types)
+		case "queryType", "mutationType", "subscriptionType":
+			v, err := i.resolveRootType(child, variables)
+			if err != nil {
+				return nil, err
+			}
+			ret.Set(child.ResponseKey(), v)
+		case "directives":
+			directives := make([]any, 0, len(i.schema.Directives))
+			for _, d := range i.schema.Directives {
+				v, err := i.resolveDirective(d, child, variables)
+				if err != nil {
+					return nil, err
+				}
+				directives = append(directives, v)
+			}
+			ret.Set(child.ResponseKey(), directives)
+		default:
+			return nil, fmt.Errorf("cannot query field %s on type __Schema", child.Name)
+		}
+	}
+
+	return ret, nil
+}
+
+func (i *Introspection) resolveRootType(node *Node, variables map[string]json.RawMessage) (any, error) {
+	var operationType schema.OperationType
+	switch node.Name {
+	case "queryType":
+		operationType = schema.QueryOperation
+	case "mutationType":
+		operationType = schema.MutationOperation
+	case "subscriptionType":
+		operationType = schema.SubscriptionOperation
+	}
+
+	return i.resolveType(i.typeIndex[string(rootTypeName(i.schema.Definition, operationType))], node, variables)
+}
+
+func rootTypeName(definition *schema.SchemaDefinition, operationType schema.OperationType) []byte {
+	switch operationType {
+	case schema.MutationOperation:
+		return definition.Mutation
+	case schema.SubscriptionOperation:
+		return definition.Subscription
+	}
+
+	return definition.Query
+}
+
+func (i *Introspection) typeRef(fieldType *schema.FieldType) *introspectionType {
+	var ret *introspectionType
+	if fieldType.IsList {
+		ret = &introspectionType{kind: "LIST", ofType: i.typeRef(fieldType.ListType)}
+	} else if t, ok := i.typeIndex[string(fieldType.Name)]; ok {
+		ret = t
+	} else {
+		ret = &introspectionType{kind: "SCALAR", name: fieldType.Name}
+	}
+
+	if !fieldType.Nullable {
+		ret = &introspectionType{kind: "NON_NULL", ofType: ret}
+	}
+
+	return ret
+}
+
+func (i *Introspection) resolveType(t *introspectionType, node *Node, variables map[string]json.RawMessage) (any, error) {
+	if t == nil {
+		return nil, nil
+	}
+
+	ret := NewObject()
+	for _, child := range collectIntrospectionFields(node) {
+		switch child.Name {
+		case "__typename":
+			ret.Set(child.ResponseKey(), "__Type")
+		case "kind":
+			ret.Set(child.ResponseKey(), t.kind)
+		case "name":
+			ret.Set(child.ResponseKey(), nullableString(t.name))
+		case "description":
+			ret.Set(child.ResponseKey(), nullableString(t.description))
+		case "specifiedByURL":
+			ret.Set(child.ResponseKey(), nullableString(t.specifiedByURL))
+		case "fields":
+			if t.kind != "OBJECT" && t.kind != "INTERFACE" {
+				ret.Set(child.ResponseKey(), nil)
+				continue
+			}
+
+			includeDeprecated, err := boolArgument(child, "includeDeprecated", variables)
+			if err != nil {
+				return nil, err
+			}
+
+			fields := make([]any, 0, len(t.fields))
+			for _, f := range t.fields {
+				if bytes.HasPrefix(f.Name, []byte("__")) {
+					continue
+				}
+
+				if isDeprecated(f.Directives) && !includeDeprecated {
+					continue
+				}
+
+				v, err := i.resolveField(f, child, variables)
+				if err != nil {
+					return nil, err
+				}
+				fields = append(fields, v)
+			}
+			ret.Set(child.ResponseKey(), fields)
+		case "interfaces":
+			if t.kind != "OBJECT" && t.kind != "INTERFACE" {
+				ret.Set(child.ResponseKey(), nil)
+				continue
+			}
+
+			v, err := i.resolveNamedTypes(t.interfaces, child, variables)
+			if err != nil {
+				return nil, err
+			}
+			ret.Set(child.ResponseKey(), v)
+		case "possibleTypes":
+			if t.kind != "INTERFACE" && t.kind != "UNION" {
+				ret.Set(child.ResponseKey(), nil)
+				continue
+			}
+
+			v, err := i.resolveNamedTypes(t.possibleTypes, child, variables)
+			if err != nil {
+				return nil, err
+			}
+			ret.Set(child.ResponseKey(), v)
+		case "enumValues":
+			if t.kind != "ENUM" {
+				ret.Set(child.ResponseKey(), nil)
+				continue
+			}
+
+			includeDeprecated, err := boolArgument(child, "includeDeprecated", variables)
+			if err != nil {
+				return nil, err
+			}
+
+			values := make([]any, 0, len(t.enumValues))
+			for _, e := range t.enumValues {
+				if isDeprecated(e.Directives) && !includeDeprecated {
+					continue
+				}
+
+				v, err := resolveEnumValue(e, child)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, v)
+			}
+			ret.Set(child.ResponseKey(), values)
+		case "inputFields":
+			if t.kind != "INPUT_OBJECT" {
+				ret.Set(child.ResponseKey(), nil)
+				continue
+			}
+
+			inputValues := make([]*introspectionInputValue, 0, len(t.inputFields))
+			for _, f := range t.inputFields {
+				inputValues = append(inputValues, &introspectionInputValue{
+					name:         f.Name,
+					description:  f.Description,
+					defaultValue: f.Default,
+					fieldType:    f.Type,
+					directives:   f.Directives,
+				})
+			}
+
+			v, err := i.resolveInputValues(inputValues, child, variables)
+			if err != nil {
+				return nil, err
+			}
+			ret.Set(child.ResponseKey(), v)
+		case "ofType":
+			v, err := i.resolveType(t.ofType, child, variables)
+			if err != nil {
+				return nil, err
+			}
+			ret.Set(child.ResponseKey(), v)
+		case "isOneOf":
+			if t.kind != "INPUT_OBJECT" {
+				ret.Set(child.ResponseKey(), nil)
+				continue
+			}
+			ret.Set(child.ResponseKey(), false)
+		default:
+			return nil, fmt.Errorf("cannot query field %s on type __Type", child.Name)
+		}
+	}
+
+	return ret, nil
+}
+
+func (i *Introspection) resolveNamedTypes(names [][]byte, node *Node, variables map[string]json.RawMessage) (any, error) {
+	ret := make([]any, 0, len(names))
+	for _, name := range names {
+		v, err := i.resolveType(i.typeRef(&schema.FieldType{Name: name, Nullable: true}), node, variables)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, v)
+	}
+
+	return ret, nil
+}
+
+func (i *Introspection) resolveField(f *schema.FieldDefinition, node *Node, variables map[string]json.RawMessage) (any, error) {
+	ret := NewObject()
+	for _, child := range collectIntrospectionFields(node) {
+		switch child.Name {
+		case "__typename":
+			ret.Set(child.ResponseKey(), "__Field")
+		case "name":
+			ret.Set(child.ResponseKey(), string(f.Name))
+		case "description":
+			ret.Set(child.ResponseKey(), nullableString(f.Description))
+		case "args":
+			v, err := i.resolveInputValues(argumentInputValues(f.Arguments), child, variables)
+			if err != nil {
+				return nil, err
+			}
+			ret.Set(child.ResponseKey(), v)
+		case "type":
+			v, err := i.resolveType(i.typeRef(f.Type), child, variables)
+			if err != nil {
+				return nil, err
+			}
+			ret.Set(child.ResponseKey(), v)
+		case "isDeprecated":
+			ret.Set(child.ResponseKey(), isDeprecated(f.Directives))
+		case "deprecationReason":
+			ret.Set(child.ResponseKey(), deprecationReason(f.Directives))
+		default:
+			return nil, fmt.Errorf("cannot query field %s on type __Field", child.Name)
+		}
+	}
+
+	return ret, nil
+}
+
+func argumentInputValues(args []*schema.ArgumentDefinition) []*introspectionInputValue {
+	ret := make([]*introspectionInputValue, 0, len(args))
+	for _, arg := range args {
+		ret = append(ret, &introspectionInputValue{
+			name:         arg.Name,
+			description:  arg.Description,
+			defaultValue: arg.Default,
+			fieldType:    arg.Type,
+		})
+	}
+
+	return ret
+}
+
+func (i *Introspection) resolveInputValues(inputValues []*introspectionInputValue, node *Node, variables map[string]json.RawMessage) (any, error) {
+	includeDeprecated, err := boolArgument(node, "includeDeprecated", variables)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]any, 0, len(inputValues))
+	for _, inputValue := range inputValues {
+		if isDeprecated(inputValue.directives) && !includeDeprecated {
+			continue
+		}
+
+		v, err := i.resolveInputValue(inputValue, node, variables)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, v)
+	}
+
+	return ret, nil
+}
+
+func (i *Introspection) resolveInputValue(inputValue *introspectionInputValue, node *Node, variables map[string]json.RawMessage) (any, error) {
+	ret := NewObject()
+	for _, child := range collectIntrospectionFields(node) {
+		switch child.Name {
+		case "__typename":
+			ret.Set(child.ResponseKey(), "__InputValue")
+		case "name":
+			ret.Set(child.ResponseKey(), string(inputValue.name))
+		case "description":
+			ret.Set(child.ResponseKey(), nullableString(inputValue.description))
+		case "type":
+			v, err := i.resolveType(i.typeRef(inputValue.fieldType), child, variables)
+			if err != nil {
+				return nil, err
+			}
+			ret.Set(child.ResponseKey(), v)
+		case "defaultValue":
+			ret.Set(child.ResponseKey(), nullableString(inputValue.defaultValue))
+		case "isDeprecated":
+			ret.Set(child.ResponseKey(), isDeprecated(inputValue.directives))
+		case "deprecationReason":
+			ret.Set(child.ResponseKey(), deprecationReason(inputValue.directives))
+		default:
+			return nil, fmt.Errorf("cannot query field %s on type __InputValue", child.Name)
+		}
+	}
+
+	return ret, nil
+}
+
+func resolveEnumValue(e *schema.EnumElement, node *Node) (any, error) {
+	ret := NewObject()
+	for _, child := range collectIntrospectionFields(node) {
+		switch child.Name {
+		case "__typename":
+			ret.Set(child.ResponseKey(), "__EnumValue")
+		case "name":
+			ret.Set(child.ResponseKey(), string(e.Value))
+		case "description":
+			ret.Set(child.ResponseKey(), nullableString(e.Description))
+		case "isDeprecated":
+			ret.Set(child.ResponseKey(), isDeprecated(e.Directives))
+		case "deprecationReason":
+			ret.Set(child.ResponseKey(), deprecationReason(e.Directives))
+		default:
+			return nil, fmt.Errorf("cannot query field %s on type __EnumValue", child.Name)
+		}
+	}
+
+	return ret, nil
+}
+
+func (i *Introspection) resolveDirective(d *schema.DirectiveDefinition, node *Node, variables map[string]json.RawMessage) (any, error) {
+	ret := NewObject()
+	for _, child := range collectIntrospectionFields(node) {
+		switch child.Name {
+		case "__typename":
+			ret.Set(child.ResponseKey(), "__Directive")
+		case "name":
+			ret.Set(child.ResponseKey(), string(d.Name))
+		case "description":
+			ret.Set(child.ResponseKey(), nullableString(d.Description))
+		case "isRepeatable":
+			ret.Set(child.ResponseKey(), d.Repeatable)
+		case "locations":
+			locations := make([]any, 0, len(d.Locations))
+			for _, l := range d.Locations {
+				locations = append(locations, string(l.Name))
+			}
+			ret.Set(child.ResponseKey(), locations)
+		case "args":
+			v, err := i.resolveInputValues(argumentInputValues(d.Arguments), child, variables)
+			if err != nil {
+				return nil, err
+			}
+			ret.Set(child.ResponseKey(), v)
+		default:
+			return nil, fmt.Errorf("cannot query field %s on type __Directive", child.Name)
+		}
+	}
+
+	return ret, nil
+}
+
+// collectIntrospectionFields flattens fragments and merges fields selected more than once,
+// since every fragment inside an introspection selection targets the same meta type.
+func collectIntrospectionFields(node *Node) []*Node {
+	return collectFields(node.Children, func(string) bool {
+		return true
+	})
+}
+
+func specifiedByURL(directives []*schema.Directive) []byte {
+	for _, d := range directives {
+		if string(d.Name) != "specifiedBy" {
+			continue
+		}
+
+		for _, arg := range d.Arguments {
+			if string(arg.Name) == "url" {
+				return unquote(arg.Value)
+			}
+		}
+	}
+
+	return nil
+}
+
+func isDeprecated(directives schema.Directives) bool {
+	return directives.Get([]byte("deprecated")) != nil
+}
+
+func deprecationReason(directives schema.Directives) any {
+	d := directives.Get([]byte("deprecated"))
+	if d == nil {
+		return nil
+	}
+
+	for _, arg := range d.Arguments {
+		if string(arg.Name) == "reason" {
+			return string(unquote(arg.Value))
+		}
+	}
+
+	return "No longer supported"
+}
+
+func nullableString(value []byte) any {
+	if len(value) == 0 {
+		return nil
+	}
+
+	return string(value)
+}
+
+func unquote(value []byte) []byte {
+	if s, err := strconv.Unquote(string(value)); err == nil {
+		return []byte(s)
+	}
+
+	return value
+}
+
+func stringArgument(node *Node, name string, variables map[string]json.RawMessage) (string, error) {
+	for _, arg := range node.Arguments {
+		if string(arg.Name) != name {
+			continue
+		}
+
+		if arg.IsVariable() {
+			var ret string
+			if err := json.Unmarshal(variables[arg.VariableAnnotation()], &ret); err != nil {
+				return "", fmt.Errorf("argument %s must be String: %w", name, err)
+			}
+
+			return ret, nil
+		}
+
+		ret, err := strconv.Unquote(string(arg.Value))
+		if err != nil {
+			return "", fmt.Errorf("argument %s must be String: %w", name, err)
+		}
+
+		return ret, nil
+	}
+
+	return "", fmt.Errorf("argument %s is not provided", name)
+}
+
+func boolArgument(node *Node, name string, variables map[string]json.RawMessage) (bool, error) {
+	for _, arg := range node.Arguments {
+		if string(arg.Name) != name {
+			continue
+		}
+
+		value := arg.Value
+		if arg.IsVariable() {
+			value = variables[arg.VariableAnnotation()]
+			if len(value) == 0 || string(value) == "null" {
+				return false, nil
+			}
+		}
+
+		ret, err := strconv.ParseBool(string(value))
+		if err != nil {
+			return false, fmt.Errorf("argument %s must be Boolean: %w", name, err)
+		}
+
+		return ret, nil
+	}
+
+	return false, nil
+}
@@ -0,0 +1,105 @@
+package executor_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/n9te9/goliteql/executor"
+	"github.com/n9te9/goliteql/query"
+	"github.com/n9te9/goliteql/schema"
+)
+
+func TestIntrospection_Resolve(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]json.RawMessage
+		want      string
+	}{
+		{
+			name:  "type by literal name",
+			query: `query { __type(name: "User") { __typename kind name fields { name type { kind ofType { name } } } } }`,
+			want:  `{"__typename":"__Type","kind":"OBJECT","name":"User","fields":[{"name":"id","type":{"kind":"NON_NULL","ofType":{"name":"ID"}}},{"name":"name","type":{"kind":"SCALAR","ofType":null}}]}`,
+		},
+		{
+			name:      "type by variable name",
+			query:     `query Q($name: String!) { __type(name: $name) { name enumValues { name } } }`,
+			variables: map[string]json.RawMessage{"name": json.RawMessage(`"Role"`)},
+			want:      `{"name":"Role","enumValues":[{"name":"ADMIN"}]}`,
+		},
+		{
+			name:  "include deprecated values",
+			query: `query { __type(name: "Role") { enumValues(includeDeprecated: true) { name isDeprecated deprecationReason } } }`,
+			want:  `{"enumValues":[{"name":"ADMIN","isDeprecated":false,"deprecationReason":null},{"name":"GUEST","isDeprecated":true,"deprecationReason":"use ADMIN"}]}`,
+		},
+		{
+			name:  "merge fields selected by fragments",
+			query: `query { __type(name: "User") { name ...F } } fragment F on __Type { name kind }`,
+			want:  `{"name":"User","kind":"OBJECT"}`,
+		},
+		{
+			name:  "unknown type is null",
+			query: `query { __type(name: "Unknown") { name } }`,
+			want:  `null`,
+		},
+		{
+			name:  "root types",
+			query: `query { __schema { queryType { name } mutationType { name } } }`,
+			want:  `{"queryType":{"name":"Query"},"mutationType":null}`,
+		},
+	}
+
+	s, err := schema.NewParser(schema.NewLexer()).Parse([]byte(`
+		enum Role {
+			ADMIN
+			GUEST @deprecated(reason: "use ADMIN")
+		}
+
+		type User {
+			id: ID!
+			name: String
+		}
+
+		type Query {
+			user: User
+		}
+	`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = s.Merge()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	introspection := executor.NewIntrospection(s)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := query.NewParserWithLexer().Parse([]byte(tt.query))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			nodes, err := executor.PlanExecution(doc.Operations[0].Selections, doc.FragmentDefinitions, tt.variables)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := introspection.Resolve(nodes[0], tt.variables)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(b) != tt.want {
+				t.Errorf("Resolve() = %s, want %s", b, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package executor
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Object is a response object that keeps its fields in the order they were selected.
+type Object struct {
+	keys    []string
+	values  []any
+	indexes map[string]int
+}
+
+func NewObject() *Object {
+	return &Object{
+		indexes: make(map[string]int),
+	}
+}
+
+// Set sets the value of key, replacing the previous value in place if key is already set.
+func (o *Object) Set(key string, value any) {
+	if idx, ok := o.indexes[key]; ok {
+		o.values[idx] = value
+		return
+	}
+
+	o.indexes[key] = len(o.keys)
+	o.keys = append(o.keys, key)
+	o.values = append(o.values, value)
+}
+
+func (o *Object) MarshalJSON() ([]byte, error) {
+	if o == nil {
+		return []byte("null"), nil
+	}
+
+	buf := bytes.NewBuffer([]byte{'{'})
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+
+		v, err := json.Marshal(o.values[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,63 @@
+package executor_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor"
+)
+
+func TestObject_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		object   func() *executor.Object
+		expected string
+	}{
+		{
+			name:     "nil object",
+			object:   func() *executor.Object { return nil },
+			expected: `null`,
+		},
+		{
+			name:     "empty object",
+			object:   executor.NewObject,
+			expected: `{}`,
+		},
+		{
+			name: "keys keep insertion order",
+			object: func() *executor.Object {
+				o := executor.NewObject()
+				o.Set("zeta", 1)
+				o.Set("alpha", "a")
+				o.Set("nested", executor.NewObject())
+				return o
+			},
+			expected: `{"zeta":1,"alpha":"a","nested":{}}`,
+		},
+		{
+			name: "set replaces value in place",
+			object: func() *executor.Object {
+				o := executor.NewObject()
+				o.Set("a", 1)
+				o.Set("b", 2)
+				o.Set("a", 3)
+				return o
+			},
+			expected: `{"a":3,"b":2}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.object())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if d := cmp.Diff(tt.expected, string(got)); d != "" {
+				t.Errorf("MarshalJSON() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
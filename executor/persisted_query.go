@@ -0,0 +1,124 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	PersistedQueryNotFoundCode     = "PERSISTED_QUERY_NOT_FOUND"
+	PersistedQueryNotSupportedCode = "PERSISTED_QUERY_NOT_SUPPORTED"
+)
+
+// PersistedQueryStore holds the query documents of Automatic Persisted Queries by their SHA-256 hash.
+type PersistedQueryStore interface {
+	Get(ctx context.Context, hash string) (string, bool, error)
+	Set(ctx context.Context, hash, query string) error
+}
+
+// InMemoryPersistedQueryStore keeps persisted queries in an LRU cache of the process.
+type InMemoryPersistedQueryStore struct {
+	cache *Cache
+}
+
+var _ PersistedQueryStore = (*InMemoryPersistedQueryStore)(nil)
+
+func NewInMemoryPersistedQueryStore(opts ...CacheOption) *InMemoryPersistedQueryStore {
+	return &InMemoryPersistedQueryStore{
+		cache: NewCache(opts...),
+	}
+}
+
+func (s *InMemoryPersistedQueryStore) Get(ctx context.Context, hash string) (string, bool, error) {
+	v, ok := s.cache.get(cacheKey{kind: persistedQueryCacheKind, hash: hash})
+	if !ok {
+		return "", false, nil
+	}
+
+	return v.(string), true, nil
+}
+
+func (s *InMemoryPersistedQueryStore) Set(ctx context.Context, hash, query string) error {
+	s.cache.set(cacheKey{kind: persistedQueryCacheKind, hash: hash}, query)
+	return nil
+}
+
+func (s *InMemoryPersistedQueryStore) Stats() CacheStats {
+	return s.cache.Stats()
+}
+
+type persistedQueryExtension struct {
+	Version    int    `json:"version"`
+	Sha256Hash string `json:"sha256Hash"`
+}
+
+// ResolvePersistedQuery returns the query document of a request following the Automatic Persisted Queries protocol.
+// A request without the persistedQuery extension is returned as is. A request with only the hash is looked up in store,
+// and a request with both the hash and the query registers the query after checking the hash.
+func ResolvePersistedQuery(ctx context.Context, store PersistedQueryStore, query string, extensions map[string]json.RawMessage) (string, error) {
+	hash, ok, err := persistedQueryHash(extensions)
+	if err != nil {
+		return "", err
+	}
+
+	if !ok {
+		return query, nil
+	}
+
+	if query == "" {
+		persisted, ok, err := store.Get(ctx, hash)
+		if err != nil {
+			return "", fmt.Errorf("error getting persisted query: %w", err)
+		}
+
+		if !ok {
+			return "", newPersistedQueryError("PersistedQueryNotFound", PersistedQueryNotFoundCode)
+		}
+
+		return persisted, nil
+	}
+
+	if QueryHash(query) != hash {
+		return "", newPersistedQueryError("provided sha does not match query", BadUserInputCode)
+	}
+
+	if err := store.Set(ctx, hash, query); err != nil {
+		return "", fmt.Errorf("error setting persisted query: %w", err)
+	}
+
+	return query, nil
+}
+
+// persistedQueryHash returns the hash of the persistedQuery extension of a request, if it has one.
+func persistedQueryHash(extensions map[string]json.RawMessage) (string, bool, error) {
+	raw, ok := extensions["persistedQuery"]
+	if !ok {
+		return "", false, nil
+	}
+
+	var ext persistedQueryExtension
+	if err := json.Unmarshal(raw, &ext); err != nil {
+		return "", false, newPersistedQueryError(fmt.Sprintf("invalid persistedQuery extension: %v", err), BadUserInputCode)
+	}
+
+	if ext.Version != 1 {
+		return "", false, newPersistedQueryError("PersistedQueryNotSupported", PersistedQueryNotSupportedCode)
+	}
+
+	return ext.Sha256Hash, true, nil
+}
+
+// IsPersistedQueryNotFound reports whether err asks the client to send the query document along with its hash.
+func IsPersistedQueryNotFound(err error) bool {
+	return NewGraphQLError(err).Extensions["code"] == PersistedQueryNotFoundCode
+}
+
+func newPersistedQueryError(message, code string) GraphQLError {
+	return GraphQLError{
+		Message: message,
+		Extensions: map[string]any{
+			"code": code,
+		},
+	}
+}
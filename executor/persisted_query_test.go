@@ -0,0 +1,106 @@
+package executor_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor"
+)
+
+func TestResolvePersistedQuery(t *testing.T) {
+	const registered = "query { a }"
+	extension := func(version int, hash string) map[string]json.RawMessage {
+		return map[string]json.RawMessage{
+			"persistedQuery": json.RawMessage(fmt.Sprintf(`{"version":%d,"sha256Hash":%q}`, version, hash)),
+		}
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		extensions map[string]json.RawMessage
+		want       string
+		wantErr    *executor.GraphQLError
+	}{
+		{
+			name:  "request without extension",
+			query: "query { b }",
+			want:  "query { b }",
+		},
+		{
+			name:       "registered hash",
+			extensions: extension(1, executor.QueryHash(registered)),
+			want:       registered,
+		},
+		{
+			name:       "unknown hash",
+			extensions: extension(1, executor.QueryHash("query { b }")),
+			wantErr: &executor.GraphQLError{
+				Message:    "PersistedQueryNotFound",
+				Extensions: map[string]any{"code": executor.PersistedQueryNotFoundCode},
+			},
+		},
+		{
+			name:       "hash and query register the query",
+			query:      "query { c }",
+			extensions: extension(1, executor.QueryHash("query { c }")),
+			want:       "query { c }",
+		},
+		{
+			name:       "hash mismatch",
+			query:      "query { c }",
+			extensions: extension(1, executor.QueryHash("query { d }")),
+			wantErr: &executor.GraphQLError{
+				Message:    "provided sha does not match query",
+				Extensions: map[string]any{"code": executor.BadUserInputCode},
+			},
+		},
+		{
+			name:       "unsupported version",
+			extensions: extension(2, executor.QueryHash(registered)),
+			wantErr: &executor.GraphQLError{
+				Message:    "PersistedQueryNotSupported",
+				Extensions: map[string]any{"code": executor.PersistedQueryNotSupportedCode},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := executor.NewInMemoryPersistedQueryStore()
+			if err := store.Set(ctx, executor.QueryHash(registered), registered); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := executor.ResolvePersistedQuery(ctx, store, tt.query, tt.extensions)
+			if tt.wantErr != nil {
+				if d := cmp.Diff(*tt.wantErr, executor.NewGraphQLError(err)); d != "" {
+					t.Errorf("ResolvePersistedQuery() error mismatch (-want +got):\n%s", d)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ResolvePersistedQuery() error = %v", err)
+			}
+
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("ResolvePersistedQuery() mismatch (-want +got):\n%s", d)
+			}
+
+			if tt.query == "" || tt.extensions == nil {
+				return
+			}
+
+			// the registered query is served by its hash alone
+			persisted, err := executor.ResolvePersistedQuery(ctx, store, "", tt.extensions)
+			if err != nil || persisted != tt.query {
+				t.Errorf("ResolvePersistedQuery() = %q, %v, want the registered query", persisted, err)
+			}
+		})
+	}
+}
@@ -1,11 +1,15 @@
 package executor
 
 import (
+	"encoding/json"
+	"strings"
+
 	"github.com/n9te9/goliteql/query"
 )
 
 type Node struct {
 	Name       string
+	Alias      string
 	Directives Directives
 	Arguments  []*query.Argument
 	Type       string
@@ -25,6 +29,15 @@ func (d Directives) FindByName(name string) *query.Directive {
 	return nil
 }
 
+// ResponseKey returns the key of the node in the response, which is its alias if it has one.
+func (n *Node) ResponseKey() string {
+	if n.Alias != "" {
+		return n.Alias
+	}
+
+	return n.Name
+}
+
 func (n *Node) HasFragment() bool {
 	return n.recursiveHasFragment()
 }
@@ -61,86 +74,222 @@ func (n *Node) recursiveHasFragment() bool {
 	return false
 }
 
-func PlanExecution(selections []query.Selection, fragmentDefinitions query.FragmentDefinitions) []*Node {
-	ret := make([]*Node, 0, len(selections))
-	for _, sel := range selections {
-		switch s := sel.(type) {
-		case *query.Field:
-			node := &Node{
-				Name:       string(s.Name),
-				Directives: s.Directives,
-				Children:   make([]*Node, 0, len(s.Selections)),
-				Arguments:  s.Arguments,
+// CollectFields returns the fields selected on n for an object of the given type names, as in CollectFields of the spec.
+// typeNames are the object type and the interfaces and unions it belongs to.
+// Fragments apply when they have no type condition or their type condition is one of typeNames,
+// and fields selected more than once under the same response key are merged into a single node.
+func (n *Node) CollectFields(typeNames ...string) []*Node {
+	return collectFields(n.Children, func(typeCondition string) bool {
+		if typeCondition == "" {
+			return true
+		}
+
+		for _, typeName := range typeNames {
+			if typeName == typeCondition {
+				return true
 			}
+		}
+
+		return false
+	})
+}
+
+func collectFields(children []*Node, applies func(typeCondition string) bool) []*Node {
+	ret := make([]*Node, 0, len(children))
+	indexes := make(map[string]int)
 
-			for _, child := range s.Selections {
-				node.Children = append(node.Children, digExecution(child, fragmentDefinitions))
+	var collect func(children []*Node)
+	collect = func(children []*Node) {
+		for _, child := range children {
+			if child.Name == "" {
+				if applies(child.Type) {
+					collect(child.Children)
+				}
+				continue
 			}
 
-			ret = append(ret, node)
+			idx, ok := indexes[child.ResponseKey()]
+			if !ok {
+				indexes[child.ResponseKey()] = len(ret)
+				ret = append(ret, child)
+				continue
+			}
+
+			merged := *ret[idx]
+			merged.Children = append(append([]*Node{}, ret[idx].Children...), child.Children...)
+			ret[idx] = &merged
 		}
 	}
+	collect(children)
 
 	return ret
 }
 
-func digExecution(selectSet query.Selection, fragmentDefinitions query.FragmentDefinitions) *Node {
+// PlanExecution builds the execution tree of the root selections.
+// Fields, fragment spreads and inline fragments excluded by @skip or @include are left out of the plan,
+// and fragments selected on the root type are flattened into the root fields.
+func PlanExecution(selections []query.Selection, fragmentDefinitions query.FragmentDefinitions, variables map[string]json.RawMessage) ([]*Node, error) {
+	children, err := planSelections(selections, fragmentDefinitions, variables, make(map[string]struct{}))
+	if err != nil {
+		return nil, err
+	}
+
+	return collectFields(children, func(string) bool {
+		return true
+	}), nil
+}
+
+func planSelections(selections []query.Selection, fragmentDefinitions query.FragmentDefinitions, variables map[string]json.RawMessage, visited map[string]struct{}) ([]*Node, error) {
+	ret := make([]*Node, 0, len(selections))
+	for _, sel := range selections {
+		node, err := digExecution(sel, fragmentDefinitions, variables, visited)
+		if err != nil {
+			return nil, err
+		}
+
+		if node != nil {
+			ret = append(ret, node)
+		}
+	}
+
+	return ret, nil
+}
+
+// digExecution plans a single selection, or returns nil when it is not part of the response.
+// visited holds the fragments being spread on the current path, so that a fragment cycle ends instead of recursing forever.
+func digExecution(selectSet query.Selection, fragmentDefinitions query.FragmentDefinitions, variables map[string]json.RawMessage, visited map[string]struct{}) (*Node, error) {
 	switch s := selectSet.(type) {
 	case *query.Field:
-		node := &Node{
+		if include, err := ShouldInclude(s.Directives, variables); !include {
+			return nil, err
+		}
+
+		children, err := planSelections(s.Selections, fragmentDefinitions, variables, visited)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Node{
 			Name:       string(s.Name),
+			Alias:      string(s.Alias),
 			Directives: s.Directives,
 			Arguments:  s.Arguments,
-			Children:   make([]*Node, 0, len(s.Selections)),
+			Children:   children,
+		}, nil
+	case *query.InlineFragment:
+		if include, err := ShouldInclude(s.Directives, variables); !include {
+			return nil, err
 		}
-		for _, child := range s.Selections {
-			switch c := child.(type) {
-			case *query.Field:
-				node.Children = append(node.Children, digExecution(c, fragmentDefinitions))
-			case *query.InlineFragment:
-				node.Type = string(c.TypeCondition)
-				node.Children = append(node.Children, digExecution(c, fragmentDefinitions))
-			case *query.FragmentSpread:
-				// Handle fragment spread
-			}
+
+		children, err := planSelections(s.Selections, fragmentDefinitions, variables, visited)
+		if err != nil {
+			return nil, err
 		}
-		return node
-	case *query.InlineFragment:
-		node := &Node{
+
+		return &Node{
 			Directives: s.Directives,
 			Type:       string(s.TypeCondition),
-			Children:   make([]*Node, 0, len(s.Selections)),
-		}
-		for _, child := range s.Selections {
-			switch c := child.(type) {
-			case *query.Field:
-				node.Children = append(node.Children, digExecution(c, fragmentDefinitions))
-			case *query.InlineFragment:
-				node.Children = append(node.Children, digExecution(c, fragmentDefinitions))
-			case *query.FragmentSpread:
-				node.Children = append(node.Children, digExecution(c, fragmentDefinitions))
-			}
-		}
-		return node
+			Children:   children,
+		}, nil
 	case *query.FragmentSpread:
+		if include, err := ShouldInclude(s.Directives, variables); !include {
+			return nil, err
+		}
+
 		fragment := fragmentDefinitions.GetFragment(s.Name)
-		node := &Node{
+		if fragment == nil {
+			return nil, nil
+		}
+
+		name := string(s.Name)
+		if _, ok := visited[name]; ok {
+			return nil, nil
+		}
+		visited[name] = struct{}{}
+		defer delete(visited, name)
+
+		children, err := planSelections(fragment.Selections, fragmentDefinitions, variables, visited)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Node{
 			Directives: s.Directives,
 			Type:       string(fragment.BasedTypeName),
-			Children:   make([]*Node, 0, len(fragment.Selections)),
+			Children:   children,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// PlanKey returns the key a plan of the operation is cached under.
+// A plan depends on the values of the variables @skip and @include refer to, so they are part of the key.
+func PlanKey(operationName, document string, selections []query.Selection, fragmentDefinitions query.FragmentDefinitions, variables map[string]json.RawMessage) string {
+	var buf strings.Builder
+	buf.WriteString(operationName)
+	buf.WriteByte(':')
+	buf.WriteString(document)
+
+	for _, name := range conditionVariables(selections, fragmentDefinitions) {
+		buf.WriteByte(':')
+		buf.WriteString(name)
+		buf.WriteByte('=')
+		buf.Write(variables[name])
+	}
+
+	return buf.String()
+}
+
+// conditionVariables returns the variables @skip and @include refer to in the selections and the fragments they spread, in order of appearance.
+func conditionVariables(selections []query.Selection, fragmentDefinitions query.FragmentDefinitions) []string {
+	ret := make([]string, 0)
+	seen := make(map[string]struct{})
+	spread := make(map[string]struct{})
+
+	addDirectives := func(directives []*query.Directive) {
+		for _, dir := range directives {
+			if string(dir.Name) != "skip" && string(dir.Name) != "include" {
+				continue
+			}
+
+			for _, arg := range dir.Arguments {
+				if !arg.IsVariable {
+					continue
+				}
+
+				if _, ok := seen[string(arg.Value)]; !ok {
+					seen[string(arg.Value)] = struct{}{}
+					ret = append(ret, string(arg.Value))
+				}
+			}
 		}
-		for _, child := range fragment.Selections {
-			switch c := child.(type) {
+	}
+
+	var walk func(selections []query.Selection)
+	walk = func(selections []query.Selection) {
+		for _, sel := range selections {
+			switch s := sel.(type) {
 			case *query.Field:
-				node.Children = append(node.Children, digExecution(c, fragmentDefinitions))
+				addDirectives(s.Directives)
+				walk(s.Selections)
 			case *query.InlineFragment:
-				node.Children = append(node.Children, digExecution(c, fragmentDefinitions))
+				addDirectives(s.Directives)
+				walk(s.Selections)
 			case *query.FragmentSpread:
-				node.Children = append(node.Children, digExecution(c, fragmentDefinitions))
+				addDirectives(s.Directives)
+				if _, ok := spread[string(s.Name)]; ok {
+					continue
+				}
+				spread[string(s.Name)] = struct{}{}
+
+				if fragment := fragmentDefinitions.GetFragment(s.Name); fragment != nil {
+					walk(fragment.Selections)
+				}
 			}
 		}
-		return node
 	}
+	walk(selections)
 
-	return nil
+	return ret
 }
@@ -1,6 +1,7 @@
 package executor_test
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -168,11 +169,286 @@ func TestPlanExecution(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Plan fragment spread in field",
+			input: []query.Selection{
+				&query.Field{
+					Name: []byte("__schema"),
+					Selections: []query.Selection{
+						&query.Field{
+							Name: []byte("types"),
+							Selections: []query.Selection{
+								&query.FragmentSpread{Name: []byte("FullType")},
+							},
+						},
+					},
+				},
+			},
+			fragmentDefinitions: query.FragmentDefinitions{
+				{
+					Name:          []byte("FullType"),
+					BasedTypeName: []byte("__Type"),
+					Selections: []query.Selection{
+						&query.Field{Name: []byte("kind")},
+						&query.Field{Name: []byte("name")},
+					},
+				},
+			},
+			resultTree: []*executor.Node{
+				{
+					Name: "__schema",
+					Children: []*executor.Node{
+						{
+							Name: "types",
+							Children: []*executor.Node{
+								{
+									Type: "__Type",
+									Children: []*executor.Node{
+										{
+											Name:     "kind",
+											Children: []*executor.Node{},
+										},
+										{
+											Name:     "name",
+											Children: []*executor.Node{},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Plan nested fragment spreads",
+			input: []query.Selection{
+				&query.Field{
+					Name: []byte("search"),
+					Selections: []query.Selection{
+						&query.InlineFragment{
+							TypeCondition: []byte("Post"),
+							Selections: []query.Selection{
+								&query.FragmentSpread{Name: []byte("PostFields")},
+							},
+						},
+					},
+				},
+			},
+			fragmentDefinitions: query.FragmentDefinitions{
+				{
+					Name:          []byte("PostFields"),
+					BasedTypeName: []byte("Post"),
+					Selections: []query.Selection{
+						&query.Field{Name: []byte("title")},
+						&query.Field{
+							Name: []byte("author"),
+							Selections: []query.Selection{
+								&query.FragmentSpread{Name: []byte("UserFields")},
+							},
+						},
+					},
+				},
+				{
+					Name:          []byte("UserFields"),
+					BasedTypeName: []byte("User"),
+					Selections: []query.Selection{
+						&query.Field{Name: []byte("name")},
+					},
+				},
+			},
+			resultTree: []*executor.Node{
+				{
+					Name: "search",
+					Children: []*executor.Node{
+						{
+							Type: "Post",
+							Children: []*executor.Node{
+								{
+									Type: "Post",
+									Children: []*executor.Node{
+										{
+											Name:     "title",
+											Children: []*executor.Node{},
+										},
+										{
+											Name: "author",
+											Children: []*executor.Node{
+												{
+													Type: "User",
+													Children: []*executor.Node{
+														{
+															Name:     "name",
+															Children: []*executor.Node{},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Plan recursive fragment spread",
+			input: []query.Selection{
+				&query.Field{
+					Name: []byte("node"),
+					Selections: []query.Selection{
+						&query.FragmentSpread{Name: []byte("A")},
+					},
+				},
+			},
+			fragmentDefinitions: query.FragmentDefinitions{
+				{
+					Name:          []byte("A"),
+					BasedTypeName: []byte("Node"),
+					Selections: []query.Selection{
+						&query.Field{Name: []byte("id")},
+						&query.FragmentSpread{Name: []byte("B")},
+					},
+				},
+				{
+					Name:          []byte("B"),
+					BasedTypeName: []byte("Node"),
+					Selections: []query.Selection{
+						&query.FragmentSpread{Name: []byte("A")},
+					},
+				},
+			},
+			resultTree: []*executor.Node{
+				{
+					Name: "node",
+					Children: []*executor.Node{
+						{
+							Type: "Node",
+							Children: []*executor.Node{
+								{
+									Name:     "id",
+									Children: []*executor.Node{},
+								},
+								{
+									Type:     "Node",
+									Children: []*executor.Node{},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Plan fragments on the root type",
+			input: []query.Selection{
+				&query.FragmentSpread{Name: []byte("Root")},
+				&query.InlineFragment{
+					TypeCondition: []byte("Query"),
+					Selections: []query.Selection{
+						&query.Field{
+							Name: []byte("users"),
+							Selections: []query.Selection{
+								&query.Field{Name: []byte("name")},
+							},
+						},
+					},
+				},
+				&query.FragmentSpread{Name: []byte("Unknown")},
+			},
+			fragmentDefinitions: query.FragmentDefinitions{
+				{
+					Name:          []byte("Root"),
+					BasedTypeName: []byte("Query"),
+					Selections: []query.Selection{
+						&query.Field{
+							Name: []byte("users"),
+							Selections: []query.Selection{
+								&query.Field{Name: []byte("id")},
+							},
+						},
+					},
+				},
+			},
+			resultTree: []*executor.Node{
+				{
+					Name: "users",
+					Children: []*executor.Node{
+						{
+							Name:     "id",
+							Children: []*executor.Node{},
+						},
+						{
+							Name:     "name",
+							Children: []*executor.Node{},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Plan aliased fields",
+			input: []query.Selection{
+				&query.Field{
+					Name:  []byte("user"),
+					Alias: []byte("a"),
+					Selections: []query.Selection{
+						&query.Field{Name: []byte("id")},
+					},
+				},
+				&query.Field{
+					Name:  []byte("user"),
+					Alias: []byte("b"),
+					Selections: []query.Selection{
+						&query.Field{Name: []byte("name")},
+					},
+				},
+				&query.Field{
+					Name:  []byte("user"),
+					Alias: []byte("a"),
+					Selections: []query.Selection{
+						&query.Field{Name: []byte("name")},
+					},
+				},
+			},
+			resultTree: []*executor.Node{
+				{
+					Name:  "user",
+					Alias: "a",
+					Children: []*executor.Node{
+						{
+							Name:     "id",
+							Children: []*executor.Node{},
+						},
+						{
+							Name:     "name",
+							Children: []*executor.Node{},
+						},
+					},
+				},
+				{
+					Name:  "user",
+					Alias: "b",
+					Children: []*executor.Node{
+						{
+							Name:     "name",
+							Children: []*executor.Node{},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := executor.PlanExecution(tt.input, tt.fragmentDefinitions)
+			result, err := executor.PlanExecution(tt.input, tt.fragmentDefinitions, nil)
+			if err != nil {
+				t.Fatalf("PlanExecution() error = %v", err)
+			}
+
 			if result == nil {
 				t.Errorf("PlanExecution() returned nil")
 				return
@@ -184,3 +460,165 @@ func TestPlanExecution(t *testing.T) {
 		})
 	}
 }
+
+func TestNode_CollectFields(t *testing.T) {
+	doc, err := query.NewParserWithLexer().Parse([]byte(`
+		query {
+			search {
+				__typename
+				... on Post { title author { id } }
+				... on User { name }
+				...NodeFields
+				... { id }
+			}
+		}
+
+		fragment NodeFields on Node {
+			id
+			... on Post { author { name } }
+		}
+	`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nodes, err := executor.PlanExecution(doc.Operations[0].Selections, doc.FragmentDefinitions, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		typeNames []string
+		want      []string
+	}{
+		{
+			name:      "object in interface",
+			typeNames: []string{"Post", "Node"},
+			want:      []string{"__typename", "title", "author", "id"},
+		},
+		{
+			name:      "object without interface",
+			typeNames: []string{"User"},
+			want:      []string{"__typename", "name", "id"},
+		},
+		{
+			name: "no type names",
+			want: []string{"__typename", "id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make([]string, 0)
+			for _, field := range nodes[0].CollectFields(tt.typeNames...) {
+				got = append(got, field.Name)
+			}
+
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("CollectFields() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+
+	for _, field := range nodes[0].CollectFields("Post", "Node") {
+		if field.Name != "author" {
+			continue
+		}
+
+		got := make([]string, 0)
+		for _, child := range field.Children {
+			got = append(got, child.Name)
+		}
+
+		if d := cmp.Diff([]string{"id", "name"}, got); d != "" {
+			t.Errorf("CollectFields() merged author mismatch (-want +got):\n%s", d)
+		}
+	}
+}
+
+func TestPlanExecution_Directives(t *testing.T) {
+	doc, err := query.NewParserWithLexer().Parse([]byte(`
+		query ($withPosts: Boolean!, $skipName: Boolean!) {
+			user {
+				id
+				name @skip(if: $skipName)
+				... on User @include(if: $withPosts) { posts { id } }
+				...UserFields @skip(if: true)
+			}
+			posts @include(if: $withPosts) { id }
+			...RootFields @include(if: $withPosts)
+		}
+
+		fragment UserFields on User { email }
+		fragment RootFields on Query { viewer { id } }
+	`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		variables map[string]json.RawMessage
+		want      []string
+	}{
+		{
+			name:      "included",
+			variables: map[string]json.RawMessage{"withPosts": json.RawMessage(`true`), "skipName": json.RawMessage(`false`)},
+			want:      []string{"user", "user.id", "user.name", "user.posts", "posts", "posts.id", "viewer", "viewer.id"},
+		},
+		{
+			name:      "excluded",
+			variables: map[string]json.RawMessage{"withPosts": json.RawMessage(`false`), "skipName": json.RawMessage(`true`)},
+			want:      []string{"user", "user.id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes, err := executor.PlanExecution(doc.Operations[0].Selections, doc.FragmentDefinitions, tt.variables)
+			if err != nil {
+				t.Fatalf("PlanExecution() error = %v", err)
+			}
+
+			got := make([]string, 0)
+			for _, node := range nodes {
+				got = append(got, node.Name)
+				for _, child := range node.CollectFields("User") {
+					got = append(got, node.Name+"."+child.Name)
+				}
+			}
+
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("PlanExecution() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+
+	if _, err := executor.PlanExecution(doc.Operations[0].Selections, doc.FragmentDefinitions, nil); err == nil {
+		t.Error("PlanExecution() error = nil, want an error for variables without runtime values")
+	}
+}
+
+func TestPlanKey(t *testing.T) {
+	document := `query ($id: ID!, $full: Boolean!) { user(id: $id) { id ... @include(if: $full) { name } } }`
+	doc, err := query.NewParserWithLexer().Parse([]byte(document))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := func(id, full string) string {
+		return executor.PlanKey("", document, doc.Operations[0].Selections, doc.FragmentDefinitions, map[string]json.RawMessage{
+			"id":   json.RawMessage(id),
+			"full": json.RawMessage(full),
+		})
+	}
+
+	if key(`"1"`, `true`) != key(`"2"`, `true`) {
+		t.Error("PlanKey() differs by a variable that no directive refers to")
+	}
+
+	if key(`"1"`, `true`) == key(`"1"`, `false`) {
+		t.Error("PlanKey() does not differ by a variable that @include refers to")
+	}
+}
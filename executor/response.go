@@ -3,6 +3,7 @@ package executor
 import (
 	"encoding/json"
 	"errors"
+	"strings"
 
 	"github.com/n9te9/goliteql/query"
 )
@@ -28,9 +29,17 @@ func ExcludeSelectFields(resp map[string]json.RawMessage, selectSets []query.Sel
 	return resp
 }
 
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+const OperationResolutionFailureCode = "OPERATION_RESOLUTION_FAILURE"
+
 type GraphQLError struct {
 	Message    string         `json:"message"`
-	Path       []string       `json:"path,omitempty"`
+	Locations  []Location     `json:"locations,omitempty"`
+	Path       []any          `json:"path,omitempty"`
 	Extensions map[string]any `json:"extensions,omitempty"`
 }
 
@@ -38,9 +47,51 @@ func (e GraphQLError) Error() string {
 	return e.Message
 }
 
+// NewGraphQLError converts err into a GraphQLError, keeping the locations and extensions of an error that already is one.
+func NewGraphQLError(err error) GraphQLError {
+	var gqlErr GraphQLError
+	if errors.As(err, &gqlErr) {
+		return gqlErr
+	}
+
+	var gqlErrPtr *GraphQLError
+	if errors.As(err, &gqlErrPtr) && gqlErrPtr != nil {
+		return *gqlErrPtr
+	}
+
+	return GraphQLError{Message: err.Error()}
+}
+
+// NewOperationResolutionError reports that the operation to execute could not be selected from the document.
+func NewOperationResolutionError(err error) GraphQLError {
+	return GraphQLError{
+		Message: err.Error(),
+		Extensions: map[string]any{
+			"code": OperationResolutionFailureCode,
+		},
+	}
+}
+
+type GraphQLErrors []GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Message)
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+func NewErrorResponse(errs []GraphQLError) *GraphQLResponse {
+	return &GraphQLResponse{
+		Errors: errs,
+	}
+}
+
 type GraphQLResponse struct {
-	Data   map[string]any `json:"data"`
-	Errors []error        `json:"errors,omitempty"`
+	Data   *Object        `json:"data"`
+	Errors []GraphQLError `json:"errors,omitempty"`
 }
 
 func MatchGraphQLResponse[T map[string]json.RawMessage | json.RawMessage | any](resp map[string]T) error {
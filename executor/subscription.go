@@ -0,0 +1,69 @@
+package executor
+
+import "context"
+
+func Subscribe[T, R any](ctx context.Context, node *Node, source <-chan T, apply func(context.Context, T, *Node) (R, error)) <-chan *GraphQLResponse {
+	ch := make(chan *GraphQLResponse)
+
+	go func() {
+		defer close(ch)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-source:
+				if !ok {
+					return
+				}
+
+				select {
+				case ch <- newSubscriptionEvent(ctx, node, v, apply):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return ch
+}
+
+func newSubscriptionEvent[T, R any](ctx context.Context, node *Node, v T, apply func(context.Context, T, *Node) (R, error)) *GraphQLResponse {
+	resp := &GraphQLResponse{
+		Data: NewObject(),
+	}
+
+	errs := &fieldErrors{}
+	ctx = withFieldErrors(WithPath(ctx, node.ResponseKey()), errs)
+
+	ret, err := apply(ctx, v, node)
+	if err != nil {
+		AddFieldError(ctx, err)
+		resp.Data.Set(node.ResponseKey(), nil)
+		resp.Errors = errs.errs
+
+		return resp
+	}
+
+	resp.Data.Set(node.ResponseKey(), NewNullable(ret))
+	resp.Errors = errs.errs
+
+	return resp
+}
+
+// SingleResult streams resp as the only event, so that queries and mutations are served over the transports of subscriptions.
+func SingleResult(resp *GraphQLResponse) <-chan *GraphQLResponse {
+	ch := make(chan *GraphQLResponse, 1)
+	ch <- resp
+	close(ch)
+
+	return ch
+}
+
+// WithEventContext returns apply called with the context returned by eventContext, so that every event of a subscription gets its own state such as loaders.
+func WithEventContext[T, R any](apply func(context.Context, T, *Node) (R, error), eventContext func(context.Context) context.Context) func(context.Context, T, *Node) (R, error) {
+	return func(ctx context.Context, v T, node *Node) (R, error) {
+		return apply(eventContext(ctx), v, node)
+	}
+}
@@ -0,0 +1,110 @@
+package executor_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor"
+)
+
+func TestSubscribe(t *testing.T) {
+	tests := []struct {
+		name   string
+		source []string
+		apply  func(context.Context, string, *executor.Node) (executor.Nullable, error)
+		want   []string
+	}{
+		{
+			name:   "apply each event",
+			source: []string{"1", "2"},
+			apply: func(ctx context.Context, v string, node *executor.Node) (executor.Nullable, error) {
+				return executor.NewNullable(map[string]string{"id": v}), nil
+			},
+			want: []string{
+				`{"data":{"postAdded":{"id":"1"}}}`,
+				`{"data":{"postAdded":{"id":"2"}}}`,
+			},
+		},
+		{
+			name:   "apply error",
+			source: []string{"1"},
+			apply: func(ctx context.Context, v string, node *executor.Node) (executor.Nullable, error) {
+				return nil, errors.New("failed to apply")
+			},
+			want: []string{
+				`{"data":{"postAdded":null},"errors":[{"message":"failed to apply","path":["postAdded"]}]}`,
+			},
+		},
+		{
+			name:   "apply each event with its own context",
+			source: []string{"1", "2"},
+			apply: executor.WithEventContext(
+				func(ctx context.Context, v string, node *executor.Node) (executor.Nullable, error) {
+					return executor.NewNullable(map[string]any{"id": v, "event": ctx.Value(eventKey{})}), nil
+				},
+				newEventContext(),
+			),
+			want: []string{
+				`{"data":{"postAdded":{"event":1,"id":"1"}}}`,
+				`{"data":{"postAdded":{"event":2,"id":"2"}}}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := make(chan string, len(tt.source))
+			for _, v := range tt.source {
+				source <- v
+			}
+			close(source)
+
+			node := &executor.Node{Name: "postAdded"}
+			got := make([]string, 0)
+			for resp := range executor.Subscribe(context.Background(), node, source, tt.apply) {
+				b, err := json.Marshal(resp)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, string(b))
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Subscribe() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+type eventKey struct{}
+
+// newEventContext returns an event context that numbers the events.
+func newEventContext() func(context.Context) context.Context {
+	var n int
+	return func(ctx context.Context) context.Context {
+		n++
+		return context.WithValue(ctx, eventKey{}, n)
+	}
+}
+
+func TestSingleResult(t *testing.T) {
+	data := executor.NewObject()
+	data.Set("posts", executor.NewNullable([]string{"1"}))
+
+	got := make([]string, 0)
+	for resp := range executor.SingleResult(&executor.GraphQLResponse{Data: data}) {
+		b, err := json.Marshal(resp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, string(b))
+	}
+
+	want := []string{`{"data":{"posts":["1"]}}`}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SingleResult() mismatch (-want +got):\n%s", diff)
+	}
+}
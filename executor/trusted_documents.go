@@ -0,0 +1,68 @@
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const PersistedQueryNotInListCode = "PERSISTED_QUERY_NOT_IN_LIST"
+
+// TrustedDocuments is an allowlist of query documents keyed by their hash, loaded from a persisted document manifest.
+// The hashes are taken as they are, so a manifest may use any hash function.
+type TrustedDocuments struct {
+	documents map[string]string
+	trusted   map[string]struct{}
+}
+
+func NewTrustedDocuments(documents map[string]string) *TrustedDocuments {
+	trusted := make(map[string]struct{}, len(documents))
+	for _, document := range documents {
+		trusted[strings.TrimSpace(document)] = struct{}{}
+	}
+
+	return &TrustedDocuments{
+		documents: documents,
+		trusted:   trusted,
+	}
+}
+
+// LoadTrustedDocuments loads a manifest of {"hash": "query"} entries, the format of Apollo and Relay persisted documents.
+func LoadTrustedDocuments(path string) (*TrustedDocuments, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading trusted documents: %w", err)
+	}
+
+	var documents map[string]string
+	if err := json.Unmarshal(b, &documents); err != nil {
+		return nil, fmt.Errorf("error decoding trusted documents %s: %w", path, err)
+	}
+
+	return NewTrustedDocuments(documents), nil
+}
+
+// Resolve returns the query document of a request, which either refers to a trusted document by the hash of its persistedQuery extension or is one, ignoring surrounding whitespace.
+// Any other document is rejected.
+func (d *TrustedDocuments) Resolve(query string, extensions map[string]json.RawMessage) (string, error) {
+	hash, ok, err := persistedQueryHash(extensions)
+	if err != nil {
+		return "", err
+	}
+
+	if ok {
+		document, ok := d.documents[hash]
+		if !ok || (query != "" && strings.TrimSpace(query) != strings.TrimSpace(document)) {
+			return "", newPersistedQueryError("PersistedQueryNotInList", PersistedQueryNotInListCode)
+		}
+
+		return document, nil
+	}
+
+	if _, ok := d.trusted[strings.TrimSpace(query)]; !ok {
+		return "", newPersistedQueryError("PersistedQueryNotInList", PersistedQueryNotInListCode)
+	}
+
+	return query, nil
+}
@@ -0,0 +1,99 @@
+package executor_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor"
+)
+
+func TestTrustedDocuments_Resolve(t *testing.T) {
+	manifest := filepath.Join(t.TempDir(), "trusted-documents.json")
+	if err := os.WriteFile(manifest, []byte(`{"1a2b": "query { a }"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	documents, err := executor.LoadTrustedDocuments(manifest)
+	if err != nil {
+		t.Fatalf("LoadTrustedDocuments() error = %v", err)
+	}
+
+	extension := func(hash string) map[string]json.RawMessage {
+		return map[string]json.RawMessage{
+			"persistedQuery": json.RawMessage(`{"version":1,"sha256Hash":"` + hash + `"}`),
+		}
+	}
+	notInList := &executor.GraphQLError{
+		Message:    "PersistedQueryNotInList",
+		Extensions: map[string]any{"code": executor.PersistedQueryNotInListCode},
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		extensions map[string]json.RawMessage
+		want       string
+		wantErr    *executor.GraphQLError
+	}{
+		{
+			name:       "trusted hash",
+			extensions: extension("1a2b"),
+			want:       "query { a }",
+		},
+		{
+			name:  "trusted query text",
+			query: "query { a }",
+			want:  "query { a }",
+		},
+		{
+			name:  "trusted query text with surrounding whitespace",
+			query: "query { a }\n",
+			want:  "query { a }\n",
+		},
+		{
+			name:       "trusted hash with its query",
+			query:      "query { a }",
+			extensions: extension("1a2b"),
+			want:       "query { a }",
+		},
+		{
+			name:       "unknown hash",
+			extensions: extension("3c4d"),
+			wantErr:    notInList,
+		},
+		{
+			name:    "untrusted query text",
+			query:   "query { b }",
+			wantErr: notInList,
+		},
+		{
+			name:       "trusted hash with another query",
+			query:      "query { b }",
+			extensions: extension("1a2b"),
+			wantErr:    notInList,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := documents.Resolve(tt.query, tt.extensions)
+			if tt.wantErr != nil {
+				if d := cmp.Diff(*tt.wantErr, executor.NewGraphQLError(err)); d != "" {
+					t.Errorf("Resolve() error mismatch (-want +got):\n%s", d)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("Resolve() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,398 @@
+package executor
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/n9te9/goliteql"
+	"github.com/n9te9/goliteql/query"
+	"github.com/n9te9/goliteql/schema"
+)
+
+const BadUserInputCode = "BAD_USER_INPUT"
+
+// ScalarUnmarshaler checks that data is a valid input value of a custom scalar.
+type ScalarUnmarshaler func(data []byte) error
+
+// NewScalarUnmarshaler returns the ScalarUnmarshaler of the custom scalar type T, which decodes its input value like goliteql.UnmarshalScalar.
+func NewScalarUnmarshaler[T any]() ScalarUnmarshaler {
+	return func(data []byte) error {
+		var v T
+		return goliteql.UnmarshalScalar(data, &v)
+	}
+}
+
+// CoerceVariableValues coerces the request variables against the variable definitions of op, following CoerceVariableValues of the spec.
+// The returned map only holds the variables op defines, with default values applied and Int, Float, ID and list values normalized.
+// Values of custom scalars are checked by their unmarshaler in scalars, if they have one.
+func CoerceVariableValues(s *schema.Schema, op *query.Operation, values map[string]json.RawMessage, scalars map[string]ScalarUnmarshaler) (map[string]json.RawMessage, []GraphQLError) {
+	coerced := make(map[string]json.RawMessage)
+	if op == nil {
+		return coerced, nil
+	}
+
+	errs := make([]GraphQLError, 0)
+	for _, variable := range op.Variables {
+		name := string(variable.Name)
+		variableType := toSchemaFieldType(variable.Type)
+
+		raw, provided := values[name]
+		if !provided && variable.DefaultValue != nil {
+			v, err := defaultValue(variable.DefaultValue)
+			if err == nil {
+				v, err = coerceInputValue(s, scalars, v, variableType, nil)
+			}
+			if err != nil {
+				errs = append(errs, newVariableError(variable, fmt.Sprintf("Variable \"$%s\" has invalid default value: %v", name, err)))
+				continue
+			}
+
+			b, err := json.Marshal(v)
+			if err != nil {
+				errs = append(errs, newVariableError(variable, fmt.Sprintf("Variable \"$%s\" has invalid default value: %v", name, err)))
+				continue
+			}
+
+			coerced[name] = b
+			continue
+		}
+
+		if !provided || bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+			if !variableType.Nullable {
+				if provided {
+					errs = append(errs, newVariableError(variable, fmt.Sprintf("Variable \"$%s\" of non-null type \"%s\" must not be null.", name, typeString(variableType))))
+				} else {
+					errs = append(errs, newVariableError(variable, fmt.Sprintf("Variable \"$%s\" of required type \"%s\" was not provided.", name, typeString(variableType))))
+				}
+				continue
+			}
+
+			if provided {
+				coerced[name] = json.RawMessage("null")
+			}
+			continue
+		}
+
+		v, err := decodeVariable(raw)
+		if err != nil {
+			errs = append(errs, newVariableError(variable, fmt.Sprintf("Variable \"$%s\" got invalid value: %v", name, err)))
+			continue
+		}
+
+		v, err = coerceInputValue(s, scalars, v, variableType, nil)
+		if err != nil {
+			errs = append(errs, newVariableError(variable, fmt.Sprintf("Variable \"$%s\" got invalid value %s; %v", name, compactJSON(raw), err)))
+			continue
+		}
+
+		b, err := json.Marshal(v)
+		if err != nil {
+			errs = append(errs, newVariableError(variable, fmt.Sprintf("Variable \"$%s\" got invalid value: %v", name, err)))
+			continue
+		}
+
+		coerced[name] = b
+	}
+
+	return coerced, errs
+}
+
+type coercionError struct {
+	path    []string
+	message string
+}
+
+func (e *coercionError) Error() string {
+	if len(e.path) == 0 {
+		return e.message
+	}
+
+	return fmt.Sprintf("%s at \"%s\"", e.message, strings.Join(e.path, "."))
+}
+
+func newCoercionError(path []string, format string, args ...any) error {
+	return &coercionError{
+		path:    append([]string(nil), path...),
+		message: fmt.Sprintf(format, args...),
+	}
+}
+
+func coerceInputValue(s *schema.Schema, scalars map[string]ScalarUnmarshaler, v any, t *schema.FieldType, path []string) (any, error) {
+	if v == nil {
+		if !t.Nullable {
+			return nil, newCoercionError(path, "Expected non-nullable type \"%s\" not to be null", typeString(t))
+		}
+
+		return nil, nil
+	}
+
+	if t.IsList {
+		items, ok := v.([]any)
+		if !ok {
+			item, err := coerceInputValue(s, scalars, v, t.ListType, path)
+			if err != nil {
+				return nil, err
+			}
+
+			return []any{item}, nil
+		}
+
+		ret := make([]any, 0, len(items))
+		for i, item := range items {
+			coerced, err := coerceInputValue(s, scalars, item, t.ListType, append(path, fmt.Sprint(i)))
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, coerced)
+		}
+
+		return ret, nil
+	}
+
+	name := string(t.Name)
+	switch name {
+	case "Int":
+		n, ok := v.(json.Number)
+		if !ok {
+			return nil, newCoercionError(path, "Int cannot represent non-integer value: %s", describeValue(v))
+		}
+
+		i, err := n.Int64()
+		if err != nil {
+			if f, ferr := n.Float64(); ferr == nil && f == math.Trunc(f) && (f < math.MinInt32 || f > math.MaxInt32) {
+				return nil, newCoercionError(path, "Int cannot represent non 32-bit signed integer value: %s", n)
+			}
+
+			return nil, newCoercionError(path, "Int cannot represent non-integer value: %s", n)
+		}
+
+		if i < math.MinInt32 || i > math.MaxInt32 {
+			return nil, newCoercionError(path, "Int cannot represent non 32-bit signed integer value: %s", n)
+		}
+
+		return i, nil
+	case "Float":
+		n, ok := v.(json.Number)
+		if !ok {
+			return nil, newCoercionError(path, "Float cannot represent non numeric value: %s", describeValue(v))
+		}
+
+		f, err := n.Float64()
+		if err != nil {
+			return nil, newCoercionError(path, "Float cannot represent non numeric value: %s", n)
+		}
+
+		return f, nil
+	case "String":
+		if _, ok := v.(string); !ok {
+			return nil, newCoercionError(path, "String cannot represent a non string value: %s", describeValue(v))
+		}
+
+		return v, nil
+	case "Boolean":
+		if _, ok := v.(bool); !ok {
+			return nil, newCoercionError(path, "Boolean cannot represent a non boolean value: %s", describeValue(v))
+		}
+
+		return v, nil
+	case "ID":
+		switch id := v.(type) {
+		case string:
+			return id, nil
+		case json.Number:
+			if _, err := id.Int64(); err == nil {
+				return id.String(), nil
+			}
+		}
+
+		return nil, newCoercionError(path, "ID cannot represent value: %s", describeValue(v))
+	}
+
+	if enum, ok := s.Indexes.EnumIndex[name]; ok {
+		value, ok := v.(string)
+		if !ok {
+			return nil, newCoercionError(path, "Enum \"%s\" cannot represent non-string value: %s", name, describeValue(v))
+		}
+
+		for _, element := range enum.Values {
+			if string(element.Value) == value {
+				return value, nil
+			}
+		}
+
+		return nil, newCoercionError(path, "Value %q does not exist in \"%s\" enum", value, name)
+	}
+
+	if input, ok := s.Indexes.InputIndex[name]; ok {
+		return coerceInputObject(s, scalars, input, v, path)
+	}
+
+	// custom scalars are decoded by their own unmarshaler along with the resolver arguments, which only checks the value here
+	if unmarshal, ok := scalars[name]; ok {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, newCoercionError(path, "Expected type \"%s\". %v", name, err)
+		}
+
+		if err := unmarshal(b); err != nil {
+			return nil, newCoercionError(path, "Expected type \"%s\". %v", name, err)
+		}
+	}
+
+	return v, nil
+}
+
+func coerceInputObject(s *schema.Schema, scalars map[string]ScalarUnmarshaler, input *schema.InputDefinition, v any, path []string) (any, error) {
+	fields, ok := v.(map[string]any)
+	if !ok {
+		return nil, newCoercionError(path, "Expected type \"%s\" to be an object", input.Name)
+	}
+
+	definitions := make(map[string]*schema.FieldDefinition, len(input.Fields))
+	for _, field := range input.Fields {
+		definitions[string(field.Name)] = field
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, ok := definitions[name]; !ok {
+			return nil, newCoercionError(path, "Field \"%s\" is not defined by type \"%s\"", name, input.Name)
+		}
+	}
+
+	ret := make(map[string]any, len(input.Fields))
+	for _, field := range input.Fields {
+		name := string(field.Name)
+		fieldPath := append(path, name)
+
+		value, provided := fields[name]
+		if !provided {
+			if field.Default != nil {
+				d, err := defaultValue(field.Default)
+				if err != nil {
+					return nil, newCoercionError(fieldPath, "Field \"%s\" has invalid default value: %v", name, err)
+				}
+
+				coerced, err := coerceInputValue(s, scalars, d, field.Type, fieldPath)
+				if err != nil {
+					return nil, err
+				}
+				ret[name] = coerced
+				continue
+			}
+
+			if !field.Type.Nullable {
+				return nil, newCoercionError(path, "Field \"%s\" of required type \"%s\" was not provided", name, typeString(field.Type))
+			}
+			continue
+		}
+
+		coerced, err := coerceInputValue(s, scalars, value, field.Type, fieldPath)
+		if err != nil {
+			return nil, err
+		}
+		ret[name] = coerced
+	}
+
+	return ret, nil
+}
+
+func defaultValue(raw []byte) (any, error) {
+	expr, err := goliteql.NewValueParser(goliteql.NewValueLexer()).Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := goliteql.ValueToJSON(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeVariable(b)
+}
+
+func decodeVariable(raw []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	if decoder.More() {
+		return nil, errors.New("unexpected data after value")
+	}
+
+	return v, nil
+}
+
+func describeValue(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(b)
+}
+
+func compactJSON(raw []byte) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return string(raw)
+	}
+
+	return buf.String()
+}
+
+func newVariableError(variable *query.Variable, message string) GraphQLError {
+	return GraphQLError{
+		Message: message,
+		Locations: []Location{
+			{Line: variable.Position.Line, Column: variable.Position.Column},
+		},
+		Extensions: map[string]any{
+			"code": BadUserInputCode,
+		},
+	}
+}
+
+func toSchemaFieldType(t *query.FieldType) *schema.FieldType {
+	if t == nil {
+		return nil
+	}
+
+	return &schema.FieldType{
+		Name:     t.Name,
+		Nullable: t.Nullable,
+		IsList:   t.IsList,
+		ListType: toSchemaFieldType(t.ListType),
+	}
+}
+
+func typeString(t *schema.FieldType) string {
+	var sb strings.Builder
+	if t.IsList {
+		sb.WriteString("[")
+		sb.WriteString(typeString(t.ListType))
+		sb.WriteString("]")
+	} else {
+		sb.Write(t.Name)
+	}
+
+	if !t.Nullable {
+		sb.WriteString("!")
+	}
+
+	return sb.String()
+}
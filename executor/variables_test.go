@@ -0,0 +1,212 @@
+package executor_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor"
+	"github.com/n9te9/goliteql/query"
+	"github.com/n9te9/goliteql/scalars"
+	"github.com/n9te9/goliteql/schema"
+)
+
+func TestCoerceVariableValues(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]json.RawMessage
+		want      map[string]string
+		wantErrs  []string
+	}{
+		{
+			name:      "scalars",
+			query:     `query Q($i: Int!, $f: Float, $s: String, $b: Boolean, $id: ID) { users { id } }`,
+			variables: map[string]json.RawMessage{"i": json.RawMessage(`1`), "f": json.RawMessage(`2`), "s": json.RawMessage(`"x"`), "b": json.RawMessage(`true`), "id": json.RawMessage(`10`)},
+			want:      map[string]string{"i": `1`, "f": `2`, "s": `"x"`, "b": `true`, "id": `"10"`},
+		},
+		{
+			name:      "undefined variables are dropped",
+			query:     `query Q($i: Int) { users { id } }`,
+			variables: map[string]json.RawMessage{"i": json.RawMessage(`1`), "extra": json.RawMessage(`1`)},
+			want:      map[string]string{"i": `1`},
+		},
+		{
+			name:      "default values",
+			query:     `query Q($limit: Int = 10, $role: Role = ADMIN, $names: [String!] = ["a"], $s: String = "x") { users { id } }`,
+			variables: map[string]json.RawMessage{"s": json.RawMessage(`null`)},
+			want:      map[string]string{"limit": `10`, "role": `"ADMIN"`, "names": `["a"]`, "s": `null`},
+		},
+		{
+			name:      "omitted nullable variable",
+			query:     `query Q($limit: Int) { users { id } }`,
+			variables: map[string]json.RawMessage{},
+			want:      map[string]string{},
+		},
+		{
+			name:      "single value is coerced to a list",
+			query:     `query Q($roles: [Role!]!) { users { id } }`,
+			variables: map[string]json.RawMessage{"roles": json.RawMessage(`"ADMIN"`)},
+			want:      map[string]string{"roles": `["ADMIN"]`},
+		},
+		{
+			name:      "input object with defaults",
+			query:     `query Q($filter: Filter!) { users { id } }`,
+			variables: map[string]json.RawMessage{"filter": json.RawMessage(`{"name": "a"}`)},
+			want:      map[string]string{"filter": `{"limit":20,"name":"a"}`},
+		},
+		{
+			name:      "custom scalars are passed through",
+			query:     `query Q($at: DateTime, $amount: Money) { users { id } }`,
+			variables: map[string]json.RawMessage{"at": json.RawMessage(`"2026-01-02T03:04:05Z"`), "amount": json.RawMessage(`{"value": 1}`)},
+			want:      map[string]string{"at": `"2026-01-02T03:04:05Z"`, "amount": `{"value":1}`},
+		},
+		{
+			name:     "missing non-null variable",
+			query:    `query Q($i: Int!) { users { id } }`,
+			wantErrs: []string{`Variable "$i" of required type "Int!" was not provided.`},
+		},
+		{
+			name:      "null non-null variable",
+			query:     `query Q($i: Int!) { users { id } }`,
+			variables: map[string]json.RawMessage{"i": json.RawMessage(`null`)},
+			wantErrs:  []string{`Variable "$i" of non-null type "Int!" must not be null.`},
+		},
+		{
+			name:      "wrong types",
+			query:     `query Q($i: Int, $s: String, $b: Boolean) { users { id } }`,
+			variables: map[string]json.RawMessage{"i": json.RawMessage(`"1"`), "s": json.RawMessage(`1`), "b": json.RawMessage(`"true"`)},
+			wantErrs: []string{
+				`Variable "$i" got invalid value "1"; Int cannot represent non-integer value: "1"`,
+				`Variable "$s" got invalid value 1; String cannot represent a non string value: 1`,
+				`Variable "$b" got invalid value "true"; Boolean cannot represent a non boolean value: "true"`,
+			},
+		},
+		{
+			name:      "Int out of 32-bit range",
+			query:     `query Q($i: Int, $j: Int) { users { id } }`,
+			variables: map[string]json.RawMessage{"i": json.RawMessage(`2147483648`), "j": json.RawMessage(`1.5`)},
+			wantErrs: []string{
+				`Variable "$i" got invalid value 2147483648; Int cannot represent non 32-bit signed integer value: 2147483648`,
+				`Variable "$j" got invalid value 1.5; Int cannot represent non-integer value: 1.5`,
+			},
+		},
+		{
+			name:      "Int written as a float",
+			query:     `query Q($i: Int, $j: Int, $k: Int) { users { id } }`,
+			variables: map[string]json.RawMessage{"i": json.RawMessage(`1.0`), "j": json.RawMessage(`1e3`), "k": json.RawMessage(`1e10`)},
+			wantErrs: []string{
+				`Variable "$i" got invalid value 1.0; Int cannot represent non-integer value: 1.0`,
+				`Variable "$j" got invalid value 1e3; Int cannot represent non-integer value: 1e3`,
+				`Variable "$k" got invalid value 1e10; Int cannot represent non 32-bit signed integer value: 1e10`,
+			},
+		},
+		{
+			name:      "unknown enum value",
+			query:     `query Q($role: Role) { users { id } }`,
+			variables: map[string]json.RawMessage{"role": json.RawMessage(`"OWNER"`)},
+			wantErrs:  []string{`Variable "$role" got invalid value "OWNER"; Value "OWNER" does not exist in "Role" enum`},
+		},
+		{
+			name:      "invalid custom scalars",
+			query:     `query Q($at: DateTime, $filter: Filter) { users { id } }`,
+			variables: map[string]json.RawMessage{"at": json.RawMessage(`"not-a-date"`), "filter": json.RawMessage(`{"name": "a", "after": 1}`)},
+			wantErrs: []string{
+				`Variable "$at" got invalid value "not-a-date"; Expected type "DateTime". DateTime must be an RFC 3339 date-time: parsing time "not-a-date" as "2006-01-02T15:04:05.999999999Z07:00": cannot parse "not-a-date" as "2006"`,
+				`Variable "$filter" got invalid value {"name":"a","after":1}; Expected type "DateTime". DateTime must be a string, got int64 at "after"`,
+			},
+		},
+		{
+			name:      "invalid list item",
+			query:     `query Q($roles: [Role!]) { users { id } }`,
+			variables: map[string]json.RawMessage{"roles": json.RawMessage(`["ADMIN", null]`)},
+			wantErrs:  []string{`Variable "$roles" got invalid value ["ADMIN",null]; Expected non-nullable type "Role!" not to be null at "1"`},
+		},
+		{
+			name:      "invalid input object fields",
+			query:     `query Q($a: Filter, $b: Filter, $c: Filter) { users { id } }`,
+			variables: map[string]json.RawMessage{"a": json.RawMessage(`{"limit": 1}`), "b": json.RawMessage(`{"name": "a", "age": 1}`), "c": json.RawMessage(`{"name": "a", "role": "OWNER"}`)},
+			wantErrs: []string{
+				`Variable "$a" got invalid value {"limit":1}; Field "name" of required type "String!" was not provided`,
+				`Variable "$b" got invalid value {"name":"a","age":1}; Field "age" is not defined by type "Filter"`,
+				`Variable "$c" got invalid value {"name":"a","role":"OWNER"}; Value "OWNER" does not exist in "Role" enum at "role"`,
+			},
+		},
+	}
+
+	s, err := schema.NewParser(schema.NewLexer()).Parse([]byte(`
+		scalar DateTime
+		scalar Money
+
+		enum Role {
+			ADMIN
+			GUEST
+		}
+
+		input Filter {
+			name: String!
+			role: Role
+			limit: Int = 20
+			after: DateTime
+		}
+
+		type User {
+			id: ID!
+		}
+
+		type Query {
+			users: [User!]!
+		}
+	`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = s.Merge()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scalarUnmarshalers := map[string]executor.ScalarUnmarshaler{
+		"DateTime": executor.NewScalarUnmarshaler[scalars.DateTime](),
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := query.NewParserWithLexer().Parse([]byte(tt.query))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, errs := executor.CoerceVariableValues(s, doc.Operations[0], tt.variables, scalarUnmarshalers)
+
+			gotErrs := make([]string, 0, len(errs))
+			for _, err := range errs {
+				gotErrs = append(gotErrs, err.Message)
+				if d := cmp.Diff(map[string]any{"code": executor.BadUserInputCode}, err.Extensions); d != "" {
+					t.Errorf("CoerceVariableValues() extensions mismatch (-want +got):\n%s", d)
+				}
+			}
+
+			if len(tt.wantErrs) > 0 {
+				if d := cmp.Diff(tt.wantErrs, gotErrs); d != "" {
+					t.Errorf("CoerceVariableValues() errors mismatch (-want +got):\n%s", d)
+				}
+				return
+			}
+
+			if len(gotErrs) > 0 {
+				t.Fatalf("CoerceVariableValues() unexpected errors: %v", gotErrs)
+			}
+
+			gotValues := make(map[string]string, len(got))
+			for k, v := range got {
+				gotValues[k] = string(v)
+			}
+
+			if d := cmp.Diff(tt.want, gotValues); d != "" {
+				t.Errorf("CoerceVariableValues() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
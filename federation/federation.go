@@ -0,0 +1,128 @@
+package federation
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/n9te9/goliteql"
+	"github.com/n9te9/goliteql/executor"
+)
+
+// LinkURL is the Federation v2 specification imported by generated subgraphs.
+const LinkURL = "https://specs.apollo.dev/federation/v2.3"
+
+// Representation is an entity reference sent by the router to _entities.
+type Representation map[string]json.RawMessage
+
+func (r Representation) Typename() string {
+	var typename string
+	if err := json.Unmarshal(r["__typename"], &typename); err != nil {
+		return ""
+	}
+
+	return typename
+}
+
+// Has reports whether every field is present and not null.
+func (r Representation) Has(fields ...string) bool {
+	for _, field := range fields {
+		value, ok := r[field]
+		if !ok || len(value) == 0 || string(value) == "null" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r Representation) Unmarshal(field string, v any) error {
+	if err := json.Unmarshal(r[field], v); err != nil {
+		return fmt.Errorf("representation field %s: %w", field, err)
+	}
+
+	return nil
+}
+
+// Populate decodes the given fields of the representation onto v, which is how @external fields reach the entity.
+func (r Representation) Populate(v any, fields ...string) error {
+	values := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		if value, ok := r[field]; ok {
+			values[field] = value
+		}
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	b, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("representation of %s: %w", r.Typename(), err)
+	}
+
+	return nil
+}
+
+// ParseRepresentations reads the representations argument of _entities, either from a variable or an inline list.
+func ParseRepresentations(node *executor.Node, variables map[string]json.RawMessage) ([]Representation, error) {
+	for _, arg := range node.Arguments {
+		if string(arg.Name) != "representations" {
+			continue
+		}
+
+		value := arg.Value
+		if arg.IsVariable() {
+			value = variables[arg.VariableAnnotation()]
+		} else {
+			expr, err := goliteql.NewValueParser(goliteql.NewValueLexer()).Parse(arg.Value)
+			if err != nil {
+				return nil, fmt.Errorf("argument representations: %w", err)
+			}
+
+			value, err = goliteql.ValueToJSON(expr)
+			if err != nil {
+				return nil, fmt.Errorf("argument representations: %w", err)
+			}
+		}
+
+		var ret []Representation
+		if err := json.Unmarshal(value, &ret); err != nil {
+			return nil, fmt.Errorf("argument representations must be [_Any!]!: %w", err)
+		}
+
+		return ret, nil
+	}
+
+	return nil, fmt.Errorf("argument representations is not provided")
+}
+
+// ResolveService resolves the _service field with the SDL of the subgraph.
+func ResolveService(node *executor.Node, sdl string) (any, error) {
+	ret := executor.NewObject()
+	for _, child := range EntityNode(node, "_Service").Children {
+		switch child.Name {
+		case "__typename":
+			ret.Set(child.ResponseKey(), "_Service")
+		case "sdl":
+			ret.Set(child.ResponseKey(), sdl)
+		default:
+			return nil, fmt.Errorf("field %s is not defined on _Service", child.Name)
+		}
+	}
+
+	return ret, nil
+}
+
+// EntityNode returns a copy of node whose fragments are flattened when their type condition is one of typeNames,
+// so that a single entity of a union can be applied without the fragments of the other members.
+func EntityNode(node *executor.Node, typeNames ...string) *executor.Node {
+	ret := *node
+	ret.Children = node.CollectFields(typeNames...)
+
+	return &ret
+}
@@ -0,0 +1,146 @@
+package federation_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor"
+	"github.com/n9te9/goliteql/federation"
+	"github.com/n9te9/goliteql/query"
+)
+
+func planQuery(t *testing.T, input string) *executor.Node {
+	t.Helper()
+
+	doc, err := query.NewParserWithLexer().Parse([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nodes, err := executor.PlanExecution(doc.Operations[0].Selections, doc.FragmentDefinitions, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(nodes) != 1 {
+		t.Fatalf("expected one root field, got %d", len(nodes))
+	}
+
+	return nodes[0]
+}
+
+func TestParseRepresentations(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]json.RawMessage
+		want      []federation.Representation
+		wantErr   bool
+	}{
+		{
+			name:  "representations from variable",
+			query: `query ($representations: [_Any!]!) { _entities(representations: $representations) { __typename } }`,
+			variables: map[string]json.RawMessage{
+				"representations": json.RawMessage(`[{"__typename":"Product","upc":"1"},{"__typename":"Product","sku":"a","package":"b"}]`),
+			},
+			want: []federation.Representation{
+				{"__typename": json.RawMessage(`"Product"`), "upc": json.RawMessage(`"1"`)},
+				{"__typename": json.RawMessage(`"Product"`), "sku": json.RawMessage(`"a"`), "package": json.RawMessage(`"b"`)},
+			},
+		},
+		{
+			name:  "inline representations",
+			query: `query { _entities(representations: [{__typename: "User", id: 1, address: {zip: "100"}}]) { __typename } }`,
+			want: []federation.Representation{
+				{"__typename": json.RawMessage(`"User"`), "id": json.RawMessage(`1`), "address": json.RawMessage(`{"zip":"100"}`)},
+			},
+		},
+		{
+			name:    "representations are not provided",
+			query:   `query { _entities { __typename } }`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := federation.ParseRepresentations(planQuery(t, tt.query), tt.variables)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRepresentations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseRepresentations() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRepresentation_Populate(t *testing.T) {
+	type product struct {
+		Upc    string `json:"upc"`
+		Weight int    `json:"weight"`
+		Price  int    `json:"price"`
+	}
+
+	representation := federation.Representation{
+		"__typename": json.RawMessage(`"Product"`),
+		"upc":        json.RawMessage(`"1"`),
+		"weight":     json.RawMessage(`10`),
+		"price":      json.RawMessage(`300`),
+	}
+
+	if !representation.Has("upc", "weight") {
+		t.Errorf("Has() = false, want true")
+	}
+
+	if representation.Has("upc", "sku") {
+		t.Errorf("Has() = true, want false")
+	}
+
+	got := &product{Upc: "1"}
+	if err := representation.Populate(got, "weight", "height"); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(&product{Upc: "1", Weight: 10}, got); diff != "" {
+		t.Errorf("Populate() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestEntityNode(t *testing.T) {
+	node := planQuery(t, `query {
+		_entities(representations: []) {
+			__typename
+			... on Product { upc name }
+			... on Review { body }
+			... on Node { id }
+		}
+	}`)
+
+	got := make([]string, 0)
+	for _, child := range federation.EntityNode(node, "Product", "Node").Children {
+		got = append(got, child.Name)
+	}
+
+	if diff := cmp.Diff([]string{"__typename", "upc", "name", "id"}, got); diff != "" {
+		t.Errorf("EntityNode() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestResolveService(t *testing.T) {
+	ret, err := federation.ResolveService(planQuery(t, `query { _service { __typename sdl } }`), "type Query { a: String }")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := json.Marshal(ret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(`{"__typename":"_Service","sdl":"type Query { a: String }"}`, string(got)); diff != "" {
+		t.Errorf("ResolveService() mismatch (-want +got):\n%s", diff)
+	}
+}
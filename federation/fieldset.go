@@ -0,0 +1,79 @@
+package federation
+
+import (
+	"fmt"
+)
+
+// Field is a field selected by a FieldSet such as the fields argument of @key.
+type Field struct {
+	Name       string
+	Selections []*Field
+}
+
+func ParseFieldSet(fieldSet string) ([]*Field, error) {
+	fields, cur, err := parseFieldSelections([]byte(fieldSet), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if cur < len(fieldSet) {
+		return nil, fmt.Errorf("unexpected %q in field set %q", fieldSet[cur], fieldSet)
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("field set must select at least one field")
+	}
+
+	return fields, nil
+}
+
+func parseFieldSelections(input []byte, cur int) ([]*Field, int, error) {
+	fields := make([]*Field, 0)
+	for cur < len(input) {
+		switch c := input[cur]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			cur++
+		case c == '{':
+			if len(fields) == 0 {
+				return nil, 0, fmt.Errorf("selection set without field in field set %q", input)
+			}
+
+			last := fields[len(fields)-1]
+			if last.Selections != nil {
+				return nil, 0, fmt.Errorf("field %s has more than one selection set", last.Name)
+			}
+
+			selections, next, err := parseFieldSelections(input, cur+1)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			if next >= len(input) || input[next] != '}' {
+				return nil, 0, fmt.Errorf("unclosed selection set of field %s", last.Name)
+			}
+
+			if len(selections) == 0 {
+				return nil, 0, fmt.Errorf("field %s has an empty selection set", last.Name)
+			}
+
+			last.Selections = selections
+			cur = next + 1
+		case c == '}':
+			return fields, cur, nil
+		case isNameStart(c):
+			start := cur
+			for cur < len(input) && (isNameStart(input[cur]) || ('0' <= input[cur] && input[cur] <= '9')) {
+				cur++
+			}
+			fields = append(fields, &Field{Name: string(input[start:cur])})
+		default:
+			return nil, 0, fmt.Errorf("unexpected %q in field set %q", c, input)
+		}
+	}
+
+	return fields, cur, nil
+}
+
+func isNameStart(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || c == '_'
+}
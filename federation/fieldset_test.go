@@ -0,0 +1,67 @@
+package federation_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/federation"
+)
+
+func TestParseFieldSet(t *testing.T) {
+	tests := []struct {
+		name     string
+		fieldSet string
+		want     []*federation.Field
+		wantErr  bool
+	}{
+		{
+			name:     "single field",
+			fieldSet: "upc",
+			want:     []*federation.Field{{Name: "upc"}},
+		},
+		{
+			name:     "compound fields",
+			fieldSet: "sku package",
+			want:     []*federation.Field{{Name: "sku"}, {Name: "package"}},
+		},
+		{
+			name:     "nested selection",
+			fieldSet: "id organization { id region { name } }",
+			want: []*federation.Field{
+				{Name: "id"},
+				{Name: "organization", Selections: []*federation.Field{
+					{Name: "id"},
+					{Name: "region", Selections: []*federation.Field{{Name: "name"}}},
+				}},
+			},
+		},
+		{
+			name:     "empty field set",
+			fieldSet: " ",
+			wantErr:  true,
+		},
+		{
+			name:     "unclosed selection",
+			fieldSet: "organization { id",
+			wantErr:  true,
+		},
+		{
+			name:     "unexpected closing brace",
+			fieldSet: "id }",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := federation.ParseFieldSet(tt.fieldSet)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFieldSet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseFieldSet() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,411 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"time"
+
+	"github.com/n9te9/goliteql/schema"
+)
+
+type DataLoaderConfig struct {
+	Name      string `yaml:"name"`
+	KeyType   string `yaml:"key_type"`
+	ValueType string `yaml:"value_type"`
+	Wait      string `yaml:"wait"`
+	MaxBatch  int    `yaml:"max_batch"`
+}
+
+const dataLoaderResolverName = "DataLoaderResolver"
+
+func validateDataLoaderConfigs(loaders []DataLoaderConfig, indexes *schema.Indexes) error {
+	names := make(map[string]struct{}, len(loaders))
+	for _, loader := range loaders {
+		if loader.Name == "" || isLowerCase(loader.Name) {
+			return fmt.Errorf("dataloader name %q must start with an upper case letter", loader.Name)
+		}
+
+		if _, exists := names[loader.Name]; exists {
+			return fmt.Errorf("dataloader %s is defined more than once", loader.Name)
+		}
+		names[loader.Name] = struct{}{}
+
+		if loader.KeyType == "" {
+			return fmt.Errorf("dataloader %s must have a key_type", loader.Name)
+		}
+
+		if !GraphQLType(loader.ValueType).IsPrimitive() && !isDefinedType(loader.ValueType, indexes) {
+			return fmt.Errorf("dataloader %s value_type %s is not defined in schema", loader.Name, loader.ValueType)
+		}
+
+		if loader.Wait != "" {
+			if _, err := time.ParseDuration(loader.Wait); err != nil {
+				return fmt.Errorf("dataloader %s has invalid wait: %w", loader.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func isDefinedType(name string, indexes *schema.Indexes) bool {
+	if indexes.GetTypeDefinition(name) != nil || indexes.GetInterfaceDefinition(name) != nil || indexes.GetUnionDefinition(name) != nil {
+		return true
+	}
+
+	if _, exists := indexes.EnumIndex[name]; exists {
+		return true
+	}
+
+	_, exists := indexes.ScalarIndex[name]
+	return exists
+}
+
+func generateDataLoaderValueTypeExpr(typePrefix string, loader DataLoaderConfig, indexes *schema.Indexes) ast.Expr {
+	graphQLType := GraphQLType(loader.ValueType)
+	if graphQLType.IsPrimitive() {
+		return ast.NewIdent(graphQLType.golangType())
+	}
+
+	var expr ast.Expr = &ast.SelectorExpr{
+		X:   ast.NewIdent(typePrefix),
+		Sel: ast.NewIdent(loader.ValueType),
+	}
+	if indexes.GetTypeDefinition(loader.ValueType) != nil {
+		expr = &ast.StarExpr{X: expr}
+	}
+
+	return expr
+}
+
+func generateDataLoaderTypeExpr(typePrefix string, loader DataLoaderConfig, indexes *schema.Indexes) ast.Expr {
+	return &ast.StarExpr{
+		X: &ast.IndexListExpr{
+			X: &ast.SelectorExpr{
+				X:   ast.NewIdent("dataloader"),
+				Sel: ast.NewIdent("Loader"),
+			},
+			Indices: []ast.Expr{
+				ast.NewIdent(loader.KeyType),
+				generateDataLoaderValueTypeExpr(typePrefix, loader, indexes),
+			},
+		},
+	}
+}
+
+func newBatchFuncName(loader DataLoaderConfig) string {
+	return "Batch" + loader.Name
+}
+
+func generateBatchFuncType(typePrefix string, loader DataLoaderConfig, indexes *schema.Indexes) *ast.FuncType {
+	return &ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Names: []*ast.Ident{ast.NewIdent("ctx")},
+					Type: &ast.SelectorExpr{
+						X:   ast.NewIdent("context"),
+						Sel: ast.NewIdent("Context"),
+					},
+				},
+				{
+					Names: []*ast.Ident{ast.NewIdent("keys")},
+					Type: &ast.ArrayType{
+						Elt: ast.NewIdent(loader.KeyType),
+					},
+				},
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.ArrayType{
+						Elt: generateDataLoaderValueTypeExpr(typePrefix, loader, indexes),
+					},
+				},
+				{
+					Type: &ast.ArrayType{
+						Elt: ast.NewIdent("error"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func generateDataLoaderResolverFields(loaders []DataLoaderConfig) []*ast.Field {
+	if len(loaders) == 0 {
+		return nil
+	}
+
+	return []*ast.Field{
+		{
+			Type: ast.NewIdent(dataLoaderResolverName),
+		},
+	}
+}
+
+func generateDataLoaderResolverInterface(typePrefix string, loaders []DataLoaderConfig, indexes *schema.Indexes) ast.Decl {
+	methods := make([]*ast.Field, 0, len(loaders))
+	for _, loader := range loaders {
+		methods = append(methods, &ast.Field{
+			Names: []*ast.Ident{ast.NewIdent(newBatchFuncName(loader))},
+			Type:  generateBatchFuncType(typePrefix, loader, indexes),
+		})
+	}
+
+	return &ast.GenDecl{
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name: ast.NewIdent(dataLoaderResolverName),
+				Type: &ast.InterfaceType{
+					Methods: &ast.FieldList{
+						List: methods,
+					},
+				},
+			},
+		},
+	}
+}
+
+func generateDataLoaderResolverImplementation(typePrefix string, loaders []DataLoaderConfig, indexes *schema.Indexes) []ast.Decl {
+	decls := make([]ast.Decl, 0, len(loaders))
+	for _, loader := range loaders {
+		decls = append(decls, &ast.FuncDecl{
+			Doc:  &ast.CommentGroup{},
+			Name: ast.NewIdent(newBatchFuncName(loader)),
+			Recv: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("r")},
+						Type:  &ast.StarExpr{X: ast.NewIdent("resolver")},
+					},
+				},
+			},
+			Type: generateBatchFuncType(typePrefix, loader, indexes),
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ExprStmt{
+						X: &ast.CallExpr{
+							Fun: ast.NewIdent("panic"),
+							Args: []ast.Expr{
+								&ast.BasicLit{
+									Kind:  token.STRING,
+									Value: fmt.Sprintf(`"%s batch function is not implemented"`, loader.Name),
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	return decls
+}
+
+func generateLoadersDecls(typePrefix string, loaders []DataLoaderConfig, indexes *schema.Indexes) []ast.Decl {
+	fields := make([]*ast.Field, 0, len(loaders))
+	elts := make([]ast.Expr, 0, len(loaders))
+	for _, loader := range loaders {
+		fields = append(fields, &ast.Field{
+			Names: []*ast.Ident{ast.NewIdent(loader.Name)},
+			Type:  generateDataLoaderTypeExpr(typePrefix, loader, indexes),
+		})
+
+		elts = append(elts, &ast.KeyValueExpr{
+			Key: ast.NewIdent(loader.Name),
+			Value: &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("dataloader"),
+					Sel: ast.NewIdent("NewLoader"),
+				},
+				Args: append([]ast.Expr{
+					&ast.SelectorExpr{
+						X:   ast.NewIdent("r"),
+						Sel: ast.NewIdent(newBatchFuncName(loader)),
+					},
+				}, generateDataLoaderOptions(loader)...),
+			},
+		})
+	}
+
+	return []ast.Decl{
+		&ast.GenDecl{
+			Tok: token.TYPE,
+			Specs: []ast.Spec{
+				&ast.TypeSpec{
+					Name: ast.NewIdent("Loaders"),
+					Type: &ast.StructType{
+						Fields: &ast.FieldList{
+							List: fields,
+						},
+					},
+				},
+			},
+		},
+		&ast.GenDecl{
+			Tok: token.TYPE,
+			Specs: []ast.Spec{
+				&ast.TypeSpec{
+					Name: ast.NewIdent("loadersContextKey"),
+					Type: &ast.StructType{
+						Fields: &ast.FieldList{},
+					},
+				},
+			},
+		},
+		&ast.FuncDecl{
+			Name: ast.NewIdent("LoadersFromContext"),
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Names: []*ast.Ident{ast.NewIdent("ctx")},
+							Type: &ast.SelectorExpr{
+								X:   ast.NewIdent("context"),
+								Sel: ast.NewIdent("Context"),
+							},
+						},
+					},
+				},
+				Results: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Type: &ast.StarExpr{X: ast.NewIdent("Loaders")},
+						},
+					},
+				},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{
+							ast.NewIdent("loaders"),
+							ast.NewIdent("_"),
+						},
+						Tok: token.DEFINE,
+						Rhs: []ast.Expr{
+							ast.NewIdent("ctx.Value(loadersContextKey{}).(*Loaders)"),
+						},
+					},
+					&ast.ReturnStmt{
+						Results: []ast.Expr{
+							ast.NewIdent("loaders"),
+						},
+					},
+				},
+			},
+		},
+		&ast.FuncDecl{
+			Name: ast.NewIdent("newLoaders"),
+			Recv: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("r")},
+						Type:  &ast.StarExpr{X: ast.NewIdent("resolver")},
+					},
+				},
+			},
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{},
+				Results: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Type: &ast.StarExpr{X: ast.NewIdent("Loaders")},
+						},
+					},
+				},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{
+							&ast.UnaryExpr{
+								Op: token.AND,
+								X: &ast.CompositeLit{
+									Type: ast.NewIdent("Loaders"),
+									Elts: elts,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		&ast.FuncDecl{
+			Name: ast.NewIdent("withLoaders"),
+			Recv: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("r")},
+						Type:  &ast.StarExpr{X: ast.NewIdent("resolver")},
+					},
+				},
+			},
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Names: []*ast.Ident{ast.NewIdent("ctx")},
+							Type: &ast.SelectorExpr{
+								X:   ast.NewIdent("context"),
+								Sel: ast.NewIdent("Context"),
+							},
+						},
+					},
+				},
+				Results: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Type: &ast.SelectorExpr{
+								X:   ast.NewIdent("context"),
+								Sel: ast.NewIdent("Context"),
+							},
+						},
+					},
+				},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{
+							ast.NewIdent("context.WithValue(ctx, loadersContextKey{}, r.newLoaders())"),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func generateDataLoaderOptions(loader DataLoaderConfig) []ast.Expr {
+	opts := make([]ast.Expr, 0, 2)
+	if loader.Wait != "" {
+		wait, _ := time.ParseDuration(loader.Wait)
+		opts = append(opts, ast.NewIdent(fmt.Sprintf("dataloader.WithWait(time.Duration(%d))", wait)))
+	}
+
+	if loader.MaxBatch > 0 {
+		opts = append(opts, ast.NewIdent(fmt.Sprintf("dataloader.WithMaxBatch(%d)", loader.MaxBatch)))
+	}
+
+	return opts
+}
+
+// generateOperationContextExpr returns the context an operation is executed with, which holds new loaders for every operation and every subscription event.
+func generateOperationContextExpr(ctxExpr ast.Expr, useDataLoader bool) ast.Expr {
+	if !useDataLoader {
+		return ctxExpr
+	}
+
+	return &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent("r"),
+			Sel: ast.NewIdent("withLoaders"),
+		},
+		Args: []ast.Expr{ctxExpr},
+	}
+}
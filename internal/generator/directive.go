@@ -0,0 +1,535 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strconv"
+
+	"github.com/n9te9/goliteql"
+	"github.com/n9te9/goliteql/schema"
+)
+
+const (
+	directiveRootName               = "DirectiveRoot"
+	directiveRootImplementationName = "directiveRoot"
+)
+
+const (
+	fieldDefinitionLocation    = "FIELD_DEFINITION"
+	objectLocation             = "OBJECT"
+	argumentDefinitionLocation = "ARGUMENT_DEFINITION"
+)
+
+// executableDirectiveLocations are the locations where a user-declared directive is executed around the resolution of the value it is applied to.
+var executableDirectiveLocations = []string{fieldDefinitionLocation, objectLocation, argumentDefinitionLocation}
+
+func isExecutableDirective(definition *schema.DirectiveDefinition) bool {
+	if bytes.Equal(definition.Name, goFieldDirectiveName) {
+		return false
+	}
+
+	for _, builtin := range schema.NewBuildInDirectives() {
+		if bytes.Equal(definition.Name, builtin.Name) {
+			return false
+		}
+	}
+
+	for _, location := range executableDirectiveLocations {
+		if hasDirectiveLocation(definition, location) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasDirectiveLocation(definition *schema.DirectiveDefinition, location string) bool {
+	for _, l := range definition.Locations {
+		if string(l.Name) == location {
+			return true
+		}
+	}
+
+	return false
+}
+
+// extractExecutableDirectives returns the directive definitions that get a method on DirectiveRoot.
+func extractExecutableDirectives(definitions schema.DirectiveDefinitions, indexes *schema.Indexes) []*schema.DirectiveDefinition {
+	ret := make([]*schema.DirectiveDefinition, 0)
+	for _, definition := range definitions {
+		if indexes.DirectiveIndex[string(definition.Name)] == definition && isExecutableDirective(definition) {
+			ret = append(ret, definition)
+		}
+	}
+
+	return ret
+}
+
+// executableDirectivesAt returns the directives applied at location that are executed through DirectiveRoot.
+func executableDirectivesAt(directives []*schema.Directive, location string, indexes *schema.Indexes) []*schema.Directive {
+	ret := make([]*schema.Directive, 0)
+	for _, directive := range directives {
+		definition, ok := indexes.DirectiveIndex[string(directive.Name)]
+		if ok && isExecutableDirective(definition) && hasDirectiveLocation(definition, location) {
+			ret = append(ret, directive)
+		}
+	}
+
+	return ret
+}
+
+// directiveArgumentValues returns the JSON encoded value of every argument of definition for the applied directive, falling back to the default values.
+func directiveArgumentValues(directive *schema.Directive, definition *schema.DirectiveDefinition) ([]string, error) {
+	for _, arg := range directive.Arguments {
+		found := false
+		for _, argDefinition := range definition.Arguments {
+			if bytes.Equal(arg.Name, argDefinition.Name) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("unknown argument %s on directive @%s", arg.Name, directive.Name)
+		}
+	}
+
+	values := make([]string, 0, len(definition.Arguments))
+	for _, argDefinition := range definition.Arguments {
+		value := argDefinition.Default
+		for _, arg := range directive.Arguments {
+			if bytes.Equal(arg.Name, argDefinition.Name) {
+				value = arg.Value
+				break
+			}
+		}
+
+		if value == nil {
+			if !argDefinition.Type.Nullable {
+				return nil, fmt.Errorf("argument %s of directive @%s is required", argDefinition.Name, directive.Name)
+			}
+			value = []byte("null")
+		}
+
+		expr, err := goliteql.NewValueParser(goliteql.NewValueLexer()).Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing argument %s of directive @%s: %w", argDefinition.Name, directive.Name, err)
+		}
+
+		b, err := goliteql.ValueToJSON(expr)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing argument %s of directive @%s: %w", argDefinition.Name, directive.Name, err)
+		}
+		values = append(values, string(b))
+	}
+
+	return values, nil
+}
+
+// validateExecutableDirectives checks the arguments of the directives executed by the generated code, so that they can be decoded at runtime.
+func validateExecutableDirectives(s *schema.Schema) error {
+	validate := func(coordinate string, directives []*schema.Directive, location string) error {
+		for _, directive := range executableDirectivesAt(directives, location, s.Indexes) {
+			if _, err := directiveArgumentValues(directive, s.Indexes.DirectiveIndex[string(directive.Name)]); err != nil {
+				return fmt.Errorf("%s: %w", coordinate, err)
+			}
+		}
+
+		return nil
+	}
+
+	validateField := func(typeName []byte, field *schema.FieldDefinition) error {
+		coordinate := fmt.Sprintf("%s.%s", typeName, field.Name)
+		if err := validate(coordinate, field.Directives, fieldDefinitionLocation); err != nil {
+			return err
+		}
+
+		for _, arg := range field.Arguments {
+			if err := validate(fmt.Sprintf("%s(%s:)", coordinate, arg.Name), arg.Directives, argumentDefinitionLocation); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	for _, op := range s.Operations {
+		typeName := s.Definition.Query
+		if op.OperationType.IsMutation() {
+			typeName = s.Definition.Mutation
+		} else if op.OperationType.IsSubscription() {
+			typeName = s.Definition.Subscription
+		}
+
+		for _, field := range op.Fields {
+			if err := validateField(typeName, field); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, t := range s.Types {
+		if err := validate(string(t.Name), t.Directives, objectLocation); err != nil {
+			return err
+		}
+
+		for _, field := range t.Fields {
+			if err := validateField(t.Name, field); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func newDirectiveMethodName(definition *schema.DirectiveDefinition) string {
+	return toUpperCase(string(definition.Name))
+}
+
+func newDirectiveArgumentName(arg *schema.ArgumentDefinition) string {
+	return fmt.Sprintf("arg%s", toUpperCase(string(arg.Name)))
+}
+
+func generateNextFuncType() *ast.FuncType {
+	return &ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Names: []*ast.Ident{ast.NewIdent("ctx")},
+					Type:  ast.NewIdent("context.Context"),
+				},
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{Type: ast.NewIdent("any")},
+				{Type: ast.NewIdent("error")},
+			},
+		},
+	}
+}
+
+func generateDirectiveFuncType(typePrefix string, definition *schema.DirectiveDefinition) *ast.FuncType {
+	params := []*ast.Field{
+		{
+			Names: []*ast.Ident{ast.NewIdent("ctx")},
+			Type:  ast.NewIdent("context.Context"),
+		},
+		{
+			Names: []*ast.Ident{ast.NewIdent("obj")},
+			Type:  ast.NewIdent("any"),
+		},
+		{
+			Names: []*ast.Ident{ast.NewIdent("next")},
+			Type:  generateNextFuncType(),
+		},
+	}
+
+	for _, arg := range definition.Arguments {
+		params = append(params, &ast.Field{
+			Names: []*ast.Ident{ast.NewIdent(string(arg.Name))},
+			Type:  generateTypeExprFromFieldType(typePrefix, arg.Type),
+		})
+	}
+
+	return &ast.FuncType{
+		Params: &ast.FieldList{
+			List: params,
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{Type: ast.NewIdent("any")},
+				{Type: ast.NewIdent("error")},
+			},
+		},
+	}
+}
+
+func generateDirectiveRootAccessorFields(definitions []*schema.DirectiveDefinition) []*ast.Field {
+	if len(definitions) == 0 {
+		return nil
+	}
+
+	return []*ast.Field{
+		{
+			Names: []*ast.Ident{ast.NewIdent(directiveRootName)},
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{},
+				Results: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Type: ast.NewIdent(directiveRootName),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func generateDirectiveRootInterface(typePrefix string, definitions []*schema.DirectiveDefinition) ast.Decl {
+	methods := make([]*ast.Field, 0, len(definitions))
+	for _, definition := range definitions {
+		methods = append(methods, &ast.Field{
+			Names: []*ast.Ident{ast.NewIdent(newDirectiveMethodName(definition))},
+			Type:  generateDirectiveFuncType(typePrefix, definition),
+		})
+	}
+
+	return &ast.GenDecl{
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name: ast.NewIdent(directiveRootName),
+				Type: &ast.InterfaceType{
+					Methods: &ast.FieldList{
+						List: methods,
+					},
+				},
+			},
+		},
+	}
+}
+
+func generateDirectiveRootImplementation(typePrefix string, definitions []*schema.DirectiveDefinition) []ast.Decl {
+	decls := []ast.Decl{
+		&ast.GenDecl{
+			Tok: token.TYPE,
+			Specs: []ast.Spec{
+				&ast.TypeSpec{
+					Name: ast.NewIdent(directiveRootImplementationName),
+					Type: &ast.StructType{
+						Fields: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Type: &ast.StarExpr{X: ast.NewIdent("resolver")},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		&ast.FuncDecl{
+			Name: ast.NewIdent(directiveRootName),
+			Recv: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("r")},
+						Type:  &ast.StarExpr{X: ast.NewIdent("resolver")},
+					},
+				},
+			},
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{},
+				Results: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Type: ast.NewIdent(directiveRootName),
+						},
+					},
+				},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{
+							&ast.UnaryExpr{
+								Op: token.AND,
+								X: &ast.CompositeLit{
+									Type: ast.NewIdent(directiveRootImplementationName),
+									Elts: []ast.Expr{ast.NewIdent("r")},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, definition := range definitions {
+		decls = append(decls, &ast.FuncDecl{
+			Doc:  &ast.CommentGroup{},
+			Name: ast.NewIdent(newDirectiveMethodName(definition)),
+			Recv: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("r")},
+						Type:  &ast.StarExpr{X: ast.NewIdent(directiveRootImplementationName)},
+					},
+				},
+			},
+			Type: generateDirectiveFuncType(typePrefix, definition),
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ExprStmt{
+						X: &ast.CallExpr{
+							Fun: ast.NewIdent("panic"),
+							Args: []ast.Expr{
+								&ast.BasicLit{
+									Kind:  token.STRING,
+									Value: fmt.Sprintf(`"@%s directive is not implemented"`, definition.Name),
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	return decls
+}
+
+// generateDirectiveFuncExprs returns an executor.DirectiveFunc for every directive, which calls DirectiveRoot with the argument values applied in the schema.
+func generateDirectiveFuncExprs(directives []*schema.Directive, objExpr ast.Expr, typePrefix string, indexes *schema.Indexes) []ast.Expr {
+	exprs := make([]ast.Expr, 0, len(directives))
+	for _, directive := range directives {
+		definition := indexes.DirectiveIndex[string(directive.Name)]
+		// the values are checked by validateExecutableDirectives
+		values, _ := directiveArgumentValues(directive, definition)
+
+		body := make([]ast.Stmt, 0)
+		args := []ast.Expr{ast.NewIdent("ctx"), objExpr, ast.NewIdent("next")}
+		for i, arg := range definition.Arguments {
+			argName := ast.NewIdent(newDirectiveArgumentName(arg))
+			body = append(body,
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{argName, ast.NewIdent("err")},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{
+						&ast.CallExpr{
+							Fun: &ast.IndexExpr{
+								X: &ast.SelectorExpr{
+									X:   ast.NewIdent("executor"),
+									Sel: ast.NewIdent("UnmarshalDirectiveArgument"),
+								},
+								Index: generateTypeExprFromFieldType(typePrefix, arg.Type),
+							},
+							Args: []ast.Expr{
+								&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(values[i])},
+							},
+						},
+					},
+				},
+				generateReturnErrorHandlingStmt([]ast.Expr{ast.NewIdent("nil")}),
+			)
+			args = append(args, argName)
+		}
+
+		body = append(body, &ast.ReturnStmt{
+			Results: []ast.Expr{
+				&ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X: &ast.CallExpr{
+							Fun: &ast.SelectorExpr{
+								X:   ast.NewIdent("r"),
+								Sel: ast.NewIdent(directiveRootName),
+							},
+						},
+						Sel: ast.NewIdent(newDirectiveMethodName(definition)),
+					},
+					Args: args,
+				},
+			},
+		})
+
+		exprs = append(exprs, &ast.FuncLit{
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Names: []*ast.Ident{ast.NewIdent("ctx")},
+							Type:  ast.NewIdent("context.Context"),
+						},
+						{
+							Names: []*ast.Ident{ast.NewIdent("next")},
+							Type:  generateNextFuncType(),
+						},
+					},
+				},
+				Results: &ast.FieldList{
+					List: []*ast.Field{
+						{Type: ast.NewIdent("any")},
+						{Type: ast.NewIdent("error")},
+					},
+				},
+			},
+			Body: &ast.BlockStmt{List: body},
+		})
+	}
+
+	return exprs
+}
+
+// generateWithDirectivesExpr resolves a value of resultType through the directives, with resolveBody as the innermost resolver.
+func generateWithDirectivesExpr(resultType ast.Expr, resolveBody []ast.Stmt, directiveExprs []ast.Expr) ast.Expr {
+	args := []ast.Expr{
+		ast.NewIdent("ctx"),
+		&ast.FuncLit{
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Names: []*ast.Ident{ast.NewIdent("ctx")},
+							Type:  ast.NewIdent("context.Context"),
+						},
+					},
+				},
+				Results: &ast.FieldList{
+					List: []*ast.Field{
+						{Type: resultType},
+						{Type: ast.NewIdent("error")},
+					},
+				},
+			},
+			Body: &ast.BlockStmt{
+				List: resolveBody,
+			},
+		},
+	}
+
+	return &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent("executor"),
+			Sel: ast.NewIdent("WithDirectives"),
+		},
+		Args: append(args, directiveExprs...),
+	}
+}
+
+// generateArgumentDirectivesStmts passes every argument with executable directives through them, after the arguments are extracted.
+func generateArgumentDirectivesStmts(args schema.ArgumentDefinitions, objExpr ast.Expr, typePrefix string, indexes *schema.Indexes, errorHandlingStmt ast.Stmt) []ast.Stmt {
+	stmts := make([]ast.Stmt, 0)
+	for _, arg := range args {
+		directives := executableDirectivesAt(arg.Directives, argumentDefinitionLocation, indexes)
+		if len(directives) == 0 {
+			continue
+		}
+
+		stmts = append(stmts,
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{ast.NewIdent(string(arg.Name)), ast.NewIdent("err")},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{
+					generateWithDirectivesExpr(
+						generateTypeExprFromFieldType(typePrefix, arg.Type),
+						[]ast.Stmt{
+							&ast.ReturnStmt{
+								Results: []ast.Expr{ast.NewIdent(string(arg.Name)), ast.NewIdent("nil")},
+							},
+						},
+						generateDirectiveFuncExprs(directives, objExpr, typePrefix, indexes),
+					),
+				},
+			},
+			errorHandlingStmt,
+		)
+	}
+
+	return stmts
+}
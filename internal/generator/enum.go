@@ -14,6 +14,7 @@ func generateEnumModelAST(enums []*schema.EnumDefinition) []ast.Decl {
 	for _, e := range enums {
 		if string(e.Name) != "" {
 			decls = append(decls, &ast.GenDecl{
+				Doc: generateDocComment(e.Description),
 				Tok: token.TYPE,
 				Specs: []ast.Spec{
 					&ast.TypeSpec{
@@ -33,6 +34,7 @@ func generateEnumModelAST(enums []*schema.EnumDefinition) []ast.Decl {
 		specs := make([]ast.Spec, 0, len(e.Values))
 		for _, v := range e.Values {
 			specs = append(specs, &ast.ValueSpec{
+				Doc: generateDocComment(v.Description),
 				Names: []*ast.Ident{
 					ast.NewIdent(string(v.Name)),
 				},
@@ -0,0 +1,772 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/n9te9/goliteql/federation"
+	"github.com/n9te9/goliteql/schema"
+)
+
+const entityResolverName = "EntityResolver"
+
+type federationDefinition struct {
+	name   string
+	source string
+}
+
+var federationTypeDefinitions = []federationDefinition{
+	{name: "_Any", source: "scalar _Any"},
+	{name: "FieldSet", source: "scalar FieldSet"},
+	{name: "link__Import", source: "scalar link__Import"},
+	{name: "link__Purpose", source: "enum link__Purpose {\n\tSECURITY\n\tEXECUTION\n}"},
+	{name: "_Service", source: "type _Service {\n\tsdl: String\n}"},
+}
+
+var federationDirectiveDefinitions = []federationDefinition{
+	{name: "link", source: "directive @link(url: String!, as: String, import: [link__Import], for: link__Purpose) repeatable on SCHEMA"},
+	{name: "key", source: "directive @key(fields: FieldSet!, resolvable: Boolean = true) repeatable on OBJECT | INTERFACE"},
+	{name: "requires", source: "directive @requires(fields: FieldSet!) on FIELD_DEFINITION"},
+	{name: "provides", source: "directive @provides(fields: FieldSet!) on FIELD_DEFINITION"},
+	{name: "external", source: "directive @external on OBJECT | FIELD_DEFINITION"},
+	{name: "shareable", source: "directive @shareable repeatable on OBJECT | FIELD_DEFINITION"},
+	{name: "inaccessible", source: "directive @inaccessible on FIELD_DEFINITION | OBJECT | INTERFACE | UNION | ARGUMENT_DEFINITION | SCALAR | ENUM | ENUM_VALUE | INPUT_OBJECT | INPUT_FIELD_DEFINITION"},
+	{name: "override", source: "directive @override(from: String!) on FIELD_DEFINITION"},
+	{name: "tag", source: "directive @tag(name: String!) repeatable on FIELD_DEFINITION | OBJECT | INTERFACE | UNION | ARGUMENT_DEFINITION | SCALAR | ENUM | ENUM_VALUE | INPUT_OBJECT | INPUT_FIELD_DEFINITION"},
+	{name: "extends", source: "directive @extends on OBJECT | INTERFACE"},
+	{name: "interfaceObject", source: "directive @interfaceObject on OBJECT"},
+	{name: "composeDirective", source: "directive @composeDirective(name: String!) repeatable on SCHEMA"},
+}
+
+type federationEntity struct {
+	definition *schema.TypeDefinition
+	keys       [][]*schema.FieldDefinition
+	externals  []string
+}
+
+// isFederationDirective also accepts the federation__ namespace used when a directive is not imported by @link.
+func isFederationDirective(directive *schema.Directive, name string) bool {
+	return string(directive.Name) == name || string(directive.Name) == "federation__"+name
+}
+
+func federationDirectiveArgument(directive *schema.Directive, name string) (string, bool) {
+	for _, arg := range directive.Arguments {
+		if string(arg.Name) != name {
+			continue
+		}
+
+		if value, err := strconv.Unquote(string(arg.Value)); err == nil {
+			return value, true
+		}
+
+		return string(arg.Value), true
+	}
+
+	return "", false
+}
+
+func extractFederationEntities(s *schema.Schema) ([]*federationEntity, error) {
+	entities := make([]*federationEntity, 0)
+	for _, t := range s.Types {
+		if t.IsIntrospection() {
+			continue
+		}
+
+		entity := &federationEntity{
+			definition: t,
+		}
+
+		for _, directive := range t.Directives {
+			if !isFederationDirective(directive, "key") {
+				continue
+			}
+
+			if resolvable, ok := federationDirectiveArgument(directive, "resolvable"); ok && resolvable == "false" {
+				continue
+			}
+
+			fieldSet, ok := federationDirectiveArgument(directive, "fields")
+			if !ok {
+				return nil, fmt.Errorf("@key on %s must have fields", t.Name)
+			}
+
+			key, err := newFederationKey(t, fieldSet)
+			if err != nil {
+				return nil, err
+			}
+
+			if !entity.hasKey(key) {
+				entity.keys = append(entity.keys, key)
+			}
+		}
+
+		if len(entity.keys) == 0 {
+			continue
+		}
+
+		for _, field := range t.Fields {
+			for _, directive := range field.Directives {
+				if isFederationDirective(directive, "external") {
+					entity.externals = append(entity.externals, string(field.Name))
+				}
+			}
+		}
+
+		entities = append(entities, entity)
+	}
+
+	return entities, nil
+}
+
+func newFederationKey(t *schema.TypeDefinition, fieldSet string) ([]*schema.FieldDefinition, error) {
+	fields, err := federation.ParseFieldSet(fieldSet)
+	if err != nil {
+		return nil, fmt.Errorf("invalid @key on %s: %w", t.Name, err)
+	}
+
+	key := make([]*schema.FieldDefinition, 0, len(fields))
+	for _, f := range fields {
+		field := t.GetFieldByName([]byte(f.Name))
+		if field == nil {
+			return nil, fmt.Errorf("@key field %s is not defined on %s", f.Name, t.Name)
+		}
+
+		key = append(key, field)
+	}
+
+	return key, nil
+}
+
+func (e *federationEntity) hasKey(key []*schema.FieldDefinition) bool {
+	for _, k := range e.keys {
+		if newFindEntityFuncName(e.definition, k) == newFindEntityFuncName(e.definition, key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// applyFederationRequiresConfig resolves @requires fields with a field resolver, since they are computed from @external fields.
+func applyFederationRequiresConfig(s *schema.Schema) {
+	for _, t := range s.Types {
+		for _, field := range t.Fields {
+			for _, directive := range field.Directives {
+				if isFederationDirective(directive, "requires") {
+					markForceResolver(field)
+				}
+			}
+		}
+	}
+}
+
+// hasFederationLink reports whether the schema definition or an extension of it links the federation specification, so it has to be called before the schema is merged.
+func hasFederationLink(s *schema.Schema) bool {
+	definitions := []*schema.SchemaDefinition{s.Definition}
+	for _, ext := range s.Extends {
+		if definition, ok := ext.(*schema.SchemaDefinition); ok {
+			definitions = append(definitions, definition)
+		}
+	}
+
+	for _, definition := range definitions {
+		if definition == nil {
+			continue
+		}
+
+		for _, directive := range definition.Directives {
+			if url, ok := federationDirectiveArgument(directive, "url"); ok && string(directive.Name) == "link" && strings.Contains(url, "specs.apollo.dev/federation/") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// newFederationServiceSDL returns the SDL served by _service, which links the federation specification when the schema does not.
+func newFederationServiceSDL(federationLinked bool, source []byte) []byte {
+	if federationLinked {
+		return source
+	}
+
+	imports := make([]string, 0, len(federationDirectiveDefinitions))
+	for _, definition := range federationDirectiveDefinitions {
+		if definition.name != "link" {
+			imports = append(imports, strconv.Quote("@"+definition.name))
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "extend schema @link(url: %s, import: [%s])\n\n", strconv.Quote(federation.LinkURL), strings.Join(imports, ", "))
+	buf.Write(source)
+
+	return buf.Bytes()
+}
+
+func isFederationTypeName(name string) bool {
+	for _, definition := range federationTypeDefinitions {
+		if definition.name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newFederationSchemaSource returns the schema used at runtime, with the federation definitions and the _service and _entities fields.
+func newFederationSchemaSource(s *schema.Schema, source []byte, entities []*federationEntity) []byte {
+	var buf bytes.Buffer
+	buf.Write(source)
+
+	for _, definition := range federationTypeDefinitions {
+		if !isDefinedType(definition.name, s.Indexes) {
+			fmt.Fprintf(&buf, "\n%s\n", definition.source)
+		}
+	}
+
+	for _, definition := range federationDirectiveDefinitions {
+		if s.Directives.Get([]byte(definition.name)) == nil {
+			fmt.Fprintf(&buf, "\n%s\n", definition.source)
+		}
+	}
+
+	if len(entities) > 0 {
+		names := make([]string, 0, len(entities))
+		for _, entity := range entities {
+			names = append(names, string(entity.definition.Name))
+		}
+		fmt.Fprintf(&buf, "\nunion _Entity = %s\n", strings.Join(names, " | "))
+	}
+
+	fmt.Fprintf(&buf, "\nextend type %s {\n", s.Definition.Query)
+	if len(entities) > 0 {
+		buf.WriteString("\t_entities(representations: [_Any!]!): [_Entity]!\n")
+	}
+	buf.WriteString("\t_service: _Service!\n}\n")
+
+	return buf.Bytes()
+}
+
+func newFindEntityFuncName(definition *schema.TypeDefinition, key []*schema.FieldDefinition) string {
+	names := make([]string, 0, len(key))
+	for _, field := range key {
+		names = append(names, toUpperCase(string(field.Name)))
+	}
+
+	return "Find" + string(definition.Name) + "By" + strings.Join(names, "And")
+}
+
+func newEntityKeyArgName(field *schema.FieldDefinition) string {
+	name := string(field.Name)
+	switch name {
+	case "ctx", "r", "node", "representation", "entity", "err":
+		return name + "Arg"
+	}
+
+	if token.IsKeyword(name) {
+		return name + "Arg"
+	}
+
+	return name
+}
+
+func generateFindEntityFuncType(typePrefix string, entity *federationEntity, key []*schema.FieldDefinition) *ast.FuncType {
+	params := []*ast.Field{
+		{
+			Names: []*ast.Ident{ast.NewIdent("ctx")},
+			Type: &ast.SelectorExpr{
+				X:   ast.NewIdent("context"),
+				Sel: ast.NewIdent("Context"),
+			},
+		},
+	}
+
+	for _, field := range key {
+		params = append(params, &ast.Field{
+			Names: []*ast.Ident{ast.NewIdent(newEntityKeyArgName(field))},
+			Type:  generateModelTypeExpr(typePrefix, field.Type),
+		})
+	}
+
+	return &ast.FuncType{
+		Params: &ast.FieldList{
+			List: params,
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: &ast.StarExpr{
+						X: &ast.SelectorExpr{
+							X:   ast.NewIdent(typePrefix),
+							Sel: ast.NewIdent(string(entity.definition.Name)),
+						},
+					},
+				},
+				{
+					Type: ast.NewIdent("error"),
+				},
+			},
+		},
+	}
+}
+
+func generateEntityResolverFields(entities []*federationEntity) []*ast.Field {
+	if len(entities) == 0 {
+		return nil
+	}
+
+	return []*ast.Field{
+		{
+			Type: ast.NewIdent(entityResolverName),
+		},
+	}
+}
+
+func generateEntityResolverInterface(typePrefix string, entities []*federationEntity) ast.Decl {
+	methods := make([]*ast.Field, 0, len(entities))
+	for _, entity := range entities {
+		for _, key := range entity.keys {
+			methods = append(methods, &ast.Field{
+				Names: []*ast.Ident{ast.NewIdent(newFindEntityFuncName(entity.definition, key))},
+				Type:  generateFindEntityFuncType(typePrefix, entity, key),
+			})
+		}
+	}
+
+	return &ast.GenDecl{
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name: ast.NewIdent(entityResolverName),
+				Type: &ast.InterfaceType{
+					Methods: &ast.FieldList{
+						List: methods,
+					},
+				},
+			},
+		},
+	}
+}
+
+func generateEntityResolverImplementation(typePrefix string, entities []*federationEntity) []ast.Decl {
+	decls := make([]ast.Decl, 0, len(entities))
+	for _, entity := range entities {
+		for _, key := range entity.keys {
+			funcName := newFindEntityFuncName(entity.definition, key)
+			decls = append(decls, &ast.FuncDecl{
+				Doc:  &ast.CommentGroup{},
+				Name: ast.NewIdent(funcName),
+				Recv: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Names: []*ast.Ident{ast.NewIdent("r")},
+							Type:  &ast.StarExpr{X: ast.NewIdent("resolver")},
+						},
+					},
+				},
+				Type: generateFindEntityFuncType(typePrefix, entity, key),
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ExprStmt{
+							X: &ast.CallExpr{
+								Fun: ast.NewIdent("panic"),
+								Args: []ast.Expr{
+									&ast.BasicLit{
+										Kind:  token.STRING,
+										Value: fmt.Sprintf(`"%s is not implemented"`, funcName),
+									},
+								},
+							},
+						},
+					},
+				},
+			})
+		}
+	}
+
+	return decls
+}
+
+func generateFederationExecutorCases(entities []*federationEntity) []ast.Stmt {
+	cases := []ast.Stmt{
+		&ast.CaseClause{
+			List: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: `"_service"`}},
+			Body: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent("federation.ResolveService(node, serviceSDL)"),
+					},
+				},
+			},
+		},
+	}
+
+	if len(entities) > 0 {
+		cases = append(cases, &ast.CaseClause{
+			List: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: `"_entities"`}},
+			Body: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent("r.resolveEntities(ctx, node, variables)"),
+					},
+				},
+			},
+		})
+	}
+
+	return cases
+}
+
+func generateServiceSDLDecl(sdl []byte) ast.Decl {
+	decl := generateSchemaSourceDecl(sdl).(*ast.GenDecl)
+	decl.Specs[0].(*ast.ValueSpec).Names[0] = ast.NewIdent("serviceSDL")
+
+	return decl
+}
+
+func generateResolverRecv() *ast.FieldList {
+	return &ast.FieldList{
+		List: []*ast.Field{
+			{
+				Names: []*ast.Ident{ast.NewIdent("r")},
+				Type:  &ast.StarExpr{X: ast.NewIdent("resolver")},
+			},
+		},
+	}
+}
+
+func generateEntityFuncType() *ast.FuncType {
+	return &ast.FuncType{
+		Params: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Names: []*ast.Ident{ast.NewIdent("ctx")},
+					Type:  ast.NewIdent("context.Context"),
+				},
+				{
+					Names: []*ast.Ident{ast.NewIdent("representation")},
+					Type:  ast.NewIdent("federation.Representation"),
+				},
+				{
+					Names: []*ast.Ident{ast.NewIdent("node")},
+					Type:  ast.NewIdent("*executor.Node"),
+				},
+			},
+		},
+		Results: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Type: ast.NewIdent("executor.Nullable"),
+				},
+				{
+					Type: ast.NewIdent("error"),
+				},
+			},
+		},
+	}
+}
+
+func generateFederationDecls(typePrefix string, entities []*federationEntity, serviceSDL []byte) []ast.Decl {
+	decls := []ast.Decl{generateServiceSDLDecl(serviceSDL)}
+	if len(entities) == 0 {
+		return decls
+	}
+
+	decls = append(decls, &ast.FuncDecl{
+		Name: ast.NewIdent("resolveEntities"),
+		Recv: generateResolverRecv(),
+		Type: &ast.FuncType{
+			Params: generateNodeWalkerArgs(),
+			Results: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Type: ast.NewIdent("any"),
+					},
+					{
+						Type: ast.NewIdent("error"),
+					},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{ast.NewIdent("representations"), ast.NewIdent("err")},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{ast.NewIdent("federation.ParseRepresentations(node, variables)")},
+				},
+				generateReturnErrorHandlingStmt([]ast.Expr{ast.NewIdent("nil")}),
+				// an entity that fails to resolve becomes null, since _entities has nullable items,
+				// and entities are resolved concurrently so that their loads are batched together
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						&ast.CallExpr{
+							Fun: &ast.SelectorExpr{
+								X:   ast.NewIdent("executor"),
+								Sel: ast.NewIdent("CompleteListConcurrently"),
+							},
+							Args: []ast.Expr{
+								ast.NewIdent("ctx"),
+								ast.NewIdent("representations"),
+								ast.NewIdent("true"),
+								&ast.FuncLit{
+									Type: &ast.FuncType{
+										Params: &ast.FieldList{
+											List: []*ast.Field{
+												{
+													Names: []*ast.Ident{ast.NewIdent("ctx")},
+													Type: &ast.SelectorExpr{
+														X:   ast.NewIdent("context"),
+														Sel: ast.NewIdent("Context"),
+													},
+												},
+												{
+													Names: []*ast.Ident{ast.NewIdent("representation")},
+													Type: &ast.SelectorExpr{
+														X:   ast.NewIdent("federation"),
+														Sel: ast.NewIdent("Representation"),
+													},
+												},
+											},
+										},
+										Results: &ast.FieldList{
+											List: []*ast.Field{
+												{
+													Type: &ast.SelectorExpr{
+														X:   ast.NewIdent("executor"),
+														Sel: ast.NewIdent("Nullable"),
+													},
+												},
+												{
+													Type: ast.NewIdent("error"),
+												},
+											},
+										},
+									},
+									Body: &ast.BlockStmt{
+										List: []ast.Stmt{
+											&ast.ReturnStmt{
+												Results: []ast.Expr{
+													&ast.CallExpr{
+														Fun: &ast.SelectorExpr{
+															X:   ast.NewIdent("r"),
+															Sel: ast.NewIdent("resolveEntity"),
+														},
+														Args: []ast.Expr{
+															ast.NewIdent("ctx"),
+															ast.NewIdent("representation"),
+															ast.NewIdent("node"),
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	typeCases := make([]ast.Stmt, 0, len(entities))
+	for _, entity := range entities {
+		typeNames := []string{strconv.Quote(string(entity.definition.Name))}
+		for _, iface := range entity.definition.Interfaces {
+			typeNames = append(typeNames, strconv.Quote(string(iface)))
+		}
+
+		typeCases = append(typeCases, &ast.CaseClause{
+			List: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(string(entity.definition.Name))}},
+			Body: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent(fmt.Sprintf("r.resolve%sEntity(ctx, representation, federation.EntityNode(node, %s))", entity.definition.Name, strings.Join(typeNames, ", "))),
+					},
+				},
+			},
+		})
+	}
+
+	decls = append(decls, &ast.FuncDecl{
+		Name: ast.NewIdent("resolveEntity"),
+		Recv: generateResolverRecv(),
+		Type: generateEntityFuncType(),
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.SwitchStmt{
+					Tag: ast.NewIdent("representation.Typename()"),
+					Body: &ast.BlockStmt{
+						List: typeCases,
+					},
+				},
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent("nil"),
+						ast.NewIdent(`fmt.Errorf("%s is not an entity", representation.Typename())`),
+					},
+				},
+			},
+		},
+	})
+
+	for _, entity := range entities {
+		decls = append(decls, generateResolveEntityFuncDecl(typePrefix, entity))
+	}
+
+	return decls
+}
+
+func generateResolveEntityFuncDecl(typePrefix string, entity *federationEntity) ast.Decl {
+	typeName := string(entity.definition.Name)
+
+	keyCases := make([]ast.Stmt, 0, len(entity.keys)+1)
+	for _, key := range entity.keys {
+		fieldNames := make([]string, 0, len(key))
+		args := []ast.Expr{ast.NewIdent("ctx")}
+		body := make([]ast.Stmt, 0, len(key)*2+1)
+		for _, field := range key {
+			fieldNames = append(fieldNames, strconv.Quote(string(field.Name)))
+			argName := newEntityKeyArgName(field)
+			args = append(args, ast.NewIdent(argName))
+
+			body = append(body,
+				&ast.DeclStmt{
+					Decl: &ast.GenDecl{
+						Tok: token.VAR,
+						Specs: []ast.Spec{
+							&ast.ValueSpec{
+								Names: []*ast.Ident{ast.NewIdent(argName)},
+								Type:  generateModelTypeExpr(typePrefix, field.Type),
+							},
+						},
+					},
+				},
+				&ast.IfStmt{
+					Init: &ast.AssignStmt{
+						Lhs: []ast.Expr{ast.NewIdent("err")},
+						Tok: token.DEFINE,
+						Rhs: []ast.Expr{ast.NewIdent(fmt.Sprintf("representation.Unmarshal(%q, &%s)", field.Name, argName))},
+					},
+					Cond: ast.NewIdent("err != nil"),
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ReturnStmt{
+								Results: []ast.Expr{ast.NewIdent("nil"), ast.NewIdent("err")},
+							},
+						},
+					},
+				},
+			)
+		}
+
+		body = append(body, &ast.AssignStmt{
+			Lhs: []ast.Expr{ast.NewIdent("entity"), ast.NewIdent("err")},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X:   ast.NewIdent("r"),
+						Sel: ast.NewIdent(newFindEntityFuncName(entity.definition, key)),
+					},
+					Args: args,
+				},
+			},
+		})
+
+		keyCases = append(keyCases, &ast.CaseClause{
+			List: []ast.Expr{ast.NewIdent(fmt.Sprintf("representation.Has(%s)", strings.Join(fieldNames, ", ")))},
+			Body: body,
+		})
+	}
+
+	keyCases = append(keyCases, &ast.CaseClause{
+		Body: []ast.Stmt{
+			&ast.ReturnStmt{
+				Results: []ast.Expr{
+					ast.NewIdent("nil"),
+					ast.NewIdent(fmt.Sprintf(`fmt.Errorf("representation of %s does not match any @key")`, typeName)),
+				},
+			},
+		},
+	})
+
+	stmts := []ast.Stmt{
+		&ast.DeclStmt{
+			Decl: &ast.GenDecl{
+				Tok: token.VAR,
+				Specs: []ast.Spec{
+					&ast.ValueSpec{
+						Names: []*ast.Ident{ast.NewIdent("entity")},
+						Type:  ast.NewIdent(fmt.Sprintf("*%s.%s", typePrefix, typeName)),
+					},
+					&ast.ValueSpec{
+						Names: []*ast.Ident{ast.NewIdent("err")},
+						Type:  ast.NewIdent("error"),
+					},
+				},
+			},
+		},
+		&ast.SwitchStmt{
+			Body: &ast.BlockStmt{
+				List: keyCases,
+			},
+		},
+		generateReturnErrorHandlingStmt([]ast.Expr{ast.NewIdent("nil")}),
+		&ast.IfStmt{
+			Cond: ast.NewIdent("entity == nil"),
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{ast.NewIdent("nil"), ast.NewIdent("nil")},
+					},
+				},
+			},
+		},
+	}
+
+	if len(entity.externals) > 0 {
+		externals := make([]string, 0, len(entity.externals))
+		for _, name := range entity.externals {
+			externals = append(externals, strconv.Quote(name))
+		}
+
+		stmts = append(stmts, &ast.IfStmt{
+			Init: &ast.AssignStmt{
+				Lhs: []ast.Expr{ast.NewIdent("err")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{ast.NewIdent(fmt.Sprintf("representation.Populate(entity, %s)", strings.Join(externals, ", ")))},
+			},
+			Cond: ast.NewIdent("err != nil"),
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{ast.NewIdent("nil"), ast.NewIdent("err")},
+					},
+				},
+			},
+		})
+	}
+
+	stmts = append(stmts, &ast.ReturnStmt{
+		Results: []ast.Expr{
+			ast.NewIdent(fmt.Sprintf("r.apply%sResponse(ctx, *entity, node)", typeName)),
+		},
+	})
+
+	return &ast.FuncDecl{
+		Name: ast.NewIdent(fmt.Sprintf("resolve%sEntity", typeName)),
+		Recv: generateResolverRecv(),
+		Type: generateEntityFuncType(),
+		Body: &ast.BlockStmt{
+			List: stmts,
+		},
+	}
+}
@@ -0,0 +1,538 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/n9te9/goliteql/schema"
+)
+
+var goFieldDirectiveName = []byte("goField")
+
+func applyForceResolverConfig(s *schema.Schema, forceResolvers map[string][]string) error {
+	for typeName, fieldNames := range forceResolvers {
+		t := s.Indexes.GetTypeDefinition(typeName)
+		if t == nil {
+			return fmt.Errorf("type %s is not defined in schema", typeName)
+		}
+
+		for _, fieldName := range fieldNames {
+			field := t.GetFieldByName([]byte(fieldName))
+			if field == nil {
+				return fmt.Errorf("field %s is not defined on %s in schema", fieldName, typeName)
+			}
+
+			markForceResolver(field)
+		}
+	}
+
+	return nil
+}
+
+func markForceResolver(field *schema.FieldDefinition) {
+	if isForceResolverField(field) {
+		return
+	}
+
+	field.Directives = append(field.Directives, &schema.Directive{
+		Name: goFieldDirectiveName,
+		Arguments: []*schema.DirectiveArgument{
+			{
+				Name:  []byte("forceResolver"),
+				Value: []byte("true"),
+			},
+		},
+	})
+}
+
+func isForceResolverField(field *schema.FieldDefinition) bool {
+	for _, directive := range field.Directives {
+		if !bytes.Equal(directive.Name, goFieldDirectiveName) {
+			continue
+		}
+
+		for _, arg := range directive.Arguments {
+			if bytes.Equal(arg.Name, []byte("forceResolver")) && bytes.Equal(arg.Value, []byte("true")) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func forceResolverFields(definition *schema.TypeDefinition) schema.FieldDefinitions {
+	fields := make(schema.FieldDefinitions, 0)
+	for _, field := range definition.Fields {
+		if isForceResolverField(field) {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+func extractFieldResolverTypes(types schema.TypeDefinitions) schema.TypeDefinitions {
+	ret := make(schema.TypeDefinitions, 0)
+	for _, t := range types {
+		if t.IsIntrospection() {
+			continue
+		}
+
+		if len(forceResolverFields(t)) > 0 {
+			ret = append(ret, t)
+		}
+	}
+
+	return ret
+}
+
+func newFieldResolverName(definition *schema.TypeDefinition) string {
+	return string(definition.Name) + "Resolver"
+}
+
+func newFieldResolverImplementationName(definition *schema.TypeDefinition) string {
+	name := newFieldResolverName(definition)
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+func newFieldResolverArgsFuncName(definition *schema.TypeDefinition, field *schema.FieldDefinition) string {
+	return string(definition.Name) + toUpperCase(string(field.Name))
+}
+
+func generateFieldResolverArgs(typePrefix string, definition *schema.TypeDefinition, field *schema.FieldDefinition, indexes *schema.Indexes) *ast.FieldList {
+	args := generateResolverArgs(typePrefix, field, indexes)
+	obj := &ast.Field{
+		Names: []*ast.Ident{ast.NewIdent("obj")},
+		Type: &ast.StarExpr{
+			X: &ast.SelectorExpr{
+				X:   ast.NewIdent(typePrefix),
+				Sel: ast.NewIdent(string(definition.Name)),
+			},
+		},
+	}
+
+	args.List = append([]*ast.Field{args.List[0], obj}, args.List[1:]...)
+
+	return args
+}
+
+func generateFieldResolverReturns(typePrefix string, field *schema.FieldDefinition) *ast.FieldList {
+	return &ast.FieldList{
+		List: []*ast.Field{
+			{
+				Type: generateModelTypeExpr(typePrefix, field.Type),
+			},
+			{
+				Type: ast.NewIdent("error"),
+			},
+		},
+	}
+}
+
+func generateModelTypeExpr(typePrefix string, fieldType *schema.FieldType) ast.Expr {
+	return qualifyModelTypeExpr(typePrefix, generateExpr(fieldType))
+}
+
+func qualifyModelTypeExpr(typePrefix string, expr ast.Expr) ast.Expr {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: qualifyModelTypeExpr(typePrefix, e.X)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Elt: qualifyModelTypeExpr(typePrefix, e.Elt)}
+	case *ast.Ident:
+		switch e.Name {
+		case "int", "float64", "string", "bool":
+			return e
+		}
+
+		return &ast.SelectorExpr{
+			X:   ast.NewIdent(typePrefix),
+			Sel: e,
+		}
+	}
+
+	return expr
+}
+
+func generateFieldResolverInterfaces(typePrefix string, types schema.TypeDefinitions, indexes *schema.Indexes) []ast.Decl {
+	decls := make([]ast.Decl, 0, len(types))
+
+	for _, t := range types {
+		methods := make([]*ast.Field, 0)
+		for _, field := range forceResolverFields(t) {
+			methods = append(methods, &ast.Field{
+				Names: []*ast.Ident{ast.NewIdent(toUpperCase(string(field.Name)))},
+				Type: &ast.FuncType{
+					Params:  generateFieldResolverArgs(typePrefix, t, field, indexes),
+					Results: generateFieldResolverReturns(typePrefix, field),
+				},
+			})
+		}
+
+		decls = append(decls, &ast.GenDecl{
+			Tok: token.TYPE,
+			Specs: []ast.Spec{
+				&ast.TypeSpec{
+					Name: ast.NewIdent(newFieldResolverName(t)),
+					Type: &ast.InterfaceType{
+						Methods: &ast.FieldList{
+							List: methods,
+						},
+					},
+				},
+			},
+		})
+	}
+
+	return decls
+}
+
+func generateFieldResolverAccessorFields(types schema.TypeDefinitions) []*ast.Field {
+	fields := make([]*ast.Field, 0, len(types))
+	for _, t := range types {
+		fields = append(fields, &ast.Field{
+			Names: []*ast.Ident{ast.NewIdent(newFieldResolverName(t))},
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{},
+				Results: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Type: ast.NewIdent(newFieldResolverName(t)),
+						},
+					},
+				},
+			},
+		})
+	}
+
+	return fields
+}
+
+func generateFieldResolverImplementation(typePrefix string, types schema.TypeDefinitions, indexes *schema.Indexes) []ast.Decl {
+	decls := make([]ast.Decl, 0)
+
+	for _, t := range types {
+		implName := newFieldResolverImplementationName(t)
+
+		decls = append(decls, &ast.GenDecl{
+			Tok: token.TYPE,
+			Specs: []ast.Spec{
+				&ast.TypeSpec{
+					Name: ast.NewIdent(implName),
+					Type: &ast.StructType{
+						Fields: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Type: &ast.StarExpr{X: ast.NewIdent("resolver")},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+
+		decls = append(decls, &ast.FuncDecl{
+			Name: ast.NewIdent(newFieldResolverName(t)),
+			Recv: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("r")},
+						Type:  &ast.StarExpr{X: ast.NewIdent("resolver")},
+					},
+				},
+			},
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{},
+				Results: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Type: ast.NewIdent(newFieldResolverName(t)),
+						},
+					},
+				},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{
+							&ast.UnaryExpr{
+								Op: token.AND,
+								X: &ast.CompositeLit{
+									Type: ast.NewIdent(implName),
+									Elts: []ast.Expr{ast.NewIdent("r")},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+
+		for _, field := range forceResolverFields(t) {
+			decls = append(decls, &ast.FuncDecl{
+				Doc:  &ast.CommentGroup{},
+				Name: ast.NewIdent(toUpperCase(string(field.Name))),
+				Recv: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Names: []*ast.Ident{ast.NewIdent("r")},
+							Type:  &ast.StarExpr{X: ast.NewIdent(implName)},
+						},
+					},
+				},
+				Type: &ast.FuncType{
+					Params:  generateFieldResolverArgs(typePrefix, t, field, indexes),
+					Results: generateFieldResolverReturns(typePrefix, field),
+				},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ExprStmt{
+							X: &ast.CallExpr{
+								Fun: ast.NewIdent("panic"),
+								Args: []ast.Expr{
+									&ast.BasicLit{
+										Kind:  token.STRING,
+										Value: fmt.Sprintf(`"%s.%s resolver is not implemented"`, t.Name, field.Name),
+									},
+								},
+							},
+						},
+					},
+				},
+			})
+		}
+	}
+
+	return decls
+}
+
+func generateFieldResolverArgumentDecls(typePrefix string, types schema.TypeDefinitions, indexes *schema.Indexes) []ast.Decl {
+	decls := make([]ast.Decl, 0)
+
+	for _, t := range types {
+		for _, field := range forceResolverFields(t) {
+			if len(field.Arguments) == 0 {
+				continue
+			}
+
+			renamed := *field
+			renamed.Name = []byte(newFieldResolverArgsFuncName(t, field))
+			decls = append(decls, generateExtractOperationArgumentsDecl(typePrefix, &renamed, indexes))
+		}
+	}
+
+	return decls
+}
+
+// generateFieldResolversExpr maps the fields of definition that have a resolver to the calls of their resolvers, which executor.ResolveFields runs concurrently.
+func generateFieldResolversExpr(definition *schema.TypeDefinition, fields schema.FieldDefinitions, typePrefix string, indexes *schema.Indexes) ast.Expr {
+	elts := make([]ast.Expr, 0, len(fields))
+	for _, field := range fields {
+		elts = append(elts, &ast.KeyValueExpr{
+			Key: &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(string(field.Name))},
+			Value: &ast.FuncLit{
+				Type: &ast.FuncType{
+					Params: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{ast.NewIdent("ctx")},
+								Type: &ast.SelectorExpr{
+									X:   ast.NewIdent("context"),
+									Sel: ast.NewIdent("Context"),
+								},
+							},
+							{
+								Names: []*ast.Ident{ast.NewIdent("child")},
+								Type: &ast.StarExpr{
+									X: &ast.SelectorExpr{
+										X:   ast.NewIdent("executor"),
+										Sel: ast.NewIdent("Node"),
+									},
+								},
+							},
+						},
+					},
+					Results: &ast.FieldList{
+						List: []*ast.Field{
+							{Type: ast.NewIdent("any")},
+							{Type: ast.NewIdent("error")},
+						},
+					},
+				},
+				Body: &ast.BlockStmt{
+					List: generateFieldResolverCallStmts(definition, field, typePrefix, indexes),
+				},
+			},
+		})
+	}
+
+	return &ast.CompositeLit{
+		Type: &ast.MapType{
+			Key: ast.NewIdent("string"),
+			Value: &ast.SelectorExpr{
+				X:   ast.NewIdent("executor"),
+				Sel: ast.NewIdent("FieldResolver"),
+			},
+		},
+		Elts: elts,
+	}
+}
+
+// generateFieldResolverCallStmts calls the resolver of field with its arguments for the selected field node child.
+func generateFieldResolverCallStmts(definition *schema.TypeDefinition, field *schema.FieldDefinition, typePrefix string, indexes *schema.Indexes) []ast.Stmt {
+	stmts := make([]ast.Stmt, 0)
+
+	args := []ast.Expr{
+		ast.NewIdent("ctx"),
+		&ast.UnaryExpr{
+			Op: token.AND,
+			X:  ast.NewIdent("resolverRet"),
+		},
+	}
+
+	if len(field.Arguments) > 0 {
+		errorHandlingStmt := &ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  ast.NewIdent("err"),
+				Op: token.NEQ,
+				Y:  ast.NewIdent("nil"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{
+							ast.NewIdent("nil"),
+							ast.NewIdent("err"),
+						},
+					},
+				},
+			},
+		}
+
+		lhs := make([]ast.Expr, 0, len(field.Arguments)+1)
+		for _, arg := range field.Arguments {
+			lhs = append(lhs, ast.NewIdent(string(arg.Name)))
+			args = append(args, ast.NewIdent(string(arg.Name)))
+		}
+		lhs = append(lhs, ast.NewIdent("err"))
+
+		stmts = append(stmts,
+			&ast.AssignStmt{
+				Lhs: lhs,
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{
+					&ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X:   ast.NewIdent("r"),
+							Sel: ast.NewIdent(fmt.Sprintf("extract%sArgs", newFieldResolverArgsFuncName(definition, field))),
+						},
+						Args: []ast.Expr{
+							ast.NewIdent("child"),
+							&ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   ast.NewIdent("executor"),
+									Sel: ast.NewIdent("VariablesFromContext"),
+								},
+								Args: []ast.Expr{
+									ast.NewIdent("ctx"),
+								},
+							},
+						},
+					},
+				},
+			},
+			errorHandlingStmt,
+		)
+		stmts = append(stmts, generateArgumentDirectivesStmts(field.Arguments, &ast.UnaryExpr{Op: token.AND, X: ast.NewIdent("resolverRet")}, typePrefix, indexes, errorHandlingStmt)...)
+	}
+
+	var rhs ast.Expr = &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X: &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("r"),
+					Sel: ast.NewIdent(newFieldResolverName(definition)),
+				},
+			},
+			Sel: ast.NewIdent(toUpperCase(string(field.Name))),
+		},
+		Args: args,
+	}
+
+	if directives := executableDirectivesAt(field.Directives, fieldDefinitionLocation, indexes); len(directives) > 0 {
+		rhs = generateFieldWithDirectivesExpr(field, directives, typePrefix, indexes, []ast.Expr{rhs})
+	}
+
+	return append(stmts, &ast.ReturnStmt{Results: []ast.Expr{rhs}})
+}
+
+// generateResolvedFieldValueStmts takes the value of field resolved by executor.ResolveFields.
+func generateResolvedFieldValueStmts(field *schema.FieldDefinition, typePrefix string, nestExpr ast.Expr) []ast.Stmt {
+	return generateFieldValueStmts(field, nestExpr, &ast.CallExpr{
+		Fun: &ast.IndexExpr{
+			X: &ast.SelectorExpr{
+				X:   ast.NewIdent("executor"),
+				Sel: ast.NewIdent("ResolvedValue"),
+			},
+			Index: generateFieldResolverReturns(typePrefix, field).List[0].Type,
+		},
+		Args: []ast.Expr{
+			&ast.IndexExpr{
+				X:     ast.NewIdent("resolved"),
+				Index: ast.NewIdent("i"),
+			},
+		},
+	})
+}
+
+// generateFieldDirectiveStmts passes the value of a field that has no resolver through the directives applied to it.
+func generateFieldDirectiveStmts(field *schema.FieldDefinition, directives []*schema.Directive, typePrefix string, indexes *schema.Indexes, nestExpr ast.Expr) []ast.Stmt {
+	value := &ast.SelectorExpr{
+		X:   ast.NewIdent("resolverRet"),
+		Sel: ast.NewIdent(toUpperCase(string(field.Name))),
+	}
+
+	return generateFieldValueStmts(field, nestExpr, generateFieldWithDirectivesExpr(field, directives, typePrefix, indexes, []ast.Expr{value, ast.NewIdent("nil")}))
+}
+
+func generateFieldWithDirectivesExpr(field *schema.FieldDefinition, directives []*schema.Directive, typePrefix string, indexes *schema.Indexes, resolveResults []ast.Expr) ast.Expr {
+	return generateWithDirectivesExpr(
+		generateFieldResolverReturns(typePrefix, field).List[0].Type,
+		[]ast.Stmt{&ast.ReturnStmt{Results: resolveResults}},
+		generateDirectiveFuncExprs(directives, &ast.UnaryExpr{Op: token.AND, X: ast.NewIdent("resolverRet")}, typePrefix, indexes),
+	)
+}
+
+// generateFieldValueStmts stores the value of a field resolved by rhs on the model, before the field is completed.
+func generateFieldValueStmts(field *schema.FieldDefinition, nestExpr ast.Expr, rhs ast.Expr) []ast.Stmt {
+	fieldRet := fmt.Sprintf("field%s", toUpperCase(string(field.Name)))
+	return []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent(fieldRet),
+				ast.NewIdent("err"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{rhs},
+		},
+		generateFieldErrorHandlingStmt(field.Type, nestExpr),
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				&ast.SelectorExpr{
+					X:   ast.NewIdent("resolverRet"),
+					Sel: ast.NewIdent(toUpperCase(string(field.Name))),
+				},
+			},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{
+				ast.NewIdent(fieldRet),
+			},
+		},
+	}
+}
@@ -18,6 +18,7 @@ import (
 
 type Generator struct {
 	Schema                          *schema.Schema
+	schemaSource                    []byte
 	queryAST                        *ast.File
 	mutationAST                     *ast.File
 	subscriptionAST                 *ast.File
@@ -36,6 +37,29 @@ type Generator struct {
 	mutationResolverAST            *ast.File
 	mutationResolverOutputFilePath string
 
+	subscriptionResolverOutput         io.Writer
+	subscriptionResolverAST            *ast.File
+	subscriptionResolverOutputFilePath string
+
+	fieldResolverOutput         io.Writer
+	fieldResolverAST            *ast.File
+	fieldResolverOutputFilePath string
+
+	dataLoaderResolverOutput         io.Writer
+	dataLoaderResolverAST            *ast.File
+	dataLoaderResolverOutputFilePath string
+
+	entityResolverOutput         io.Writer
+	entityResolverAST            *ast.File
+	entityResolverOutputFilePath string
+	federationEntities           []*federationEntity
+	serviceSDL                   []byte
+
+	directiveResolverOutput         io.Writer
+	directiveResolverAST            *ast.File
+	directiveResolverOutputFilePath string
+	executableDirectives            []*schema.DirectiveDefinition
+
 	rootResolverOutput io.Writer
 	resolverAST        *ast.File
 
@@ -52,24 +76,33 @@ type Generator struct {
 }
 
 type ScalarConfig struct {
-	Name               string `yaml:"name"`
-	Package            string `yaml:"package"`
-	Type               string `yaml:"type"`
-	ParseInjectionCode string `yaml:"parse_injection_code"`
+	Name          string `yaml:"name"`
+	Package       string `yaml:"package,omitempty"`
+	Type          string `yaml:"type,omitempty"`
+	MarshalFunc   string `yaml:"marshal_func,omitempty"`
+	UnmarshalFunc string `yaml:"unmarshal_func,omitempty"`
 }
 
 type Config struct {
-	SchemaDirectory             string         `yaml:"schema_directory"`
-	ModelOutputFile             string         `yaml:"model_output_file"`
-	QueryResolverOutputFile     string         `yaml:"query_resolver_output_file"`
-	MutationResolverOutputFile  string         `yaml:"mutation_resolver_output_file"`
-	RootResolverOutputFile      string         `yaml:"root_resolver_output_file"`
-	ResolverGeneratedOutputFile string         `yaml:"resolver_generated_output_file"`
-	EnumOutputFile              string         `yaml:"enum_output_file"`
-	ScalarOutputFile            string         `yaml:"scalar_output_file"`
-	ModelPackageName            string         `yaml:"model_package_name"`
-	ResolverPackageName         string         `yaml:"resolver_package_name"`
-	Scalars                     []ScalarConfig `yaml:"scalars"`
+	SchemaDirectory                string              `yaml:"schema_directory"`
+	ModelOutputFile                string              `yaml:"model_output_file"`
+	QueryResolverOutputFile        string              `yaml:"query_resolver_output_file"`
+	MutationResolverOutputFile     string              `yaml:"mutation_resolver_output_file"`
+	SubscriptionResolverOutputFile string              `yaml:"subscription_resolver_output_file"`
+	FieldResolverOutputFile        string              `yaml:"field_resolver_output_file"`
+	DataLoaderResolverOutputFile   string              `yaml:"dataloader_resolver_output_file"`
+	EntityResolverOutputFile       string              `yaml:"entity_resolver_output_file"`
+	DirectiveResolverOutputFile    string              `yaml:"directive_resolver_output_file"`
+	RootResolverOutputFile         string              `yaml:"root_resolver_output_file"`
+	ResolverGeneratedOutputFile    string              `yaml:"resolver_generated_output_file"`
+	EnumOutputFile                 string              `yaml:"enum_output_file"`
+	ScalarOutputFile               string              `yaml:"scalar_output_file"`
+	ModelPackageName               string              `yaml:"model_package_name"`
+	ResolverPackageName            string              `yaml:"resolver_package_name"`
+	Scalars                        []ScalarConfig      `yaml:"scalars"`
+	ForceResolvers                 map[string][]string `yaml:"force_resolvers"`
+	DataLoaders                    []DataLoaderConfig  `yaml:"dataloaders"`
+	Federation                     bool                `yaml:"federation"`
 }
 
 var gqlFilePattern = regexp.MustCompile(`^.+\.gql$|^.+\.graphql$`)
@@ -94,6 +127,26 @@ func createDirectories(conf *Config) {
 	if err := os.MkdirAll(filepath.Dir(conf.RootResolverOutputFile), 0755); err != nil {
 		log.Fatalf("error creating root resolver output directory: %v", err)
 	}
+
+	if err := os.MkdirAll(filepath.Dir(conf.SubscriptionResolverOutputFile), 0755); err != nil {
+		log.Fatalf("error creating subscription resolver output directory: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(conf.FieldResolverOutputFile), 0755); err != nil {
+		log.Fatalf("error creating field resolver output directory: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(conf.DataLoaderResolverOutputFile), 0755); err != nil {
+		log.Fatalf("error creating dataloader resolver output directory: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(conf.EntityResolverOutputFile), 0755); err != nil {
+		log.Fatalf("error creating entity resolver output directory: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(conf.DirectiveResolverOutputFile), 0755); err != nil {
+		log.Fatalf("error creating directive resolver output directory: %v", err)
+	}
 }
 
 func createFile(filePath string) (*os.File, error) {
@@ -105,16 +158,11 @@ func createFile(filePath string) (*os.File, error) {
 	return file, nil
 }
 
-func NewGenerator(config *Config) (*Generator, error) {
-	createDirectories(config)
-
-	schemaDirectory := config.SchemaDirectory
-	modelPackagePath := config.ModelPackageName
-	resolverPackagePath := config.ResolverPackageName
-
+// readGraphQLFiles concatenates the .graphql and .gql files under directory.
+func readGraphQLFiles(directory string) ([]byte, error) {
 	gqlFilePaths := make([]string, 0)
 
-	err := filepath.Walk(schemaDirectory, func(path string, info os.FileInfo, err error) error {
+	err := filepath.Walk(directory, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -148,6 +196,41 @@ func NewGenerator(config *Config) (*Generator, error) {
 		fileContents = append(fileContents, content...)
 	}
 
+	return fileContents, nil
+}
+
+func NewGenerator(config *Config) (*Generator, error) {
+	if config.SubscriptionResolverOutputFile == "" {
+		config.SubscriptionResolverOutputFile = filepath.Join(filepath.Dir(config.RootResolverOutputFile), "subscription.resolver.go")
+	}
+
+	if config.FieldResolverOutputFile == "" {
+		config.FieldResolverOutputFile = filepath.Join(filepath.Dir(config.RootResolverOutputFile), "field.resolver.go")
+	}
+
+	if config.DataLoaderResolverOutputFile == "" {
+		config.DataLoaderResolverOutputFile = filepath.Join(filepath.Dir(config.RootResolverOutputFile), "dataloader.resolver.go")
+	}
+
+	if config.EntityResolverOutputFile == "" {
+		config.EntityResolverOutputFile = filepath.Join(filepath.Dir(config.RootResolverOutputFile), "entity.resolver.go")
+	}
+
+	if config.DirectiveResolverOutputFile == "" {
+		config.DirectiveResolverOutputFile = filepath.Join(filepath.Dir(config.RootResolverOutputFile), "directive.resolver.go")
+	}
+
+	createDirectories(config)
+
+	schemaDirectory := config.SchemaDirectory
+	modelPackagePath := config.ModelPackageName
+	resolverPackagePath := config.ResolverPackageName
+
+	fileContents, err := readGraphQLFiles(schemaDirectory)
+	if err != nil {
+		return nil, err
+	}
+
 	lexer := schema.NewLexer()
 	parser := schema.NewParser(lexer)
 	s, err := parser.Parse(fileContents)
@@ -155,12 +238,58 @@ func NewGenerator(config *Config) (*Generator, error) {
 		return nil, fmt.Errorf("error parsing schema: %w", err)
 	}
 
+	// Merge drops the schema extensions that may link the federation specification
+	federationLinked := hasFederationLink(s)
+
 	s, err = s.Merge()
 	if err != nil {
 		return nil, fmt.Errorf("error merging schema: %w", err)
 	}
 
-	var modelOutput, queryResolverOutput, mutationResolverOutput, rootResolverOutput, resolverGeneratedOutput, enumOutput, scalarOutput io.Writer
+	if err := applyForceResolverConfig(s, config.ForceResolvers); err != nil {
+		return nil, fmt.Errorf("error applying force resolvers: %w", err)
+	}
+
+	resolveScalarConfigs(config.Scalars)
+	fileContents = append(fileContents, applyScalarSpecifiedBy(config.Scalars, s.Indexes)...)
+
+	schemaSource := fileContents
+	var federationEntities []*federationEntity
+	var serviceSDL []byte
+	if config.Federation {
+		if s.GetQuery() == nil {
+			return nil, fmt.Errorf("federation requires a %s type", s.Definition.Query)
+		}
+
+		federationEntities, err = extractFederationEntities(s)
+		if err != nil {
+			return nil, fmt.Errorf("error extracting federation entities: %w", err)
+		}
+
+		applyFederationRequiresConfig(s)
+		serviceSDL = newFederationServiceSDL(federationLinked, fileContents)
+		schemaSource = newFederationSchemaSource(s, fileContents, federationEntities)
+
+		// federation directives are interpreted by the router, not executed by the subgraph
+		for _, definition := range federationDirectiveDefinitions {
+			delete(s.Indexes.DirectiveIndex, definition.name)
+		}
+	}
+
+	if err := validateExecutableDirectives(s); err != nil {
+		return nil, fmt.Errorf("error validating directives: %w", err)
+	}
+	executableDirectives := extractExecutableDirectives(s.Directives, s.Indexes)
+
+	if err := validateDataLoaderConfigs(config.DataLoaders, s.Indexes); err != nil {
+		return nil, fmt.Errorf("error validating dataloaders: %w", err)
+	}
+
+	if err := validateScalarConfigs(config.Scalars, s.Indexes, config.Federation); err != nil {
+		return nil, fmt.Errorf("error validating scalars: %w", err)
+	}
+
+	var modelOutput, queryResolverOutput, mutationResolverOutput, subscriptionResolverOutput, fieldResolverOutput, dataLoaderResolverOutput, entityResolverOutput, directiveResolverOutput, rootResolverOutput, resolverGeneratedOutput, enumOutput, scalarOutput io.Writer
 	if len(extractUserEnumDefinitions(s.Enums)) > 0 {
 		enumOutput, err = createFile(config.EnumOutputFile)
 		if err != nil {
@@ -168,7 +297,7 @@ func NewGenerator(config *Config) (*Generator, error) {
 		}
 	}
 
-	if len(s.Scalars) > 0 {
+	if len(config.Scalars) > 0 {
 		scalarOutput, err = createFile(config.ScalarOutputFile)
 		if err != nil {
 			return nil, fmt.Errorf("error creating scalar output file: %w", err)
@@ -189,6 +318,41 @@ func NewGenerator(config *Config) (*Generator, error) {
 		}
 	}
 
+	if s.Definition.Subscription != nil {
+		subscriptionResolverOutput, err = createFile(config.SubscriptionResolverOutputFile)
+		if err != nil {
+			return nil, fmt.Errorf("error creating subscription resolver output file: %w", err)
+		}
+	}
+
+	if len(extractFieldResolverTypes(s.Types)) > 0 {
+		fieldResolverOutput, err = createFile(config.FieldResolverOutputFile)
+		if err != nil {
+			return nil, fmt.Errorf("error creating field resolver output file: %w", err)
+		}
+	}
+
+	if len(config.DataLoaders) > 0 {
+		dataLoaderResolverOutput, err = createFile(config.DataLoaderResolverOutputFile)
+		if err != nil {
+			return nil, fmt.Errorf("error creating dataloader resolver output file: %w", err)
+		}
+	}
+
+	if len(federationEntities) > 0 {
+		entityResolverOutput, err = createFile(config.EntityResolverOutputFile)
+		if err != nil {
+			return nil, fmt.Errorf("error creating entity resolver output file: %w", err)
+		}
+	}
+
+	if len(executableDirectives) > 0 {
+		directiveResolverOutput, err = createFile(config.DirectiveResolverOutputFile)
+		if err != nil {
+			return nil, fmt.Errorf("error creating directive resolver output file: %w", err)
+		}
+	}
+
 	if s.Definition.Subscription != nil || s.Definition.Query != nil || s.Definition.Mutation != nil {
 		rootResolverOutput, err = createFile(config.RootResolverOutputFile)
 		if err != nil {
@@ -208,6 +372,7 @@ func NewGenerator(config *Config) (*Generator, error) {
 
 	g := &Generator{
 		Schema:          s,
+		schemaSource:    schemaSource,
 		queryAST:        &ast.File{},
 		mutationAST:     &ast.File{},
 		subscriptionAST: &ast.File{},
@@ -231,24 +396,57 @@ func NewGenerator(config *Config) (*Generator, error) {
 			Name:  ast.NewIdent(filepath.Base(resolverPackagePath)),
 			Decls: []ast.Decl{},
 		},
+		subscriptionResolverAST: &ast.File{
+			Name:  ast.NewIdent(filepath.Base(resolverPackagePath)),
+			Decls: []ast.Decl{},
+		},
+		fieldResolverAST: &ast.File{
+			Name:  ast.NewIdent(filepath.Base(resolverPackagePath)),
+			Decls: []ast.Decl{},
+		},
+		dataLoaderResolverAST: &ast.File{
+			Name:  ast.NewIdent(filepath.Base(resolverPackagePath)),
+			Decls: []ast.Decl{},
+		},
+		entityResolverAST: &ast.File{
+			Name:  ast.NewIdent(filepath.Base(resolverPackagePath)),
+			Decls: []ast.Decl{},
+		},
+		directiveResolverAST: &ast.File{
+			Name:  ast.NewIdent(filepath.Base(resolverPackagePath)),
+			Decls: []ast.Decl{},
+		},
 		generatedAST: &ast.File{
 			Name:  ast.NewIdent(filepath.Base(resolverPackagePath)),
 			Decls: []ast.Decl{},
 		},
-		modelOutput:                     modelOutput,
-		modelPackagePath:                modelPackagePath,
-		queryResolverOutput:             queryResolverOutput,
-		mutationResolverOutput:          mutationResolverOutput,
-		rootResolverOutput:              rootResolverOutput,
-		enumOutput:                      enumOutput,
-		scalarOutput:                    scalarOutput,
-		resolverPackagePath:             resolverPackagePath,
-		queryResolverOutputFilePath:     config.QueryResolverOutputFile,
-		mutationResolverOutputFilePath:  config.MutationResolverOutputFile,
-		rootResolverOutputFilePath:      config.RootResolverOutputFile,
-		resolverGeneratedOutput:         resolverGeneratedOutput,
-		resolverGeneratedOutputFilePath: config.ResolverGeneratedOutputFile,
-		config:                          config,
+		modelOutput:                        modelOutput,
+		modelPackagePath:                   modelPackagePath,
+		queryResolverOutput:                queryResolverOutput,
+		mutationResolverOutput:             mutationResolverOutput,
+		subscriptionResolverOutput:         subscriptionResolverOutput,
+		fieldResolverOutput:                fieldResolverOutput,
+		dataLoaderResolverOutput:           dataLoaderResolverOutput,
+		entityResolverOutput:               entityResolverOutput,
+		rootResolverOutput:                 rootResolverOutput,
+		enumOutput:                         enumOutput,
+		scalarOutput:                       scalarOutput,
+		resolverPackagePath:                resolverPackagePath,
+		queryResolverOutputFilePath:        config.QueryResolverOutputFile,
+		mutationResolverOutputFilePath:     config.MutationResolverOutputFile,
+		subscriptionResolverOutputFilePath: config.SubscriptionResolverOutputFile,
+		fieldResolverOutputFilePath:        config.FieldResolverOutputFile,
+		dataLoaderResolverOutputFilePath:   config.DataLoaderResolverOutputFile,
+		entityResolverOutputFilePath:       config.EntityResolverOutputFile,
+		directiveResolverOutput:            directiveResolverOutput,
+		directiveResolverOutputFilePath:    config.DirectiveResolverOutputFile,
+		executableDirectives:               executableDirectives,
+		federationEntities:                 federationEntities,
+		serviceSDL:                         serviceSDL,
+		rootResolverOutputFilePath:         config.RootResolverOutputFile,
+		resolverGeneratedOutput:            resolverGeneratedOutput,
+		resolverGeneratedOutputFilePath:    config.ResolverGeneratedOutputFile,
+		config:                             config,
 	}
 
 	return g, nil
@@ -275,6 +473,7 @@ func (g *Generator) generateModel() error {
 
 	for _, input := range g.Schema.Inputs {
 		g.modelAST.Decls = append(g.modelAST.Decls, &ast.GenDecl{
+			Doc: generateDocComment(input.Description),
 			Tok: token.TYPE,
 			Specs: []ast.Spec{
 				&ast.TypeSpec{
@@ -327,6 +526,7 @@ func (g *Generator) generateModel() error {
 		}
 
 		decl := &ast.GenDecl{
+			Doc: generateDocComment(t.Description),
 			Tok: token.TYPE,
 			Specs: []ast.Spec{
 				&ast.TypeSpec{
@@ -377,10 +577,12 @@ func (g *Generator) generateModel() error {
 		g.modelAST.Decls = append(g.modelAST.Decls, generateSelectionSetInput(op.Fields)...)
 	}
 
-	format.Node(g.modelOutput, token.NewFileSet(), g.modelAST)
+	if err := formatNodeWithDocs(g.modelOutput, g.modelAST); err != nil {
+		return fmt.Errorf("error formatting model: %w", err)
+	}
 
 	if g.enumOutput != nil {
-		if err := format.Node(g.enumOutput, token.NewFileSet(), g.enumAST); err != nil {
+		if err := formatNodeWithDocs(g.enumOutput, g.enumAST); err != nil {
 			return fmt.Errorf("error formatting enum: %w", err)
 		}
 	}
@@ -395,6 +597,23 @@ func (g *Generator) generateModel() error {
 	return nil
 }
 
+// formatNodeWithDocs formats the file twice so that doc comments built by
+// generateDocComment are indented like the declarations they document.
+func formatNodeWithDocs(w io.Writer, file *ast.File) error {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, token.NewFileSet(), file); err != nil {
+		return err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(src)
+	return err
+}
+
 func generateOperationImport(operation *schema.OperationDefinition, modelPackagePath string) []ast.Spec {
 	specs := make([]ast.Spec, 0)
 
@@ -476,6 +695,45 @@ func (g *Generator) generateResolver() error {
 			},
 		}
 
+		importSpecs = append(importSpecs, &ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: `"github.com/n9te9/goliteql/schema"`,
+			},
+		}, &ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: `"github.com/n9te9/goliteql/validator"`,
+			},
+		})
+
+		if g.Schema.GetSubscription() != nil {
+			importSpecs = append(importSpecs, &ast.ImportSpec{
+				Path: &ast.BasicLit{
+					Kind:  token.STRING,
+					Value: `"github.com/n9te9/goliteql/transport"`,
+				},
+			})
+		}
+
+		if len(g.config.DataLoaders) > 0 {
+			importSpecs = append(importSpecs, &ast.ImportSpec{
+				Path: &ast.BasicLit{
+					Kind:  token.STRING,
+					Value: `"github.com/n9te9/goliteql/executor/dataloader"`,
+				},
+			})
+		}
+
+		if g.config.Federation {
+			importSpecs = append(importSpecs, &ast.ImportSpec{
+				Path: &ast.BasicLit{
+					Kind:  token.STRING,
+					Value: `"github.com/n9te9/goliteql/federation"`,
+				},
+			})
+		}
+
 		importSpecs = append(importSpecs, generateResolverImport().Specs...)
 
 		// generate import statement
@@ -485,11 +743,24 @@ func (g *Generator) generateResolver() error {
 		})
 	}
 
-	g.resolverAST.Decls = append(g.resolverAST.Decls, generateResolverInterface(g.Schema.GetQuery(), g.Schema.GetMutation(), g.Schema.GetSubscription()))
+	g.resolverAST.Decls = append(g.resolverAST.Decls, &ast.GenDecl{
+		Tok: token.IMPORT,
+		Specs: []ast.Spec{
+			&ast.ImportSpec{
+				Path: &ast.BasicLit{
+					Kind:  token.STRING,
+					Value: `"github.com/n9te9/goliteql/validator"`,
+				},
+			},
+		},
+	})
+
+	fieldResolverTypes := extractFieldResolverTypes(g.Schema.Types)
+	g.resolverAST.Decls = append(g.resolverAST.Decls, generateResolverInterface(g.Schema.GetQuery(), g.Schema.GetMutation(), g.Schema.GetSubscription(), append(append(append(generateFieldResolverAccessorFields(fieldResolverTypes), generateDataLoaderResolverFields(g.config.DataLoaders)...), generateEntityResolverFields(g.federationEntities)...), generateDirectiveRootAccessorFields(g.executableDirectives)...)))
 
 	queryFields := make(schema.FieldDefinitions, 0)
 	mutationFields := make(schema.FieldDefinitions, 0)
-	fields := make(schema.FieldDefinitions, 0)
+	subscriptionFields := make(schema.FieldDefinitions, 0)
 
 	modelPrefix := filepath.Base(g.modelPackagePath)
 
@@ -498,27 +769,36 @@ func (g *Generator) generateResolver() error {
 	g.generatedAST.Decls = append(g.generatedAST.Decls, generateApplyResponseFuncDecl(g.Schema.Unions, g.Schema.Indexes, modelPrefix)...)
 	g.generatedAST.Decls = append(g.generatedAST.Decls, generateApplyResponseFuncDecl(g.Schema.Scalars, g.Schema.Indexes, modelPrefix)...)
 	g.generatedAST.Decls = append(g.generatedAST.Decls, generateApplyResponseFuncDecl(g.Schema.Enums, g.Schema.Indexes, modelPrefix)...)
+	g.generatedAST.Decls = append(g.generatedAST.Decls, generateFieldResolverArgumentDecls(modelPrefix, fieldResolverTypes, g.Schema.Indexes)...)
+	g.generatedAST.Decls = append(g.generatedAST.Decls, generateScalarUnmarshalersDecl(g.config.Scalars, modelPrefix))
 
 	if q := g.Schema.GetQuery(); q != nil {
 		queryFields = q.Fields
-		g.generatedAST.Decls = append(g.generatedAST.Decls, generateQueryExecutor(q))
+		var federationCases []ast.Stmt
+		if g.config.Federation {
+			federationCases = generateFederationExecutorCases(g.federationEntities)
+			g.generatedAST.Decls = append(g.generatedAST.Decls, generateFederationDecls(modelPrefix, g.federationEntities, g.serviceSDL)...)
+		}
+		g.generatedAST.Decls = append(g.generatedAST.Decls, generateQueryExecutor(q, g.Schema.Definition.Query, modelPrefix, g.Schema.Indexes, federationCases...))
 		// g.generatedAST.Decls = append(g.generatedAST.Decls, generateApplyQueryResponseFuncDecls(q, g.Schema.Indexes, 0, modelPrefix)...)
-		g.generatedAST.Decls = append(g.generatedAST.Decls, generateApplyResponseFuncDeclFromOperationDefinition(q, modelPrefix, g.Schema.Indexes)...)
+		g.generatedAST.Decls = append(g.generatedAST.Decls, generateApplyResponseFuncDeclFromOperationDefinition(q, g.Schema.Definition.Query, modelPrefix, g.Schema.Indexes)...)
 		g.generatedAST.Decls = append(g.generatedAST.Decls, generateOperationArgumentDecls(modelPrefix, q, g.Schema.Indexes)...)
 	}
 
 	if m := g.Schema.GetMutation(); m != nil {
 		mutationFields = m.Fields
-		g.generatedAST.Decls = append(g.generatedAST.Decls, generateMutationExecutor(m))
+		g.generatedAST.Decls = append(g.generatedAST.Decls, generateMutationExecutor(m, g.Schema.Definition.Mutation, modelPrefix, g.Schema.Indexes))
 		// g.generatedAST.Decls = append(g.generatedAST.Decls, generateApplyQueryResponseFuncDecls(m, g.Schema.Indexes, 0, modelPrefix)...)
-		g.generatedAST.Decls = append(g.generatedAST.Decls, generateApplyResponseFuncDeclFromOperationDefinition(m, modelPrefix, g.Schema.Indexes)...)
+		g.generatedAST.Decls = append(g.generatedAST.Decls, generateApplyResponseFuncDeclFromOperationDefinition(m, g.Schema.Definition.Mutation, modelPrefix, g.Schema.Indexes)...)
 		g.generatedAST.Decls = append(g.generatedAST.Decls, generateOperationArgumentDecls(modelPrefix, m, g.Schema.Indexes)...)
 	}
 
 	if s := g.Schema.GetSubscription(); s != nil {
-		fields = append(fields, s.Fields...)
-		g.generatedAST.Decls = append(g.generatedAST.Decls, generateSubscriptionExecutor(g.Schema.GetSubscription()))
-		g.generatedAST.Decls = append(g.generatedAST.Decls, generateWrapResponseWriter(g.Schema.GetSubscription())...)
+		subscriptionFields = s.Fields
+		g.generatedAST.Decls = append(g.generatedAST.Decls, generateSubscriptionExecutor(s, modelPrefix, g.Schema.Indexes, len(g.config.DataLoaders) > 0))
+		g.generatedAST.Decls = append(g.generatedAST.Decls, generateSubscribeFuncDecl(g.Schema.GetQuery(), g.Schema.GetMutation(), len(g.config.DataLoaders) > 0))
+		g.generatedAST.Decls = append(g.generatedAST.Decls, generateApplyResponseFuncDeclFromOperationDefinition(s, g.Schema.Definition.Subscription, modelPrefix, g.Schema.Indexes)...)
+		g.generatedAST.Decls = append(g.generatedAST.Decls, generateOperationArgumentDecls(modelPrefix, s, g.Schema.Indexes)...)
 	}
 
 	if g.Schema.GetQuery() != nil {
@@ -537,14 +817,112 @@ func (g *Generator) generateResolver() error {
 		g.mutationResolverAST.Decls = append(g.mutationResolverAST.Decls, generateInterfaceField(modelPrefix, g.Schema.GetMutation(), g.Schema.Indexes))
 	}
 
+	if g.Schema.GetSubscription() != nil {
+		g.subscriptionResolverAST.Decls = append(g.subscriptionResolverAST.Decls, &ast.GenDecl{
+			Tok:   token.IMPORT,
+			Specs: generateOperationImport(g.Schema.GetSubscription(), g.modelPackagePath),
+		})
+		g.subscriptionResolverAST.Decls = append(g.subscriptionResolverAST.Decls, generateInterfaceField(modelPrefix, g.Schema.GetSubscription(), g.Schema.Indexes))
+	}
+
+	if len(fieldResolverTypes) > 0 {
+		g.fieldResolverAST.Decls = append(g.fieldResolverAST.Decls, &ast.GenDecl{
+			Tok: token.IMPORT,
+			Specs: []ast.Spec{
+				&ast.ImportSpec{
+					Path: &ast.BasicLit{
+						Kind:  token.STRING,
+						Value: `"context"`,
+					},
+				},
+				&ast.ImportSpec{
+					Path: &ast.BasicLit{
+						Kind:  token.STRING,
+						Value: fmt.Sprintf(`"%s"`, g.modelPackagePath),
+					},
+				},
+			},
+		})
+		g.fieldResolverAST.Decls = append(g.fieldResolverAST.Decls, generateFieldResolverInterfaces(modelPrefix, fieldResolverTypes, g.Schema.Indexes)...)
+		g.fieldResolverAST.Decls = append(g.fieldResolverAST.Decls, generateFieldResolverImplementation(modelPrefix, fieldResolverTypes, g.Schema.Indexes)...)
+	}
+
+	if len(g.config.DataLoaders) > 0 {
+		g.dataLoaderResolverAST.Decls = append(g.dataLoaderResolverAST.Decls, &ast.GenDecl{
+			Tok: token.IMPORT,
+			Specs: []ast.Spec{
+				&ast.ImportSpec{
+					Path: &ast.BasicLit{
+						Kind:  token.STRING,
+						Value: `"context"`,
+					},
+				},
+				&ast.ImportSpec{
+					Path: &ast.BasicLit{
+						Kind:  token.STRING,
+						Value: fmt.Sprintf(`"%s"`, g.modelPackagePath),
+					},
+				},
+			},
+		})
+		g.dataLoaderResolverAST.Decls = append(g.dataLoaderResolverAST.Decls, generateDataLoaderResolverInterface(modelPrefix, g.config.DataLoaders, g.Schema.Indexes))
+		g.dataLoaderResolverAST.Decls = append(g.dataLoaderResolverAST.Decls, generateDataLoaderResolverImplementation(modelPrefix, g.config.DataLoaders, g.Schema.Indexes)...)
+		g.generatedAST.Decls = append(g.generatedAST.Decls, generateLoadersDecls(modelPrefix, g.config.DataLoaders, g.Schema.Indexes)...)
+	}
+
+	if len(g.federationEntities) > 0 {
+		g.entityResolverAST.Decls = append(g.entityResolverAST.Decls, &ast.GenDecl{
+			Tok: token.IMPORT,
+			Specs: []ast.Spec{
+				&ast.ImportSpec{
+					Path: &ast.BasicLit{
+						Kind:  token.STRING,
+						Value: `"context"`,
+					},
+				},
+				&ast.ImportSpec{
+					Path: &ast.BasicLit{
+						Kind:  token.STRING,
+						Value: fmt.Sprintf(`"%s"`, g.modelPackagePath),
+					},
+				},
+			},
+		})
+		g.entityResolverAST.Decls = append(g.entityResolverAST.Decls, generateEntityResolverInterface(modelPrefix, g.federationEntities))
+		g.entityResolverAST.Decls = append(g.entityResolverAST.Decls, generateEntityResolverImplementation(modelPrefix, g.federationEntities)...)
+	}
+
+	if len(g.executableDirectives) > 0 {
+		g.directiveResolverAST.Decls = append(g.directiveResolverAST.Decls, &ast.GenDecl{
+			Tok: token.IMPORT,
+			Specs: []ast.Spec{
+				&ast.ImportSpec{
+					Path: &ast.BasicLit{
+						Kind:  token.STRING,
+						Value: `"context"`,
+					},
+				},
+				&ast.ImportSpec{
+					Path: &ast.BasicLit{
+						Kind:  token.STRING,
+						Value: fmt.Sprintf(`"%s"`, g.modelPackagePath),
+					},
+				},
+			},
+		})
+		g.directiveResolverAST.Decls = append(g.directiveResolverAST.Decls, generateDirectiveRootInterface(modelPrefix, g.executableDirectives))
+		g.directiveResolverAST.Decls = append(g.directiveResolverAST.Decls, generateDirectiveRootImplementation(modelPrefix, g.executableDirectives)...)
+	}
+
 	g.resolverAST.Decls = append(g.resolverAST.Decls, generateResolverImplementationStruct()...)
-	g.resolverAST.Decls = append(g.resolverAST.Decls, generateResolverImplementation(modelPrefix, fields, g.Schema.Indexes)...)
 
 	g.queryResolverAST.Decls = append(g.queryResolverAST.Decls, generateResolverImplementation(modelPrefix, queryFields, g.Schema.Indexes)...)
 	g.mutationResolverAST.Decls = append(g.mutationResolverAST.Decls, generateResolverImplementation(modelPrefix, mutationFields, g.Schema.Indexes)...)
+	g.subscriptionResolverAST.Decls = append(g.subscriptionResolverAST.Decls, generateSubscriptionResolverImplementation(modelPrefix, subscriptionFields, g.Schema.Indexes)...)
 
-	g.generatedAST.Decls = append(g.generatedAST.Decls, generateResolverServeHTTP(g.Schema.GetQuery(), g.Schema.GetMutation(), g.Schema.GetSubscription()))
-	g.generatedAST.Decls = append(g.generatedAST.Decls, generateOperationResponseStructDecls(g.Schema)...)
+	g.generatedAST.Decls = append(g.generatedAST.Decls, generateSchemaSourceDecl(g.schemaSource), generateNewSchemaFuncDecl())
+	g.generatedAST.Decls = append(g.generatedAST.Decls, generateResolverServeHTTP(g.Schema.GetQuery(), g.Schema.GetMutation(), g.Schema.GetSubscription(), len(g.config.DataLoaders) > 0))
+	g.generatedAST.Decls = append(g.generatedAST.Decls, generateResolveDocumentFuncDecl())
 
 	// Introspection generation
 	// g.resolverAST.Decls = append(g.resolverAST.Decls, g.generateIntrospection(g.modelPackagePath)...)
@@ -564,6 +942,31 @@ func (g *Generator) generateResolver() error {
 		return fmt.Errorf("error formatting mutation resolver: %w", err)
 	}
 
+	var subscriptionResolverBuffer bytes.Buffer
+	if err := format.Node(&subscriptionResolverBuffer, token.NewFileSet(), g.subscriptionResolverAST); err != nil {
+		return fmt.Errorf("error formatting subscription resolver: %w", err)
+	}
+
+	var fieldResolverBuffer bytes.Buffer
+	if err := format.Node(&fieldResolverBuffer, token.NewFileSet(), g.fieldResolverAST); err != nil {
+		return fmt.Errorf("error formatting field resolver: %w", err)
+	}
+
+	var dataLoaderResolverBuffer bytes.Buffer
+	if err := format.Node(&dataLoaderResolverBuffer, token.NewFileSet(), g.dataLoaderResolverAST); err != nil {
+		return fmt.Errorf("error formatting dataloader resolver: %w", err)
+	}
+
+	var entityResolverBuffer bytes.Buffer
+	if err := format.Node(&entityResolverBuffer, token.NewFileSet(), g.entityResolverAST); err != nil {
+		return fmt.Errorf("error formatting entity resolver: %w", err)
+	}
+
+	var directiveResolverBuffer bytes.Buffer
+	if err := format.Node(&directiveResolverBuffer, token.NewFileSet(), g.directiveResolverAST); err != nil {
+		return fmt.Errorf("error formatting directive resolver: %w", err)
+	}
+
 	var generatedBuffer bytes.Buffer
 	if err := format.Node(&generatedBuffer, token.NewFileSet(), g.generatedAST); err != nil {
 		return fmt.Errorf("error formatting generated resolver: %w", err)
@@ -601,6 +1004,56 @@ func (g *Generator) generateResolver() error {
 		return fmt.Errorf("error writing mutation resolver output: %w", err)
 	}
 
+	if g.fieldResolverOutput != nil {
+		fixed, err = imports.Process(g.fieldResolverOutputFilePath, fieldResolverBuffer.Bytes(), nil)
+		if err != nil {
+			return fmt.Errorf("error processing field resolver imports: %w", err)
+		}
+		if _, err := g.fieldResolverOutput.Write(fixed); err != nil {
+			return fmt.Errorf("error writing field resolver output: %w", err)
+		}
+	}
+
+	if g.dataLoaderResolverOutput != nil {
+		fixed, err = imports.Process(g.dataLoaderResolverOutputFilePath, dataLoaderResolverBuffer.Bytes(), nil)
+		if err != nil {
+			return fmt.Errorf("error processing dataloader resolver imports: %w", err)
+		}
+		if _, err := g.dataLoaderResolverOutput.Write(fixed); err != nil {
+			return fmt.Errorf("error writing dataloader resolver output: %w", err)
+		}
+	}
+
+	if g.entityResolverOutput != nil {
+		fixed, err = imports.Process(g.entityResolverOutputFilePath, entityResolverBuffer.Bytes(), nil)
+		if err != nil {
+			return fmt.Errorf("error processing entity resolver imports: %w", err)
+		}
+		if _, err := g.entityResolverOutput.Write(fixed); err != nil {
+			return fmt.Errorf("error writing entity resolver output: %w", err)
+		}
+	}
+
+	if g.directiveResolverOutput != nil {
+		fixed, err = imports.Process(g.directiveResolverOutputFilePath, directiveResolverBuffer.Bytes(), nil)
+		if err != nil {
+			return fmt.Errorf("error processing directive resolver imports: %w", err)
+		}
+		if _, err := g.directiveResolverOutput.Write(fixed); err != nil {
+			return fmt.Errorf("error writing directive resolver output: %w", err)
+		}
+	}
+
+	if g.subscriptionResolverOutput != nil {
+		fixed, err = imports.Process(g.subscriptionResolverOutputFilePath, subscriptionResolverBuffer.Bytes(), nil)
+		if err != nil {
+			return fmt.Errorf("error processing subscription resolver imports: %w", err)
+		}
+		if _, err := g.subscriptionResolverOutput.Write(fixed); err != nil {
+			return fmt.Errorf("error writing subscription resolver output: %w", err)
+		}
+	}
+
 	return nil
 }
 
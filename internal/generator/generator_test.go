@@ -0,0 +1,371 @@
+package generator_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/internal/generator"
+)
+
+type request struct {
+	Header map[string]string `json:"header,omitempty"`
+	Body   string            `json:"body"`
+}
+
+type response struct {
+	Status int    `json:"status"`
+	Body   string `json:"body"`
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   func(*generator.Config)
+		requests []request
+		want     []response
+	}{
+		{
+			name: "field_resolvers",
+			requests: []request{
+				{Body: `{"query": "{ posts { id comments { id } } }"}`},
+				{Body: `{"query": "{ posts { title comments(first: 1) { id } } }"}`},
+				{Body: `{"query": "query ($first: Int) { posts { id comments(first: $first) { id } } }", "variables": {"first": 3}}`},
+			},
+			want: []response{
+				{Status: 200, Body: `{"data":{"posts":[{"id":"p1","comments":[{"id":"p1-c1"},{"id":"p1-c2"}]},{"id":"p2","comments":[{"id":"p2-c1"},{"id":"p2-c2"}]}]}}`},
+				{Status: 200, Body: `{"data":{"posts":[{"title":"first","comments":[{"id":"p1-c1"}]},{"title":"second","comments":[{"id":"p2-c1"}]}]}}`},
+				{Status: 200, Body: `{"data":{"posts":[{"id":"p1","comments":[{"id":"p1-c1"},{"id":"p1-c2"},{"id":"p1-c3"}]},{"id":"p2","comments":[{"id":"p2-c1"},{"id":"p2-c2"},{"id":"p2-c3"}]}]}}`},
+			},
+		},
+		{
+			name: "dataloaders",
+			config: func(config *generator.Config) {
+				config.ForceResolvers = map[string][]string{"Post": {"author", "editor"}}
+				config.DataLoaders = []generator.DataLoaderConfig{
+					{Name: "UserByID", KeyType: "string", ValueType: "User", Wait: "10ms"},
+				}
+			},
+			requests: []request{
+				{Body: `{"query": "{ posts { id author { name } editor { name } } }"}`},
+				{Body: `{"query": "{ batches }"}`},
+				{Header: map[string]string{"Accept": "text/event-stream"}, Body: `{"query": "subscription { postAdded { id author { name } } }"}`},
+				{Body: `{"query": "{ batches }"}`},
+				{Header: map[string]string{"Accept": "text/event-stream"}, Body: `{"query": "{ posts { id author { name } } }"}`},
+			},
+			want: []response{
+				{Status: 200, Body: `{"data":{"posts":[{"id":"1","author":{"name":"user u1"},"editor":{"name":"user e1"}},{"id":"2","author":{"name":"user u2"},"editor":{"name":"user e2"}},{"id":"3","author":{"name":"user u3"},"editor":{"name":"user e3"}}]}}`},
+				// the authors and editors of all posts are loaded in one batch
+				{Status: 200, Body: `{"data":{"batches":["e1,e2,e3,u1,u2,u3"]}}`},
+				{Status: 200, Body: `event: next
+data: {"data":{"postAdded":{"id":"4","author":{"name":"user u4"}}}}
+
+event: next
+data: {"data":{"postAdded":{"id":"4","author":{"name":"user u4"}}}}
+
+event: complete
+data:`},
+				// every event loads with new loaders, so the author loaded by the first event is loaded again by the second one
+				{Status: 200, Body: `{"data":{"batches":["e1,e2,e3,u1,u2,u3","u4","u4"]}}`},
+				// a query over SSE is served as a single result
+				{Status: 200, Body: `event: next
+data: {"data":{"posts":[{"id":"1","author":{"name":"user u1"}},{"id":"2","author":{"name":"user u2"}},{"id":"3","author":{"name":"user u3"}}]}}
+
+event: complete
+data:`},
+			},
+		},
+		{
+			name: "federation",
+			config: func(config *generator.Config) {
+				config.Federation = true
+			},
+			requests: []request{
+				// the schema links the federation specification itself, so the SDL is served as it is
+				{Body: `{"query": "{ _service { sdl } }"}`},
+				{Body: `{"query": "query ($representations: [_Any!]!) { _entities(representations: $representations) { ... on Product { upc name } } }", "variables": {"representations": [{"__typename": "Product", "upc": "1"}, {"__typename": "Product", "upc": "2"}]}}`},
+			},
+			want: []response{
+				{Status: 200, Body: `{"data":{"_service":{"sdl":"extend schema @link(url: \"https://specs.apollo.dev/federation/v2.3\", import: [\"@key\"])\n\ntype Product @key(fields: \"upc\") {\n\tupc: String!\n\tname: String!\n}\n\ntype Query {\n\tproduct(upc: String!): Product\n}\n\n"}}}`},
+				{Status: 200, Body: `{"data":{"_entities":[{"upc":"1","name":"product 1"},{"upc":"2","name":"product 2"}]}}`},
+			},
+		},
+		{
+			name: "scalars",
+			config: func(config *generator.Config) {
+				config.Scalars = []generator.ScalarConfig{{Name: "DateTime"}}
+			},
+			requests: []request{
+				{Body: `{"query": "{ events { name at } }"}`},
+				{Body: `{"query": "query ($after: DateTime) { events(after: $after) { name } }", "variables": {"after": "2026-01-01T12:00:00Z"}}`},
+				{Body: `{"query": "query ($after: DateTime) { events(after: $after) { name } }", "variables": {"after": "tomorrow"}}`},
+			},
+			want: []response{
+				{Status: 200, Body: `{"data":{"events":[{"name":"opening","at":"2026-01-01T09:00:00Z"},{"name":"closing","at":"2026-01-02T18:00:00Z"}]}}`},
+				{Status: 200, Body: `{"data":{"events":[{"name":"closing"}]}}`},
+				// custom scalar variables are checked by the unmarshaler of their model before execution
+				{Status: 400, Body: `{"data":null,"errors":[{"message":"Variable \"$after\" got invalid value \"tomorrow\"; Expected type \"DateTime\". DateTime must be an RFC 3339 date-time: parsing time \"tomorrow\" as \"2006-01-02T15:04:05.999999999Z07:00\": cannot parse \"tomorrow\" as \"2006\"","locations":[{"line":1,"column":8}],"extensions":{"code":"BAD_USER_INPUT"}}]}`},
+			},
+		},
+		{
+			name: "directives",
+			requests: []request{
+				{Body: `{"query": "{ posts { id title } }"}`},
+				{Body: `{"query": "query ($prefix: String) { posts(prefix: $prefix) { title } }", "variables": {"prefix": "  he "}}`},
+				{Body: `{"query": "{ viewer }"}`},
+				{Header: map[string]string{"X-Role": "admin"}, Body: `{"query": "{ posts { id secret { value } } }"}`},
+				{Header: map[string]string{"X-Role": "user"}, Body: `{"query": "{ viewer posts { id secret { value } } }"}`},
+			},
+			want: []response{
+				{Status: 200, Body: `{"data":{"posts":[{"id":"1","title":"HELLO"},{"id":"2","title":"WORLD"}]}}`},
+				// @trim applies to the argument before the resolver gets it
+				{Status: 200, Body: `{"data":{"posts":[{"title":"HELLO"}]}}`},
+				{Status: 200, Body: `{"data":{"viewer":null},"errors":[{"message":"requires role user","path":["viewer"]}]}`},
+				{Status: 200, Body: `{"data":{"posts":[{"id":"1","secret":{"value":"s1"}},{"id":"2","secret":null}]}}`},
+				// @auth on an object type applies wherever a value of the type is resolved
+				{Status: 200, Body: `{"data":{"viewer":"viewer","posts":[{"id":"1","secret":null},{"id":"2","secret":null}]},"errors":[{"message":"requires role admin","path":["posts",0,"secret"]}]}`},
+			},
+		},
+		{
+			name: "persisted_queries",
+			requests: []request{
+				{Body: `{"extensions": {"persistedQuery": {"version": 1, "sha256Hash": "ce67e3fb7e01f092791cf7b8957404e43a6679ac4f84f4c311678bb3effc5392"}}}`},
+				{Body: `{"query": "{ posts { id } }", "extensions": {"persistedQuery": {"version": 1, "sha256Hash": "ce67e3fb7e01f092791cf7b8957404e43a6679ac4f84f4c311678bb3effc5392"}}}`},
+				{Body: `{"extensions": {"persistedQuery": {"version": 1, "sha256Hash": "ce67e3fb7e01f092791cf7b8957404e43a6679ac4f84f4c311678bb3effc5392"}}}`},
+				{Body: `{"query": "{ posts { title } }", "extensions": {"persistedQuery": {"version": 1, "sha256Hash": "ce67e3fb7e01f092791cf7b8957404e43a6679ac4f84f4c311678bb3effc5392"}}}`},
+			},
+			want: []response{
+				{Status: 200, Body: `{"data":null,"errors":[{"message":"PersistedQueryNotFound","extensions":{"code":"PERSISTED_QUERY_NOT_FOUND"}}]}`},
+				// sending the query along with its hash registers it
+				{Status: 200, Body: `{"data":{"posts":[{"id":"p1"},{"id":"p2"}]}}`},
+				{Status: 200, Body: `{"data":{"posts":[{"id":"p1"},{"id":"p2"}]}}`},
+				{Status: 400, Body: `{"data":null,"errors":[{"message":"provided sha does not match query","extensions":{"code":"BAD_USER_INPUT"}}]}`},
+			},
+		},
+		{
+			name: "trusted_documents",
+			requests: []request{
+				{Body: `{"query": "query Posts { posts { id } }"}`},
+				{Body: `{"extensions": {"persistedQuery": {"version": 1, "sha256Hash": "99ffd94929276ce608387ab1a363599bb4b52a2af41eea6865f8e60cd78bace2"}}}`},
+				{Body: `{"query": "{ posts { id title } }"}`},
+				{Body: `{"extensions": {"persistedQuery": {"version": 1, "sha256Hash": "0000"}}}`},
+			},
+			want: []response{
+				{Status: 200, Body: `{"data":{"posts":[{"id":"p1"},{"id":"p2"}]}}`},
+				{Status: 200, Body: `{"data":{"posts":[{"id":"p1"},{"id":"p2"}]}}`},
+				{Status: 400, Body: `{"data":null,"errors":[{"message":"PersistedQueryNotInList","extensions":{"code":"PERSISTED_QUERY_NOT_IN_LIST"}}]}`},
+				{Status: 400, Body: `{"data":null,"errors":[{"message":"PersistedQueryNotInList","extensions":{"code":"PERSISTED_QUERY_NOT_IN_LIST"}}]}`},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := newConfig(t, tt.name)
+			if tt.config != nil {
+				tt.config(config)
+			}
+
+			got := serveGenerated(t, config, tt.name, tt.requests)
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("responses mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+// newConfig configures the generation of the schema under testdata/name/schema into a module in a temporary directory.
+func newConfig(t *testing.T, name string) *generator.Config {
+	t.Helper()
+
+	schemaDirectory, err := filepath.Abs(filepath.Join("testdata", name, "schema"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	return &generator.Config{
+		SchemaDirectory:             schemaDirectory,
+		ModelOutputFile:             filepath.Join(dir, "graphql", "model", "models.go"),
+		EnumOutputFile:              filepath.Join(dir, "graphql", "model", "enum.go"),
+		ScalarOutputFile:            filepath.Join(dir, "graphql", "model", "scalar.go"),
+		QueryResolverOutputFile:     filepath.Join(dir, "graphql", "resolver", "query.resolver.go"),
+		MutationResolverOutputFile:  filepath.Join(dir, "graphql", "resolver", "mutate.resolver.go"),
+		RootResolverOutputFile:      filepath.Join(dir, "graphql", "resolver", "resolver.go"),
+		ResolverGeneratedOutputFile: filepath.Join(dir, "graphql", "resolver", "generated.go"),
+		ModelPackageName:            "example.com/e2e/graphql/model",
+		ResolverPackageName:         "example.com/e2e/graphql/resolver",
+	}
+}
+
+// serveGenerated generates the code of config and replaces the resolver stubs with the implementations under testdata/name/resolver.
+// It then builds a server with testdata/main.go and testdata/name/server.go and returns its responses to requests.
+func serveGenerated(t *testing.T, config *generator.Config, name string, requests []request) []response {
+	t.Helper()
+
+	if testing.Short() {
+		t.Skip("skipping the build of generated code in short mode")
+	}
+
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command is not available")
+	}
+
+	g, err := generator.NewGenerator(config)
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+
+	if err := g.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	dir := filepath.Dir(filepath.Dir(filepath.Dir(config.RootResolverOutputFile)))
+	writeModule(t, dir)
+	implementResolvers(t, filepath.Join("testdata", name, "resolver"), filepath.Dir(config.RootResolverOutputFile))
+	copyFile(t, filepath.Join("testdata", "main.go"), filepath.Join(dir, "main.go"))
+	copyFile(t, filepath.Join("testdata", name, "server.go"), filepath.Join(dir, "server.go"))
+
+	input, err := json.Marshal(requests)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(goBin, "run", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off", "GOPROXY=off")
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("error running generated server: %v\n%s", err, stderr.String())
+	}
+
+	var responses []response
+	if err := json.Unmarshal(stdout.Bytes(), &responses); err != nil {
+		t.Fatalf("error decoding responses: %v\n%s", err, stdout.String())
+	}
+
+	return responses
+}
+
+// writeModule makes dir a module that uses goliteql of this repository.
+func writeModule(t *testing.T, dir string) {
+	t.Helper()
+
+	root, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	goMod := "module example.com/e2e\n\ngo 1.23.2\n\nrequire github.com/n9te9/goliteql v0.0.0\n\nreplace github.com/n9te9/goliteql => " + root + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	copyFile(t, filepath.Join(root, "go.sum"), filepath.Join(dir, "go.sum"))
+}
+
+// implementResolvers copies the files of src into the generated resolver package dst, removing the generated methods they implement.
+func implementResolvers(t *testing.T, src, dst string) {
+	t.Helper()
+
+	implemented := make(map[string]struct{})
+	srcPaths, err := filepath.Glob(filepath.Join(src, "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range srcPaths {
+		f, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, decl := range f.Decls {
+			if key, ok := methodKey(decl); ok {
+				implemented[key] = struct{}{}
+			}
+		}
+
+		if _, err := os.Stat(filepath.Join(dst, filepath.Base(path))); err == nil {
+			t.Fatalf("%s would overwrite a generated file", path)
+		}
+		copyFile(t, path, filepath.Join(dst, filepath.Base(path)))
+	}
+
+	dstPaths, err := filepath.Glob(filepath.Join(dst, "*.resolver.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range dstPaths {
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decls := make([]ast.Decl, 0, len(f.Decls))
+		for _, decl := range f.Decls {
+			if key, ok := methodKey(decl); ok {
+				if _, ok := implemented[key]; ok {
+					continue
+				}
+			}
+			decls = append(decls, decl)
+		}
+		f.Decls = decls
+
+		var buf bytes.Buffer
+		if err := format.Node(&buf, fset, f); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func methodKey(decl ast.Decl) (string, bool) {
+	fn, ok := decl.(*ast.FuncDecl)
+	if !ok || fn.Recv == nil {
+		return "", false
+	}
+
+	recv := fn.Recv.List[0].Type
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv = star.X
+	}
+
+	ident, ok := recv.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+
+	return ident.Name + "." + fn.Name.Name, true
+}
+
+func copyFile(t *testing.T, src, dst string) {
+	t.Helper()
+
+	b, err := os.ReadFile(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(dst, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
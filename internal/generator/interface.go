@@ -12,6 +12,7 @@ func generateInterfaceTypeDecls(interfaces []*schema.InterfaceDefinition) []ast.
 
 	for _, i := range interfaces {
 		decls = append(decls, &ast.GenDecl{
+			Doc: generateDocComment(i.Description),
 			Tok: token.TYPE,
 			Specs: []ast.Spec{
 				&ast.TypeSpec{
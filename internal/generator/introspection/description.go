@@ -0,0 +1,51 @@
+package introspection
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+)
+
+func GenerateDescriptionExpr(description []byte) ast.Expr {
+	if len(description) == 0 {
+		return ast.NewIdent("nil")
+	}
+
+	return &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent("executor"),
+			Sel: ast.NewIdent("NewNullable"),
+		},
+		Args: []ast.Expr{
+			&ast.BasicLit{
+				Kind:  token.STRING,
+				Value: strconv.Quote(string(description)),
+			},
+		},
+	}
+}
+
+func generateDescriptionCaseClause(description []byte) ast.Stmt {
+	return &ast.CaseClause{
+		List: []ast.Expr{
+			&ast.BasicLit{
+				Kind:  token.STRING,
+				Value: `"description"`,
+			},
+		},
+		Body: []ast.Stmt{
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{
+					&ast.SelectorExpr{
+						X:   ast.NewIdent("ret"),
+						Sel: ast.NewIdent("Description"),
+					},
+				},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{
+					GenerateDescriptionExpr(description),
+				},
+			},
+		},
+	}
+}
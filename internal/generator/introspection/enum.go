@@ -42,7 +42,7 @@ func GenerateEnumTypeCaseStmts(enumDefinition *schema.EnumDefinition) []ast.Stmt
 					},
 				},
 			},
-		}, &ast.CaseClause{
+		}, generateDescriptionCaseClause(enumDefinition.Description), &ast.CaseClause{
 			List: []ast.Expr{
 				&ast.BasicLit{
 					Kind:  token.STRING,
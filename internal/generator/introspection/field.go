@@ -87,18 +87,7 @@ func generateFieldDescriptionAssignStmt(fieldDefinition *schema.FieldDefinition)
 		},
 		Tok: token.ASSIGN,
 		Rhs: []ast.Expr{
-			&ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X:   ast.NewIdent("executor"),
-					Sel: ast.NewIdent("NewNullable"),
-				},
-				Args: []ast.Expr{
-					&ast.BasicLit{
-						Kind:  token.STRING,
-						Value: "nil",
-					},
-				},
-			},
+			GenerateDescriptionExpr(fieldDefinition.Description),
 		},
 	}
 }
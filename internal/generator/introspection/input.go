@@ -42,7 +42,7 @@ func GenerateInputTypeCaseStmts(inputDefinition *schema.InputDefinition) []ast.S
 					},
 				},
 			},
-		}, &ast.CaseClause{
+		}, generateDescriptionCaseClause(inputDefinition.Description), &ast.CaseClause{
 			List: []ast.Expr{
 				&ast.BasicLit{
 					Kind:  token.STRING,
@@ -214,7 +214,7 @@ func GenerateInterfaceTypeCaseStmts(interfaceDefinition *schema.InterfaceDefinit
 					},
 				},
 			},
-		}, &ast.CaseClause{
+		}, generateDescriptionCaseClause(interfaceDefinition.Description), &ast.CaseClause{
 			List: []ast.Expr{
 				&ast.BasicLit{
 					Kind:  token.STRING,
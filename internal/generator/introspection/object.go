@@ -130,7 +130,7 @@ func GenerateTypeObjectCaseStmts(typeDefinition *schema.TypeDefinition) []ast.St
 					},
 				},
 			},
-		}, &ast.CaseClause{
+		}, generateDescriptionCaseClause(typeDefinition.Description), &ast.CaseClause{
 			List: []ast.Expr{
 				&ast.BasicLit{
 					Kind:  token.STRING,
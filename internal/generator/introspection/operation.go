@@ -227,15 +227,7 @@ func GenerateOperationCaseStmts(operationName string, operationDefinition *schem
 					},
 					Tok: token.ASSIGN,
 					Rhs: []ast.Expr{
-						&ast.CallExpr{
-							Fun: &ast.SelectorExpr{
-								X:   ast.NewIdent("executor"),
-								Sel: ast.NewIdent("NewNullable"),
-							},
-							Args: []ast.Expr{
-								ast.NewIdent("nil"),
-							},
-						},
+						GenerateDescriptionExpr(operationDefinition.Description),
 					},
 				},
 			},
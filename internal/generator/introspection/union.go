@@ -131,7 +131,7 @@ func GenerateUnionTypeCaseStmts(unionDefinition *schema.UnionDefinition) []ast.S
 					},
 				},
 			},
-		}, &ast.CaseClause{
+		}, generateDescriptionCaseClause(unionDefinition.Description), &ast.CaseClause{
 			List: []ast.Expr{
 				&ast.BasicLit{
 					Kind:  token.STRING,
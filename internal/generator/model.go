@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"strings"
 
 	"github.com/n9te9/goliteql/schema"
 )
@@ -32,10 +33,11 @@ func generateModelImport(s *schema.Schema) ast.Decl {
 	}
 }
 
+// hasRequiredField reports whether an input has a non-null field, which its UnmarshalJSON checks with fmt.Errorf.
 func hasRequiredField(s *schema.Schema) bool {
-	for _, t := range s.Types {
+	for _, t := range s.Inputs {
 		for _, field := range t.Fields {
-			if findRequiredField(field.Type) {
+			if !field.Type.Nullable {
 				return true
 			}
 		}
@@ -44,18 +46,6 @@ func hasRequiredField(s *schema.Schema) bool {
 	return false
 }
 
-func findRequiredField(field *schema.FieldType) bool {
-	if !field.Nullable {
-		return true
-	}
-
-	if field.IsList {
-		return findRequiredField(field.ListType)
-	}
-
-	return false
-}
-
 // generateEnumImport generates an empty import declaration for enums.
 func generateEnumImport() *ast.GenDecl {
 	return &ast.GenDecl{}
@@ -117,6 +107,7 @@ func generateModelField(field schema.FieldDefinitions) *ast.FieldList {
 		fieldTypeExpr := generateExpr(f.Type)
 
 		fields = append(fields, &ast.Field{
+			Doc: generateDocComment(f.Description),
 			Names: []*ast.Ident{
 				{
 					Name: toUpperCase(string(f.Name)),
@@ -135,6 +126,28 @@ func generateModelField(field schema.FieldDefinitions) *ast.FieldList {
 	}
 }
 
+func generateDocComment(description []byte) *ast.CommentGroup {
+	if len(description) == 0 {
+		return nil
+	}
+
+	lines := strings.Split(string(description), "\n")
+	comments := make([]*ast.Comment, 0, len(lines))
+	for _, line := range lines {
+		comments = append(comments, &ast.Comment{
+			Text: strings.TrimRight("// "+line, " \t"),
+		})
+	}
+
+	// the AST has no positions, so the printer would put the comment on the
+	// same line as the previous token without a leading newline.
+	comments[0].Text = "\n" + comments[0].Text
+
+	return &ast.CommentGroup{
+		List: comments,
+	}
+}
+
 func generateExpr(fieldType *schema.FieldType) ast.Expr {
 	graphQLType := GraphQLType(fieldType.Name)
 	if fieldType.Nullable {
@@ -0,0 +1,151 @@
+package generator
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// generatePersistedQueryAssignStmt resolves the document of the request, which an Automatic Persisted Query refers to by its hash.
+func generatePersistedQueryAssignStmt(ctxExpr string) ast.Stmt {
+	return &ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("document"),
+			ast.NewIdent("err"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("r"),
+					Sel: ast.NewIdent("resolveDocument"),
+				},
+				Args: []ast.Expr{
+					ast.NewIdent(ctxExpr),
+					ast.NewIdent("request.Query"),
+					ast.NewIdent("request.Extensions"),
+				},
+			},
+		},
+	}
+}
+
+// generateResolveDocumentFuncDecl generates the method that resolves the document of a request.
+// With trusted documents only their allowlist is served, otherwise Automatic Persisted Queries are resolved through the store.
+func generateResolveDocumentFuncDecl() *ast.FuncDecl {
+	return &ast.FuncDecl{
+		Name: ast.NewIdent("resolveDocument"),
+		Recv: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Names: []*ast.Ident{ast.NewIdent("r")},
+					Type:  &ast.StarExpr{X: ast.NewIdent("resolver")},
+				},
+			},
+		},
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("ctx")},
+						Type: &ast.SelectorExpr{
+							X:   ast.NewIdent("context"),
+							Sel: ast.NewIdent("Context"),
+						},
+					},
+					{
+						Names: []*ast.Ident{ast.NewIdent("document")},
+						Type:  ast.NewIdent("string"),
+					},
+					{
+						Names: []*ast.Ident{ast.NewIdent("extensions")},
+						Type: &ast.MapType{
+							Key: ast.NewIdent("string"),
+							Value: &ast.SelectorExpr{
+								X:   ast.NewIdent("json"),
+								Sel: ast.NewIdent("RawMessage"),
+							},
+						},
+					},
+				},
+			},
+			Results: &ast.FieldList{
+				List: []*ast.Field{
+					{Type: ast.NewIdent("string")},
+					{Type: ast.NewIdent("error")},
+				},
+			},
+		},
+		Doc: &ast.CommentGroup{
+			List: []*ast.Comment{
+				{
+					Text: "// *********** AUTO GENERATED CODE ***********",
+				},
+				{
+					Text: "// *********** DON'T EDIT ***********",
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.IfStmt{
+					Cond: &ast.BinaryExpr{
+						X:  ast.NewIdent("r.trustedDocuments"),
+						Op: token.NEQ,
+						Y:  ast.NewIdent("nil"),
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ReturnStmt{
+								Results: []ast.Expr{
+									ast.NewIdent("r.trustedDocuments.Resolve(document, extensions)"),
+								},
+							},
+						},
+					},
+				},
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent("executor.ResolvePersistedQuery(ctx, r.persistedQueryStore, document, extensions)"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func generateReplaceQueryStmt() ast.Stmt {
+	return &ast.AssignStmt{
+		Lhs: []ast.Expr{ast.NewIdent("request.Query")},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{ast.NewIdent("document")},
+	}
+}
+
+// generateServeHTTPPersistedQueryErrorStmt answers an unknown hash with 200 OK, as clients retry it with the query document.
+func generateServeHTTPPersistedQueryErrorStmt() ast.Stmt {
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("err"),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.IfStmt{
+					Cond: ast.NewIdent("!executor.IsPersistedQueryNotFound(err)"),
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ExprStmt{
+								X: ast.NewIdent("w.WriteHeader(http.StatusBadRequest)"),
+							},
+						},
+					},
+				},
+				&ast.ExprStmt{
+					X: ast.NewIdent("json.NewEncoder(w).Encode(executor.NewErrorResponse([]executor.GraphQLError{executor.NewGraphQLError(err)}))"),
+				},
+				&ast.ReturnStmt{},
+			},
+		},
+	}
+}
@@ -1,7 +1,6 @@
 package generator
 
 import (
-	"bytes"
 	"fmt"
 	"go/ast"
 	"go/token"
@@ -89,12 +88,6 @@ func generateResolverImport() *ast.GenDecl {
 					Value: `"encoding/json"`,
 				},
 			},
-			&ast.ImportSpec{
-				Path: &ast.BasicLit{
-					Kind:  token.STRING,
-					Value: `"sync"`,
-				},
-			},
 			&ast.ImportSpec{
 				Path: &ast.BasicLit{
 					Kind:  token.STRING,
@@ -111,7 +104,7 @@ func generateResolverImport() *ast.GenDecl {
 	}
 }
 
-func generateResolverInterface(query, mutation, subscription *schema.OperationDefinition) *ast.GenDecl {
+func generateResolverInterface(query, mutation, subscription *schema.OperationDefinition, methods []*ast.Field) *ast.GenDecl {
 	generateField := func(query, mutation, subscription *schema.OperationDefinition) []*ast.Field {
 		fields := make([]*ast.Field, 0, 3)
 		if query != nil {
@@ -144,7 +137,7 @@ func generateResolverInterface(query, mutation, subscription *schema.OperationDe
 				},
 				Type: &ast.InterfaceType{
 					Methods: &ast.FieldList{
-						List: generateField(query, mutation, subscription),
+						List: append(generateField(query, mutation, subscription), methods...),
 					},
 				},
 			},
@@ -152,7 +145,7 @@ func generateResolverInterface(query, mutation, subscription *schema.OperationDe
 	}
 }
 
-func generateResolverServeHTTP(query, mutation, subscription *schema.OperationDefinition) *ast.FuncDecl {
+func generateResolverServeHTTP(query, mutation, subscription *schema.OperationDefinition, useDataLoader bool) *ast.FuncDecl {
 	return &ast.FuncDecl{
 		Name: ast.NewIdent("ServeHTTP"),
 		Recv: &ast.FieldList{
@@ -177,7 +170,7 @@ func generateResolverServeHTTP(query, mutation, subscription *schema.OperationDe
 				},
 			},
 		},
-		Body: generateServeHTTPBody(query, mutation, subscription),
+		Body: generateServeHTTPBody(query, mutation, subscription, useDataLoader),
 	}
 }
 
@@ -245,7 +238,7 @@ func generateTypeExprFromFieldTypeForReturn(typePrefix string, fieldType *schema
 	return baseTypeExpr
 }
 
-func generateQueryExecutor(query *schema.OperationDefinition) *ast.FuncDecl {
+func generateQueryExecutor(query *schema.OperationDefinition, typeName []byte, typePrefix string, indexes *schema.Indexes, extraCases ...ast.Stmt) *ast.FuncDecl {
 	return &ast.FuncDecl{
 		Name: ast.NewIdent("queryExecutor"),
 		Recv: &ast.FieldList{
@@ -279,11 +272,11 @@ func generateQueryExecutor(query *schema.OperationDefinition) *ast.FuncDecl {
 				},
 			},
 		},
-		Body: generateExecutorBody(query, "query"),
+		Body: generateExecutorBody(query, "query", typeName, typePrefix, indexes, extraCases...),
 	}
 }
 
-func generateMutationExecutor(mutation *schema.OperationDefinition) *ast.FuncDecl {
+func generateMutationExecutor(mutation *schema.OperationDefinition, typeName []byte, typePrefix string, indexes *schema.Indexes) *ast.FuncDecl {
 	return &ast.FuncDecl{
 		Name: ast.NewIdent("mutationExecutor"),
 		Recv: &ast.FieldList{
@@ -317,11 +310,11 @@ func generateMutationExecutor(mutation *schema.OperationDefinition) *ast.FuncDec
 				},
 			},
 		},
-		Body: generateExecutorBody(mutation, "mutation"),
+		Body: generateExecutorBody(mutation, "mutation", typeName, typePrefix, indexes),
 	}
 }
 
-func generateSubscriptionExecutor(subscription *schema.OperationDefinition) *ast.FuncDecl {
+func generateSubscriptionExecutor(subscription *schema.OperationDefinition, typePrefix string, indexes *schema.Indexes, useDataLoader bool) *ast.FuncDecl {
 	return &ast.FuncDecl{
 		Name: ast.NewIdent("subscriptionExecutor"),
 		Recv: &ast.FieldList{
@@ -333,147 +326,14 @@ func generateSubscriptionExecutor(subscription *schema.OperationDefinition) *ast
 			},
 		},
 		Type: &ast.FuncType{
-			Params:  generateNodeWalkerArgs(),
-			Results: &ast.FieldList{},
-		},
-		Doc: &ast.CommentGroup{
-			List: []*ast.Comment{
-				{
-					Text: "// *********** AUTO GENERATED CODE ***********",
-				},
-				{
-					Text: "// *********** DON'T EDIT ***********",
-				},
-			},
-		},
-		Body: generateExecutorBody(subscription, "subscription"),
-	}
-}
-
-func generateWrapResponseWriter(op *schema.OperationDefinition) []ast.Decl {
-	res := make([]ast.Decl, 0, len(op.Fields))
-
-	for _, field := range op.Fields {
-		res = append(res, generateWrapResponseWriterStruct(field))
-		res = append(res, generateWrapResponseWriterFunc(field))
-		res = append(res, generateWrapResponseWriterWrite(string(field.Name), field))
-	}
-
-	return res
-}
-
-func generateWrapResponseWriterStruct(field *schema.FieldDefinition) *ast.GenDecl {
-	return &ast.GenDecl{
-		Tok: token.TYPE,
-		Specs: []ast.Spec{
-			&ast.TypeSpec{
-				Name: ast.NewIdent("Wrap" + string(field.Name) + "ResponseWriter"),
-				Type: &ast.StructType{
-					Fields: &ast.FieldList{
-						List: []*ast.Field{
-							{
-								Type: &ast.SelectorExpr{
-									X:   ast.NewIdent("http"),
-									Sel: ast.NewIdent("ResponseWriter"),
-								},
-							},
-							{
-								Names: []*ast.Ident{ast.NewIdent("selections")},
-								Type: &ast.ArrayType{
-									Len: nil,
-									Elt: &ast.SelectorExpr{
-										X:   ast.NewIdent("query"),
-										Sel: ast.NewIdent("Selection"),
-									},
-								},
-							},
-							{
-								Names: []*ast.Ident{
-									ast.NewIdent("variables"),
-								},
-								Type: &ast.SelectorExpr{
-									X:   ast.NewIdent("json"),
-									Sel: ast.NewIdent("RawMessage"),
-								},
-							},
-						},
-					},
-				},
-			},
-		},
-	}
-}
-
-func generateWrapResponseWriterFunc(field *schema.FieldDefinition) *ast.FuncDecl {
-	return &ast.FuncDecl{
-		Name: ast.NewIdent("new" + string(field.Name) + "Writer"),
-		Type: &ast.FuncType{
-			Params: &ast.FieldList{
-				List: []*ast.Field{
-					{
-						Names: []*ast.Ident{ast.NewIdent("w")},
-						Type: &ast.SelectorExpr{
-							X:   ast.NewIdent("http"),
-							Sel: ast.NewIdent("ResponseWriter"),
-						},
-					},
-					{
-						Names: []*ast.Ident{ast.NewIdent("selections")},
-						Type: &ast.ArrayType{
-							Len: nil,
-							Elt: &ast.SelectorExpr{
-								X:   ast.NewIdent("query"),
-								Sel: ast.NewIdent("Selection"),
-							},
-						},
-					},
-					{
-						Names: []*ast.Ident{
-							ast.NewIdent("variables"),
-						},
-						Type: &ast.SelectorExpr{
-							X:   ast.NewIdent("json"),
-							Sel: ast.NewIdent("RawMessage"),
-						},
-					},
-				},
-			},
+			Params: generateNodeWalkerArgs(),
 			Results: &ast.FieldList{
 				List: []*ast.Field{
 					{
-						Type: &ast.StarExpr{
-							X: &ast.Ident{
-								Name: "Wrap" + string(field.Name) + "ResponseWriter",
-							},
-						},
+						Type: generateSubscriptionEventChanType(),
 					},
-				},
-			},
-		},
-		Body: &ast.BlockStmt{
-			List: []ast.Stmt{
-				&ast.ReturnStmt{
-					Results: []ast.Expr{
-						&ast.UnaryExpr{
-							Op: token.AND,
-							X: &ast.CompositeLit{
-								Type: ast.NewIdent("Wrap" + string(field.Name) + "ResponseWriter"),
-								Elts: []ast.Expr{
-									&ast.KeyValueExpr{
-										Key:   ast.NewIdent("ResponseWriter"),
-										Value: ast.NewIdent("w"),
-									},
-									&ast.KeyValueExpr{
-										Key:   ast.NewIdent("selections"),
-										Value: ast.NewIdent("selections"),
-									},
-									&ast.KeyValueExpr{
-										Key:   ast.NewIdent("variables"),
-										Value: ast.NewIdent("variables"),
-									},
-								},
-							},
-						},
+					{
+						Type: ast.NewIdent("error"),
 					},
 				},
 			},
@@ -488,101 +348,117 @@ func generateWrapResponseWriterFunc(field *schema.FieldDefinition) *ast.FuncDecl
 				},
 			},
 		},
+		Body: generateSubscriptionExecutorBody(subscription, typePrefix, indexes, useDataLoader),
 	}
 }
 
-func extractRootGraphQLType(fieldType *schema.FieldType) GraphQLType {
-	if fieldType.IsList {
-		return extractRootGraphQLType(fieldType.ListType)
+func generateSubscriptionEventChanType() ast.Expr {
+	return &ast.ChanType{
+		Dir: ast.RECV,
+		Value: &ast.StarExpr{
+			X: &ast.SelectorExpr{
+				X:   ast.NewIdent("executor"),
+				Sel: ast.NewIdent("GraphQLResponse"),
+			},
+		},
 	}
-
-	return GraphQLType(fieldType.Name)
 }
 
-func generateWrapResponseWriterWrite(rootFieldName string, field *schema.FieldDefinition) *ast.FuncDecl {
-	graphqlType := GraphQLType(field.Type.Name)
-
-	if field.Type.IsList {
-		ft := field.Type
-		for ft.IsList {
-			ft = ft.ListType
+func generateSubscriptionExecutorBody(subscription *schema.OperationDefinition, typePrefix string, indexes *schema.Indexes, useDataLoader bool) *ast.BlockStmt {
+	cases := make([]ast.Stmt, 0, len(subscription.Fields))
+	for _, field := range subscription.Fields {
+		caseBody := make([]ast.Stmt, 0)
+		if len(field.Arguments) > 0 {
+			caseBody = append(caseBody,
+				generateArgumentsAssignStmt(string(field.Name), field.Arguments),
+				generateReturnErrorHandlingStmt([]ast.Expr{
+					ast.NewIdent("nil"),
+				}),
+			)
+			caseBody = append(caseBody, generateArgumentDirectivesStmts(field.Arguments, ast.NewIdent("nil"), typePrefix, indexes, generateReturnErrorHandlingStmt([]ast.Expr{
+				ast.NewIdent("nil"),
+			}))...)
 		}
-		graphqlType = GraphQLType(ft.Name)
-	}
 
-	if graphqlType == "" {
-		return &ast.FuncDecl{
-			Name: ast.NewIdent("Write"),
-			Recv: &ast.FieldList{
-				List: []*ast.Field{
-					{
-						Names: []*ast.Ident{ast.NewIdent("w")},
-						Type: &ast.StarExpr{
-							X: &ast.Ident{
-								Name: "Wrap" + string(field.Name) + "ResponseWriter",
-							},
-						},
-					},
+		var applyExpr ast.Expr = &ast.SelectorExpr{
+			X:   ast.NewIdent("r"),
+			Sel: ast.NewIdent(fmt.Sprintf("apply%sQueryResponse", field.Name)),
+		}
+		if useDataLoader {
+			applyExpr = &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("executor"),
+					Sel: ast.NewIdent("WithEventContext"),
 				},
-			},
-			Type: &ast.FuncType{
-				Params: &ast.FieldList{
-					List: []*ast.Field{
-						{
-							Names: []*ast.Ident{ast.NewIdent("b")},
-							Type: &ast.ArrayType{
-								Elt: &ast.Ident{
-									Name: "byte",
-								},
-								Len: nil,
-							},
-						},
+				Args: []ast.Expr{
+					applyExpr,
+					&ast.SelectorExpr{
+						X:   ast.NewIdent("r"),
+						Sel: ast.NewIdent("withLoaders"),
 					},
 				},
-				Results: &ast.FieldList{
-					List: []*ast.Field{
-						{
-							Type: &ast.Ident{
-								Name: "int",
-							},
-						},
-						{
-							Type: &ast.Ident{
-								Name: "error",
-							},
+			}
+		}
+
+		caseBody = append(caseBody,
+			generateRootResolverCallStmt(field, generateSubscriptionResolverReturns(typePrefix, field, indexes), typePrefix, indexes),
+			generateReturnErrorHandlingStmt([]ast.Expr{
+				ast.NewIdent("nil"),
+			}),
+			&ast.ReturnStmt{
+				Results: []ast.Expr{
+					&ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X:   ast.NewIdent("executor"),
+							Sel: ast.NewIdent("Subscribe"),
 						},
-					},
-				},
-			},
-			Body: &ast.BlockStmt{
-				List: []ast.Stmt{
-					&ast.ReturnStmt{
-						Results: []ast.Expr{
-							ast.NewIdent("0"),
-							ast.NewIdent("nil"),
+						Args: []ast.Expr{
+							ast.NewIdent("ctx"),
+							ast.NewIdent("node"),
+							ast.NewIdent("resolverRet"),
+							applyExpr,
 						},
 					},
+					ast.NewIdent("nil"),
 				},
 			},
-		}
+		)
+
+		cases = append(cases, &ast.CaseClause{
+			List: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: fmt.Sprintf("\"%s\"", field.Name)}},
+			Body: caseBody,
+		})
 	}
 
-	var argExpr ast.Expr = &ast.SelectorExpr{
-		X:   ast.NewIdent("resp"),
-		Sel: ast.NewIdent("Data"),
+	return &ast.BlockStmt{
+		List: []ast.Stmt{
+			generateWithVariablesStmt(),
+			&ast.SwitchStmt{
+				Tag: ast.NewIdent("string(node.Name)"),
+				Body: &ast.BlockStmt{
+					List: cases,
+				},
+			},
+			&ast.ReturnStmt{
+				Results: []ast.Expr{
+					ast.NewIdent("nil"),
+					ast.NewIdent(`fmt.Errorf("subscription field %s is not defined", node.Name)`),
+				},
+			},
+		},
 	}
+}
 
+// generateSubscribeFuncDecl generates subscribe, which serves the operations of the WebSocket and SSE transports.
+// Queries and mutations are served as a single result, as both transports allow.
+func generateSubscribeFuncDecl(query, mutation *schema.OperationDefinition, useDataLoader bool) *ast.FuncDecl {
 	return &ast.FuncDecl{
-		Name: ast.NewIdent("Write"),
+		Name: ast.NewIdent("subscribe"),
 		Recv: &ast.FieldList{
 			List: []*ast.Field{
 				{
-					Names: []*ast.Ident{ast.NewIdent("w")},
-					Type: &ast.StarExpr{
-						X: &ast.Ident{
-							Name: "Wrap" + string(field.Name) + "ResponseWriter",
-						},
-					},
+					Names: []*ast.Ident{ast.NewIdent("r")},
+					Type:  &ast.StarExpr{X: ast.NewIdent("resolver")},
 				},
 			},
 		},
@@ -590,12 +466,19 @@ func generateWrapResponseWriterWrite(rootFieldName string, field *schema.FieldDe
 			Params: &ast.FieldList{
 				List: []*ast.Field{
 					{
-						Names: []*ast.Ident{ast.NewIdent("b")},
-						Type: &ast.ArrayType{
-							Elt: &ast.Ident{
-								Name: "byte",
+						Names: []*ast.Ident{ast.NewIdent("ctx")},
+						Type: &ast.SelectorExpr{
+							X:   ast.NewIdent("context"),
+							Sel: ast.NewIdent("Context"),
+						},
+					},
+					{
+						Names: []*ast.Ident{ast.NewIdent("request")},
+						Type: &ast.StarExpr{
+							X: &ast.SelectorExpr{
+								X:   ast.NewIdent("transport"),
+								Sel: ast.NewIdent("Request"),
 							},
-							Len: nil,
 						},
 					},
 				},
@@ -603,14 +486,10 @@ func generateWrapResponseWriterWrite(rootFieldName string, field *schema.FieldDe
 			Results: &ast.FieldList{
 				List: []*ast.Field{
 					{
-						Type: &ast.Ident{
-							Name: "int",
-						},
+						Type: generateSubscriptionEventChanType(),
 					},
 					{
-						Type: &ast.Ident{
-							Name: "error",
-						},
+						Type: ast.NewIdent("error"),
 					},
 				},
 			},
@@ -627,226 +506,141 @@ func generateWrapResponseWriterWrite(rootFieldName string, field *schema.FieldDe
 		},
 		Body: &ast.BlockStmt{
 			List: []ast.Stmt{
+				generatePersistedQueryAssignStmt("ctx"),
+				generateReturnErrorHandlingStmt([]ast.Expr{
+					ast.NewIdent("nil"),
+				}),
+				generateReplaceQueryStmt(),
 				&ast.AssignStmt{
 					Lhs: []ast.Expr{
-						ast.NewIdent("resp"),
+						ast.NewIdent("parsedQuery"),
+						ast.NewIdent("err"),
 					},
 					Tok: token.DEFINE,
 					Rhs: []ast.Expr{
-						&ast.UnaryExpr{
-							Op: token.AND,
-							X: &ast.CompositeLit{
-								Type: ast.NewIdent(fmt.Sprintf("%sGraphQLResponse", rootFieldName)),
-								Elts: []ast.Expr{},
-							},
+						&ast.SelectorExpr{
+							X:   ast.NewIdent("r.cache"),
+							Sel: ast.NewIdent("Parse(r.parser, request.Query)"),
 						},
 					},
 				},
-				&ast.ExprStmt{
-					X: &ast.BasicLit{},
-				},
-				&ast.IfStmt{
-					Init: &ast.AssignStmt{
-						Lhs: []ast.Expr{
-							ast.NewIdent("err"),
-						},
-						Tok: token.DEFINE,
-						Rhs: []ast.Expr{
-							&ast.SelectorExpr{
-								X:   ast.NewIdent("json"),
-								Sel: ast.NewIdent("Unmarshal(b, &resp)"),
-							},
+				generateReturnErrorHandlingStmt([]ast.Expr{
+					ast.NewIdent("nil"),
+				}),
+				generateSubscribeValidationStmt(),
+				generateGetOperationAssignStmt(),
+				generateReturnErrorHandlingStmt([]ast.Expr{
+					ast.NewIdent("nil"),
+				}),
+				generateCoerceVariablesAssignStmt("operation"),
+				generateSubscribeCoerceVariablesErrorStmt(),
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent("rootSelectionSet"),
+					},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{
+						&ast.SelectorExpr{
+							X:   ast.NewIdent("operation"),
+							Sel: ast.NewIdent("Selections"),
 						},
 					},
+				},
+				generatePlanKeyAssignStmt(),
+				generateCachedPlanExecutionAssignStmt(),
+				generateReturnErrorHandlingStmt([]ast.Expr{
+					ast.NewIdent("nil"),
+				}),
+				generateSubscribeSingleResultStmt(query, mutation, useDataLoader),
+				&ast.IfStmt{
 					Cond: &ast.BinaryExpr{
-						X:  ast.NewIdent("err"),
+						X:  ast.NewIdent("len(nodes)"),
 						Op: token.NEQ,
-						Y:  ast.NewIdent("nil"),
+						Y:  ast.NewIdent("1"),
 					},
 					Body: &ast.BlockStmt{
 						List: []ast.Stmt{
 							&ast.ReturnStmt{
 								Results: []ast.Expr{
-									&ast.CallExpr{
-										Fun: &ast.SelectorExpr{
-											X: &ast.SelectorExpr{
-												X:   ast.NewIdent("w"),
-												Sel: ast.NewIdent("ResponseWriter"),
-											},
-											Sel: ast.NewIdent("Write"),
-										},
-										Args: []ast.Expr{
-											ast.NewIdent(`[]byte(fmt.Sprintf("failed to Unmarshal\nuse \"executor.GraphQLResponse\" for response: %s", err.Error()))`),
-										},
-									},
+									ast.NewIdent("nil"),
+									ast.NewIdent(`errors.New("subscription must select exactly one root field")`),
 								},
 							},
 						},
 					},
 				},
-				&ast.ExprStmt{
-					X: &ast.BasicLit{},
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						&ast.CallExpr{
+							Fun: &ast.SelectorExpr{
+								X:   ast.NewIdent("r"),
+								Sel: ast.NewIdent("subscriptionExecutor"),
+							},
+							Args: []ast.Expr{
+								ast.NewIdent("ctx"),
+								ast.NewIdent("nodes[0]"),
+								ast.NewIdent("variables"),
+							},
+						},
+					},
 				},
+			},
+		},
+	}
+}
 
-				&ast.AssignStmt{
-					Tok: token.DEFINE,
-					Lhs: []ast.Expr{
-						ast.NewIdent("selected"),
-					},
-					Rhs: []ast.Expr{
-						&ast.UnaryExpr{
-							Op: token.AND,
-							X: &ast.CompositeLit{
-								Type: ast.NewIdent("wrap" + FieldName(rootFieldName).ExportedGolangFieldName() + extractRootGraphQLType(field.Type).golangType() + "Response"),
-								Elts: []ast.Expr{
-									&ast.KeyValueExpr{
-										Key: ast.NewIdent(FieldName(rootFieldName).ExportedGolangFieldName() + extractRootGraphQLType(field.Type).golangType() + "Response"),
-										Value: &ast.CallExpr{
-											Fun: &ast.SelectorExpr{
-												X:   ast.NewIdent("w"),
-												Sel: ast.NewIdent("walk" + string(rootFieldName)),
-											},
-											Args: []ast.Expr{
-												&ast.SelectorExpr{
-													X:   ast.NewIdent("w"),
-													Sel: ast.NewIdent("selections"),
-												},
-												argExpr,
-											},
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-
-				&ast.AssignStmt{
-					Lhs: []ast.Expr{
-						ast.NewIdent("selectedResp"),
-					},
-					Tok: token.DEFINE,
-					Rhs: []ast.Expr{
-						&ast.CallExpr{
-							Fun: ast.NewIdent("make"),
-							Args: []ast.Expr{
-								&ast.MapType{
-									Key:   &ast.Ident{Name: "string"},
-									Value: ast.NewIdent("any"),
-								},
-							},
-						},
-					},
-				},
+func generateSubscribeSingleResultStmt(query, mutation *schema.OperationDefinition, useDataLoader bool) ast.Stmt {
+	var cases []ast.Stmt
+	if query != nil {
+		cases = append(cases, generateSubscribeSingleResultCase("query", "ExecuteParallel", "queryExecutor", useDataLoader))
+	}
 
-				&ast.AssignStmt{
-					Tok: token.ASSIGN,
-					Lhs: []ast.Expr{
-						&ast.IndexExpr{
-							X:     ast.NewIdent("selectedResp"),
-							Index: ast.NewIdent("\"data\""),
-						},
-					},
-					Rhs: []ast.Expr{
-						ast.NewIdent("selected"),
-					},
-				},
+	if mutation != nil {
+		cases = append(cases, generateSubscribeSingleResultCase("mutation", "ExecuteSerially", "mutationExecutor", useDataLoader))
+	}
 
-				&ast.AssignStmt{
-					Tok: token.ASSIGN,
-					Lhs: []ast.Expr{
-						&ast.IndexExpr{
-							X:     ast.NewIdent("selectedResp"),
-							Index: ast.NewIdent("\"errors\""),
-						},
-					},
-					Rhs: []ast.Expr{
-						&ast.SelectorExpr{
-							X:   ast.NewIdent("resp"),
-							Sel: ast.NewIdent("Errors"),
-						},
-					},
-				},
+	if len(cases) == 0 {
+		return &ast.EmptyStmt{}
+	}
 
-				&ast.ExprStmt{
-					X: &ast.BasicLit{},
-				},
+	return &ast.SwitchStmt{
+		Tag: ast.NewIdent("operation.OperationType"),
+		Body: &ast.BlockStmt{
+			List: cases,
+		},
+	}
+}
 
-				&ast.AssignStmt{
-					Tok: token.DEFINE,
-					Lhs: []ast.Expr{
-						ast.NewIdent("respByte"),
-						ast.NewIdent("err"),
-					},
-					Rhs: []ast.Expr{
-						&ast.CallExpr{
-							Fun: &ast.SelectorExpr{
-								X:   ast.NewIdent("json"),
-								Sel: ast.NewIdent("Marshal"),
-							},
-							Args: []ast.Expr{
-								ast.NewIdent("selectedResp"),
-							},
+func generateSubscribeSingleResultCase(operationType, execute, operationExecutor string, useDataLoader bool) ast.Stmt {
+	return &ast.CaseClause{
+		List: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: fmt.Sprintf("\"%s\"", operationType)}},
+		Body: []ast.Stmt{
+			&ast.ReturnStmt{
+				Results: []ast.Expr{
+					&ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X:   ast.NewIdent("executor"),
+							Sel: ast.NewIdent("SingleResult"),
 						},
-					},
-				},
-
-				&ast.IfStmt{
-					Cond: &ast.BinaryExpr{
-						X:  ast.NewIdent("err"),
-						Op: token.NEQ,
-						Y:  ast.NewIdent("nil"),
-					},
-					Body: &ast.BlockStmt{
-						List: []ast.Stmt{
-							&ast.ExprStmt{
-								X: &ast.CallExpr{
-									Fun: &ast.SelectorExpr{
-										X: &ast.SelectorExpr{
-											X:   ast.NewIdent("w"),
-											Sel: ast.NewIdent("ResponseWriter"),
-										},
-										Sel: ast.NewIdent("WriteHeader"),
-									},
-									Args: []ast.Expr{
-										ast.NewIdent("http.StatusInternalServerError"),
-									},
+						Args: []ast.Expr{
+							&ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   ast.NewIdent("executor"),
+									Sel: ast.NewIdent(execute),
 								},
-							},
-							&ast.ReturnStmt{
-								Results: []ast.Expr{
-									&ast.CallExpr{
-										Fun: &ast.SelectorExpr{
-											X: &ast.SelectorExpr{
-												X:   ast.NewIdent("w"),
-												Sel: ast.NewIdent("ResponseWriter"),
-											},
-											Sel: ast.NewIdent("Write"),
-										},
-										Args: []ast.Expr{
-											ast.NewIdent("[]byte(err.Error())"),
-										},
+								Args: []ast.Expr{
+									generateOperationContextExpr(ast.NewIdent("ctx"), useDataLoader),
+									ast.NewIdent("nodes"),
+									ast.NewIdent("variables"),
+									&ast.SelectorExpr{
+										X:   ast.NewIdent("r"),
+										Sel: ast.NewIdent(operationExecutor),
 									},
 								},
 							},
 						},
 					},
-				},
-				&ast.ReturnStmt{
-					Results: []ast.Expr{
-						&ast.CallExpr{
-							Fun: &ast.SelectorExpr{
-								X: &ast.SelectorExpr{
-									X:   ast.NewIdent("w"),
-									Sel: ast.NewIdent("ResponseWriter"),
-								},
-								Sel: ast.NewIdent("Write"),
-							},
-							Args: []ast.Expr{
-								ast.NewIdent("respByte"),
-							},
-						},
-					},
+					ast.NewIdent("nil"),
 				},
 			},
 		},
@@ -878,7 +672,34 @@ func generateArgumentsAssignStmt(fieldName string, args schema.ArgumentDefinitio
 	}
 }
 
-func generateExecutorBody(op *schema.OperationDefinition, operationType string) *ast.BlockStmt {
+// generateRootFieldErrorHandlingStmt records the error of a root field.
+// A nullable root field resolves to null, while a non-nullable one returns the error so that data becomes null.
+func generateRootFieldErrorHandlingStmt(fieldType *schema.FieldType) ast.Stmt {
+	if !fieldType.Nullable {
+		return generateReturnErrorHandlingStmt([]ast.Expr{ast.NewIdent("nil")})
+	}
+
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("err"),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{X: generateAddFieldErrorExpr(ast.NewIdent("err"))},
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent("nil"),
+						ast.NewIdent("nil"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func generateExecutorBody(op *schema.OperationDefinition, operationType string, typeName []byte, typePrefix string, indexes *schema.Indexes, extraCases ...ast.Stmt) *ast.BlockStmt {
 	body := []ast.Stmt{}
 
 	if op == nil {
@@ -894,37 +715,12 @@ func generateExecutorBody(op *schema.OperationDefinition, operationType string)
 		if len(field.Arguments) > 0 {
 			caseBody = append(caseBody,
 				generateArgumentsAssignStmt(string(field.Name), field.Arguments),
-				&ast.IfStmt{
-					Cond: &ast.BinaryExpr{
-						X:  ast.NewIdent("err"),
-						Op: token.NEQ,
-						Y:  ast.NewIdent("nil"),
-					},
-					Body: &ast.BlockStmt{
-						List: []ast.Stmt{},
-					},
-				})
+				generateRootFieldErrorHandlingStmt(field.Type))
+			caseBody = append(caseBody, generateArgumentDirectivesStmts(field.Arguments, ast.NewIdent("nil"), typePrefix, indexes, generateRootFieldErrorHandlingStmt(field.Type))...)
 		}
 		caseBody = append(caseBody,
-			&ast.AssignStmt{
-				Lhs: []ast.Expr{
-					ast.NewIdent("resolverRet"),
-					ast.NewIdent("err"),
-				},
-				Tok: token.DEFINE,
-				Rhs: []ast.Expr{
-					&ast.CallExpr{
-						Fun: &ast.SelectorExpr{
-							X:   ast.NewIdent("r"),
-							Sel: ast.NewIdent(toUpperCase(string(field.Name))),
-						},
-						Args: generateFieldArguments(field.Arguments),
-					},
-				},
-			},
-			generateReturnErrorHandlingStmt([]ast.Expr{
-				ast.NewIdent("nil"),
-			}),
+			generateRootResolverCallStmt(field, generateResolverReturns(typePrefix, field, indexes), typePrefix, indexes),
+			generateRootFieldErrorHandlingStmt(field.Type),
 			&ast.AssignStmt{
 				Lhs: []ast.Expr{
 					ast.NewIdent("ret"),
@@ -938,15 +734,14 @@ func generateExecutorBody(op *schema.OperationDefinition, operationType string)
 							Sel: ast.NewIdent(fmt.Sprintf("apply%sQueryResponse", field.Name)),
 						},
 						Args: []ast.Expr{
+							ast.NewIdent("ctx"),
 							ast.NewIdent("resolverRet"),
 							ast.NewIdent("node"),
 						},
 					},
 				},
 			},
-			generateReturnErrorHandlingStmt([]ast.Expr{
-				ast.NewIdent("nil"),
-			}),
+			generateRootFieldErrorHandlingStmt(field.Type),
 			&ast.ReturnStmt{
 				Results: []ast.Expr{
 					ast.NewIdent("ret"),
@@ -961,112 +756,50 @@ func generateExecutorBody(op *schema.OperationDefinition, operationType string)
 		})
 	}
 
+	bodyStmt = append(bodyStmt, &ast.CaseClause{
+		List: []ast.Expr{
+			&ast.BasicLit{Kind: token.STRING, Value: "\"__typename\""},
+		},
+		Body: []ast.Stmt{
+			&ast.ReturnStmt{
+				Results: []ast.Expr{
+					&ast.BasicLit{Kind: token.STRING, Value: fmt.Sprintf("%q", typeName)},
+					ast.NewIdent("nil"),
+				},
+			},
+		},
+	})
+
 	if operationType == "query" {
-		// TODO: for introspection
-		// schemaCase := &ast.CaseClause{
-		// 	List: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: "\"__schema\""}},
-		// 	Body: []ast.Stmt{
-		// 		&ast.AssignStmt{
-		// 			Lhs: []ast.Expr{
-		// 				ast.NewIdent("ret"),
-		// 				ast.NewIdent("err"),
-		// 			},
-		// 			Tok: token.DEFINE,
-		// 			Rhs: []ast.Expr{
-		// 				&ast.CallExpr{
-		// 					Fun: &ast.SelectorExpr{
-		// 						X:   ast.NewIdent("r"),
-		// 						Sel: ast.NewIdent("__schema"),
-		// 					},
-		// 					Args: []ast.Expr{
-		// 						ast.NewIdent("ctx"),
-		// 						ast.NewIdent("node"),
-		// 						ast.NewIdent("variables"),
-		// 					},
-		// 				},
-		// 			},
-		// 		},
-		// 		&ast.IfStmt{
-		// 			Cond: &ast.BinaryExpr{
-		// 				X:  ast.NewIdent("err"),
-		// 				Op: token.NEQ,
-		// 				Y:  ast.NewIdent("nil"),
-		// 			},
-		// 			Body: &ast.BlockStmt{
-		// 				List: []ast.Stmt{
-		// 					&ast.ReturnStmt{
-		// 						Results: []ast.Expr{
-		// 							ast.NewIdent("nil"),
-		// 							ast.NewIdent("err"),
-		// 						},
-		// 					},
-		// 				},
-		// 			},
-		// 		},
-		// 		&ast.ReturnStmt{
-		// 			Results: []ast.Expr{
-		// 				ast.NewIdent("ret"),
-		// 				ast.NewIdent("nil"),
-		// 			},
-		// 		},
-		// 	},
-		// }
-
-		// typeCase := &ast.CaseClause{
-		// 	List: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: "\"__type\""}},
-		// 	Body: []ast.Stmt{
-		// 		&ast.AssignStmt{
-		// 			Lhs: []ast.Expr{
-		// 				ast.NewIdent("ret"),
-		// 				ast.NewIdent("err"),
-		// 			},
-		// 			Tok: token.DEFINE,
-		// 			Rhs: []ast.Expr{
-		// 				&ast.CallExpr{
-		// 					Fun: &ast.SelectorExpr{
-		// 						X:   ast.NewIdent("r"),
-		// 						Sel: ast.NewIdent("__type"),
-		// 					},
-		// 					Args: []ast.Expr{
-		// 						ast.NewIdent("ctx"),
-		// 						ast.NewIdent("node"),
-		// 						ast.NewIdent("variables"),
-		// 					},
-		// 				},
-		// 			},
-		// 		},
-		// 		&ast.IfStmt{
-		// 			Cond: &ast.BinaryExpr{
-		// 				X:  ast.NewIdent("err"),
-		// 				Op: token.NEQ,
-		// 				Y:  ast.NewIdent("nil"),
-		// 			},
-		// 			Body: &ast.BlockStmt{
-		// 				List: []ast.Stmt{
-		// 					&ast.ReturnStmt{
-		// 						Results: []ast.Expr{
-		// 							ast.NewIdent("nil"),
-		// 							ast.NewIdent("err"),
-		// 						},
-		// 					},
-		// 				},
-		// 			},
-		// 		},
-		// 		&ast.ReturnStmt{
-		// 			Results: []ast.Expr{
-		// 				ast.NewIdent("ret"),
-		// 				ast.NewIdent("nil"),
-		// 			},
-		// 		},
-		// 	},
-		// }
-		// bodyStmt = append(bodyStmt, schemaCase, typeCase)
+		bodyStmt = append(bodyStmt, &ast.CaseClause{
+			List: []ast.Expr{
+				&ast.BasicLit{Kind: token.STRING, Value: "\"__schema\""},
+				&ast.BasicLit{Kind: token.STRING, Value: "\"__type\""},
+			},
+			Body: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						&ast.CallExpr{
+							Fun: &ast.SelectorExpr{
+								X:   ast.NewIdent("r.introspection"),
+								Sel: ast.NewIdent("Resolve"),
+							},
+							Args: []ast.Expr{
+								ast.NewIdent("node"),
+								ast.NewIdent("variables"),
+							},
+						},
+					},
+				},
+			},
+		})
 	}
 
 	stmts := []ast.Stmt{}
 	stmts = append(stmts, bodyStmt...)
+	stmts = append(stmts, extraCases...)
 
-	body = append(body, &ast.SwitchStmt{
+	body = append(body, generateWithVariablesStmt(), &ast.SwitchStmt{
 		Tag: ast.NewIdent("string(node.Name)"),
 		Body: &ast.BlockStmt{
 			List: stmts,
@@ -1083,6 +816,51 @@ func generateExecutorBody(op *schema.OperationDefinition, operationType string)
 	}
 }
 
+// generateRootResolverCallStmt calls the resolver of a root field, through the directives applied to the field.
+func generateRootResolverCallStmt(field *schema.FieldDefinition, returns *ast.FieldList, typePrefix string, indexes *schema.Indexes) ast.Stmt {
+	var rhs ast.Expr = &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent("r"),
+			Sel: ast.NewIdent(toUpperCase(string(field.Name))),
+		},
+		Args: generateFieldArguments(field.Arguments),
+	}
+
+	if directives := executableDirectivesAt(field.Directives, fieldDefinitionLocation, indexes); len(directives) > 0 {
+		rhs = generateWithDirectivesExpr(returns.List[0].Type, []ast.Stmt{&ast.ReturnStmt{Results: []ast.Expr{rhs}}}, generateDirectiveFuncExprs(directives, ast.NewIdent("nil"), typePrefix, indexes))
+	}
+
+	return &ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("resolverRet"),
+			ast.NewIdent("err"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{rhs},
+	}
+}
+
+func generateWithVariablesStmt() ast.Stmt {
+	return &ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("ctx"),
+		},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("executor"),
+					Sel: ast.NewIdent("WithVariables"),
+				},
+				Args: []ast.Expr{
+					ast.NewIdent("ctx"),
+					ast.NewIdent("variables"),
+				},
+			},
+		},
+	}
+}
+
 func generateFieldArguments(arguments schema.ArgumentDefinitions) []ast.Expr {
 	args := make([]ast.Expr, 0, len(arguments)+1)
 	args = append(args, ast.NewIdent("ctx"))
@@ -1092,387 +870,102 @@ func generateFieldArguments(arguments schema.ArgumentDefinitions) []ast.Expr {
 	}
 
 	return args
-}
-
-func generateServeHTTPBody(query, mutation, subscription *schema.OperationDefinition) *ast.BlockStmt {
-	querySwitchCases := []ast.Stmt{}
-	// req.Body = io.NopCloser(strings.NewReader(string(request.Variables)))
-
-	if query != nil {
-		querySwitchCases = append(querySwitchCases,
-			&ast.AssignStmt{
-				Tok: token.DEFINE,
-				Lhs: []ast.Expr{
-					ast.NewIdent("data"),
-				},
-				Rhs: []ast.Expr{
-					&ast.CallExpr{
-						Fun: ast.NewIdent("make"),
-						Args: []ast.Expr{
-							&ast.MapType{
-								Key:   &ast.Ident{Name: "string"},
-								Value: ast.NewIdent("any"),
-							},
-						},
-					},
-				},
-			},
-			&ast.AssignStmt{
-				Tok: token.DEFINE,
-				Lhs: []ast.Expr{
-					ast.NewIdent("graphqlErrors"),
-				},
-				Rhs: []ast.Expr{
-					&ast.CallExpr{
-						Fun: ast.NewIdent("make"),
-						Args: []ast.Expr{
-							&ast.ArrayType{
-								Elt: ast.NewIdent("error"),
-							},
-							ast.NewIdent("0"),
-						},
-					},
-				},
-			},
-			&ast.RangeStmt{
-				Key:   ast.NewIdent("_"),
-				Tok:   token.DEFINE,
-				Value: ast.NewIdent("node"),
-				X:     ast.NewIdent("nodes"),
-				Body: &ast.BlockStmt{
-					List: []ast.Stmt{
-						&ast.AssignStmt{
-							Tok: token.DEFINE,
-							Lhs: []ast.Expr{
-								ast.NewIdent("ret"),
-								ast.NewIdent("err"),
-							},
-							Rhs: []ast.Expr{
-								&ast.CallExpr{
-									Fun: &ast.SelectorExpr{
-										X:   ast.NewIdent("r"),
-										Sel: ast.NewIdent("queryExecutor"),
-									},
-									Args: []ast.Expr{
-										&ast.CallExpr{
-											Fun: &ast.SelectorExpr{
-												X:   ast.NewIdent("req"),
-												Sel: ast.NewIdent("Context"),
-											},
-										},
-										ast.NewIdent("node"),
-										ast.NewIdent("variables"),
-									},
-								},
-							},
-						},
-						&ast.IfStmt{
-							Cond: &ast.BinaryExpr{
-								X:  ast.NewIdent("err"),
-								Op: token.NEQ,
-								Y:  ast.NewIdent("nil"),
-							},
-							Body: &ast.BlockStmt{
-								List: []ast.Stmt{
-									&ast.AssignStmt{
-										Lhs: []ast.Expr{
-											ast.NewIdent("graphqlErrors"),
-										},
-										Tok: token.ASSIGN,
-										Rhs: []ast.Expr{
-											&ast.CallExpr{
-												Fun: ast.NewIdent("append"),
-												Args: []ast.Expr{
-													ast.NewIdent("graphqlErrors"),
-													ast.NewIdent("err"),
-												},
-											},
-										},
-									},
-								},
-							},
-						},
-						&ast.AssignStmt{
-							Tok: token.ASSIGN,
-							Lhs: []ast.Expr{
-								&ast.IndexExpr{
-									X: ast.NewIdent("data"),
-									Index: &ast.CallExpr{
-										Fun: ast.NewIdent("string"),
-										Args: []ast.Expr{
-											&ast.SelectorExpr{
-												X:   ast.NewIdent("node"),
-												Sel: ast.NewIdent("Name"),
-											},
-										},
-									},
-								},
-							},
-							Rhs: []ast.Expr{
-								&ast.CallExpr{
-									Fun: &ast.SelectorExpr{
-										X:   ast.NewIdent("executor"),
-										Sel: ast.NewIdent("NewNullable"),
-									},
-									Args: []ast.Expr{
-										ast.NewIdent("ret"),
-									},
-								},
-							},
-						},
-					},
-				},
-			}, generateResponseWrite())
+}
+
+func generateServeHTTPBody(query, mutation, subscription *schema.OperationDefinition, useDataLoader bool) *ast.BlockStmt {
+	queryCaseBody := generateOperationNotSupportedStmts("query")
+	if query != nil {
+		queryCaseBody = generateExecutePlannedOperationStmts("ExecuteParallel", "queryExecutor", useDataLoader)
 	}
 
-	mutationSwitchCases := []ast.Stmt{}
+	mutationCaseBody := generateOperationNotSupportedStmts("mutation")
 	if mutation != nil {
-		mutationSwitchCases = append(mutationSwitchCases, &ast.AssignStmt{
-			Tok: token.DEFINE,
-			Lhs: []ast.Expr{
-				ast.NewIdent("data"),
-			},
-			Rhs: []ast.Expr{
-				&ast.CallExpr{
-					Fun: ast.NewIdent("make"),
-					Args: []ast.Expr{
-						&ast.MapType{
-							Key:   &ast.Ident{Name: "string"},
-							Value: ast.NewIdent("any"),
-						},
-					},
-				},
-			},
-		},
-			&ast.AssignStmt{
-				Tok: token.DEFINE,
-				Lhs: []ast.Expr{
-					ast.NewIdent("graphqlErrors"),
+		mutationCaseBody = generateExecutePlannedOperationStmts("ExecuteSerially", "mutationExecutor", useDataLoader)
+	}
+
+	var upgradeStmt ast.Stmt = &ast.EmptyStmt{}
+	subscriptionCaseBody := generateOperationNotSupportedStmts("subscription")
+	if subscription != nil {
+		upgradeStmt = &ast.IfStmt{
+			Cond: &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("transport"),
+					Sel: ast.NewIdent("IsWebSocketUpgrade"),
 				},
-				Rhs: []ast.Expr{
-					&ast.CallExpr{
-						Fun: ast.NewIdent("make"),
-						Args: []ast.Expr{
-							&ast.ArrayType{
-								Elt: ast.NewIdent("error"),
-							},
-							ast.NewIdent("0"),
-						},
-					},
+				Args: []ast.Expr{
+					ast.NewIdent("req"),
 				},
 			},
-			&ast.RangeStmt{
-				Key:   ast.NewIdent("_"),
-				Tok:   token.DEFINE,
-				Value: ast.NewIdent("node"),
-				X:     ast.NewIdent("nodes"),
-				Body: &ast.BlockStmt{
-					List: []ast.Stmt{
-						&ast.AssignStmt{
-							Tok: token.DEFINE,
-							Lhs: []ast.Expr{
-								ast.NewIdent("ret"),
-								ast.NewIdent("err"),
-							},
-							Rhs: []ast.Expr{
-								&ast.CallExpr{
-									Fun: &ast.SelectorExpr{
-										X:   ast.NewIdent("r"),
-										Sel: ast.NewIdent("mutationExecutor"),
-									},
-									Args: []ast.Expr{
-										&ast.CallExpr{
-											Fun: &ast.SelectorExpr{
-												X:   ast.NewIdent("req"),
-												Sel: ast.NewIdent("Context"),
-											},
-										},
-										ast.NewIdent("node"),
-										ast.NewIdent("variables"),
-									},
-								},
-							},
-						},
-						&ast.IfStmt{
-							Cond: &ast.BinaryExpr{
-								X:  ast.NewIdent("err"),
-								Op: token.NEQ,
-								Y:  ast.NewIdent("nil"),
-							},
-							Body: &ast.BlockStmt{
-								List: []ast.Stmt{
-									&ast.AssignStmt{
-										Lhs: []ast.Expr{
-											ast.NewIdent("graphqlErrors"),
-										},
-										Tok: token.ASSIGN,
-										Rhs: []ast.Expr{
-											&ast.CallExpr{
-												Fun: ast.NewIdent("append"),
-												Args: []ast.Expr{
-													ast.NewIdent("graphqlErrors"),
-													ast.NewIdent("err"),
-												},
-											},
-										},
-									},
-								},
-							},
-						},
-						&ast.AssignStmt{
-							Tok: token.ASSIGN,
-							Lhs: []ast.Expr{
-								&ast.IndexExpr{
-									X: ast.NewIdent("data"),
-									Index: &ast.CallExpr{
-										Fun: ast.NewIdent("string"),
-										Args: []ast.Expr{
-											&ast.SelectorExpr{
-												X:   ast.NewIdent("node"),
-												Sel: ast.NewIdent("Name"),
-											},
-										},
-									},
-								},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ExprStmt{
+						X: &ast.CallExpr{
+							Fun: &ast.SelectorExpr{
+								X:   ast.NewIdent("transport"),
+								Sel: ast.NewIdent("ServeWebSocket"),
 							},
-							Rhs: []ast.Expr{
-								&ast.CallExpr{
-									Fun: &ast.SelectorExpr{
-										X:   ast.NewIdent("executor"),
-										Sel: ast.NewIdent("NewNullable"),
-									},
-									Args: []ast.Expr{
-										ast.NewIdent("ret"),
-									},
+							Args: []ast.Expr{
+								ast.NewIdent("w"),
+								ast.NewIdent("req"),
+								&ast.SelectorExpr{
+									X:   ast.NewIdent("r"),
+									Sel: ast.NewIdent("subscribe"),
 								},
 							},
 						},
 					},
+					&ast.ReturnStmt{},
 				},
-			}, generateResponseWrite())
-	}
-
-	subscriptionSwitchCases := []ast.Stmt{}
-	if subscription != nil {
-		subscriptionSwitchCases = append(subscriptionSwitchCases, &ast.AssignStmt{
-			Tok: token.DEFINE,
-			Lhs: []ast.Expr{
-				ast.NewIdent("data"),
 			},
-			Rhs: []ast.Expr{
-				&ast.CallExpr{
-					Fun: ast.NewIdent("make"),
-					Args: []ast.Expr{
-						&ast.MapType{
-							Key:   &ast.Ident{Name: "string"},
-							Value: ast.NewIdent("any"),
-						},
+			Else: &ast.IfStmt{
+				Cond: &ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X:   ast.NewIdent("transport"),
+						Sel: ast.NewIdent("IsEventStreamRequest"),
 					},
-				},
-			},
-		},
-			&ast.AssignStmt{
-				Tok: token.DEFINE,
-				Lhs: []ast.Expr{
-					ast.NewIdent("graphqlErrors"),
-				},
-				Rhs: []ast.Expr{
-					&ast.CallExpr{
-						Fun: ast.NewIdent("make"),
-						Args: []ast.Expr{
-							&ast.ArrayType{
-								Elt: ast.NewIdent("error"),
-							},
-							ast.NewIdent("0"),
-						},
+					Args: []ast.Expr{
+						ast.NewIdent("req"),
 					},
 				},
-			},
-			&ast.RangeStmt{
-				Key:   ast.NewIdent("_"),
-				Tok:   token.DEFINE,
-				Value: ast.NewIdent("node"),
-				X:     ast.NewIdent("nodes"),
 				Body: &ast.BlockStmt{
 					List: []ast.Stmt{
-						&ast.AssignStmt{
-							Tok: token.DEFINE,
-							Lhs: []ast.Expr{
-								ast.NewIdent("ret"),
-								ast.NewIdent("err"),
-							},
-							Rhs: []ast.Expr{
-								&ast.CallExpr{
-									Fun: &ast.SelectorExpr{
-										X:   ast.NewIdent("r"),
-										Sel: ast.NewIdent("subscriptionExecutor"),
-									},
-									Args: []ast.Expr{
-										&ast.CallExpr{
-											Fun: &ast.SelectorExpr{
-												X:   ast.NewIdent("req"),
-												Sel: ast.NewIdent("Context"),
-											},
-										},
-										ast.NewIdent("node"),
-										ast.NewIdent("variables"),
-									},
-								},
-							},
-						},
-						&ast.IfStmt{
-							Cond: &ast.BinaryExpr{
-								X:  ast.NewIdent("err"),
-								Op: token.NEQ,
-								Y:  ast.NewIdent("nil"),
-							},
-							Body: &ast.BlockStmt{
-								List: []ast.Stmt{
-									&ast.AssignStmt{
-										Lhs: []ast.Expr{
-											ast.NewIdent("graphqlErrors"),
-										},
-										Tok: token.ASSIGN,
-										Rhs: []ast.Expr{
-											&ast.CallExpr{
-												Fun: ast.NewIdent("append"),
-												Args: []ast.Expr{
-													ast.NewIdent("graphqlErrors"),
-													ast.NewIdent("err"),
-												},
-											},
-										},
-									},
+						&ast.ExprStmt{
+							X: &ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   ast.NewIdent("transport"),
+									Sel: ast.NewIdent("ServeSSE"),
 								},
-							},
-						},
-						&ast.AssignStmt{
-							Tok: token.ASSIGN,
-							Lhs: []ast.Expr{
-								&ast.IndexExpr{
-									X: ast.NewIdent("data"),
-									Index: &ast.CallExpr{
-										Fun: ast.NewIdent("string"),
-										Args: []ast.Expr{
-											&ast.SelectorExpr{
-												X:   ast.NewIdent("node"),
-												Sel: ast.NewIdent("Name"),
-											},
-										},
+								Args: []ast.Expr{
+									ast.NewIdent("w"),
+									ast.NewIdent("req"),
+									&ast.SelectorExpr{
+										X:   ast.NewIdent("r"),
+										Sel: ast.NewIdent("subscribe"),
 									},
 								},
 							},
-							Rhs: []ast.Expr{
-								ast.NewIdent("ret"),
-							},
 						},
+						&ast.ReturnStmt{},
 					},
 				},
-			}, generateResponseWrite())
+			},
+		}
+
+		subscriptionCaseBody = []ast.Stmt{
+			&ast.ExprStmt{X: &ast.CallExpr{
+				Fun: ast.NewIdent("http.Error"),
+				Args: []ast.Expr{
+					ast.NewIdent("w"),
+					&ast.BasicLit{Kind: token.STRING, Value: "\"subscription requires a websocket or event-stream connection\""},
+					ast.NewIdent("http.StatusBadRequest"),
+				},
+			}},
+		}
 	}
 
 	return &ast.BlockStmt{
 		List: []ast.Stmt{
+			upgradeStmt,
 			&ast.ExprStmt{X: &ast.BasicLit{}},
 			&ast.DeclStmt{Decl: &ast.GenDecl{
 				Tok: token.VAR,
@@ -1491,6 +984,13 @@ func generateServeHTTPBody(query, mutation, subscription *schema.OperationDefini
 											Sel: ast.NewIdent("RawMessage"),
 										},
 									}},
+									{Names: []*ast.Ident{ast.NewIdent("Extensions")}, Type: &ast.MapType{
+										Key: ast.NewIdent("string"),
+										Value: &ast.SelectorExpr{
+											X:   ast.NewIdent("json"),
+											Sel: ast.NewIdent("RawMessage"),
+										},
+									}},
 								},
 							},
 						},
@@ -1537,6 +1037,10 @@ func generateServeHTTPBody(query, mutation, subscription *schema.OperationDefini
 
 			&ast.ExprStmt{X: &ast.BasicLit{}},
 
+			generatePersistedQueryAssignStmt("req.Context()"),
+			generateServeHTTPPersistedQueryErrorStmt(),
+			generateReplaceQueryStmt(),
+
 			&ast.ExprStmt{
 				X: &ast.BasicLit{
 					Kind:  token.STRING,
@@ -1552,8 +1056,8 @@ func generateServeHTTPBody(query, mutation, subscription *schema.OperationDefini
 				Tok: token.DEFINE,
 				Rhs: []ast.Expr{
 					&ast.SelectorExpr{
-						X:   ast.NewIdent("r.parser"),
-						Sel: ast.NewIdent("Parse([]byte(request.Query))"),
+						X:   ast.NewIdent("r.cache"),
+						Sel: ast.NewIdent("Parse(r.parser, request.Query)"),
 					},
 				},
 			},
@@ -1580,377 +1084,102 @@ func generateServeHTTPBody(query, mutation, subscription *schema.OperationDefini
 				},
 			},
 
-			&ast.ExprStmt{X: &ast.BasicLit{}},
-
-			&ast.AssignStmt{
-				Lhs: []ast.Expr{ast.NewIdent("operationType")},
-				Tok: token.DEFINE,
-				Rhs: []ast.Expr{
-					&ast.CallExpr{
-						Fun: &ast.SelectorExpr{
-							X:   ast.NewIdent("utils"),
-							Sel: ast.NewIdent("GetOperationType"),
-						},
-						Args: []ast.Expr{
-							&ast.SelectorExpr{
-								X:   ast.NewIdent("parsedQuery"),
-								Sel: ast.NewIdent("Operations"),
-							},
-							&ast.SelectorExpr{
-								X:   ast.NewIdent("request"),
-								Sel: ast.NewIdent("OperationName"),
-							},
-						},
-					},
-				},
-			},
-
-			&ast.AssignStmt{
-				Lhs: []ast.Expr{
-					ast.NewIdent("variables"),
-				},
-				Tok: token.DEFINE,
-				Rhs: []ast.Expr{
-					&ast.SelectorExpr{
-						X:   ast.NewIdent("request"),
-						Sel: ast.NewIdent("Variables"),
-					},
-				},
-			},
-
-			&ast.SwitchStmt{
-				Tag: ast.NewIdent("operationType"),
-				Body: &ast.BlockStmt{
-					List: []ast.Stmt{
-						&ast.CaseClause{
-							List: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: "\"query\""}},
-							Body: append([]ast.Stmt{
-								&ast.AssignStmt{
-									Tok: token.DEFINE,
-									Lhs: []ast.Expr{
-										ast.NewIdent("rootSelectionSet"),
-									},
-									Rhs: []ast.Expr{
-										&ast.SelectorExpr{
-											X:   ast.NewIdent("utils"),
-											Sel: ast.NewIdent("ExtractExecuteSelector(parsedQuery.Operations.GetQuery(), request.OperationName)"),
-										},
-									},
-								},
-
-								&ast.AssignStmt{
-									Lhs: []ast.Expr{
-										ast.NewIdent("cacheMap"),
-									},
-									Tok: token.DEFINE,
-									Rhs: []ast.Expr{
-										&ast.TypeAssertExpr{
-											X: &ast.CallExpr{
-												Fun: &ast.SelectorExpr{
-													X: &ast.SelectorExpr{
-														X:   ast.NewIdent("r"),
-														Sel: ast.NewIdent("pool"),
-													},
-													Sel: ast.NewIdent("Get"),
-												},
-											},
-											Type: &ast.SelectorExpr{
-												X:   ast.NewIdent("executor"),
-												Sel: ast.NewIdent("CacheMap"),
-											},
-										},
-									},
-								},
-
-								&ast.DeferStmt{
-									Call: &ast.CallExpr{
-										Fun: &ast.FuncLit{
-											Type: &ast.FuncType{
-												Params: &ast.FieldList{
-													List: []*ast.Field{},
-												},
-											},
-											Body: &ast.BlockStmt{
-												List: []ast.Stmt{
-													&ast.ExprStmt{
-														X: &ast.CallExpr{
-															Fun: &ast.SelectorExpr{
-																X: &ast.SelectorExpr{
-																	X:   ast.NewIdent("r"),
-																	Sel: ast.NewIdent("pool"),
-																},
-																Sel: ast.NewIdent("Put"),
-															},
-															Args: []ast.Expr{
-																ast.NewIdent("cacheMap"),
-															},
-														},
-													},
-												},
-											},
-										},
-									},
-								},
-
-								&ast.AssignStmt{
-									Tok: token.DEFINE,
-									Lhs: []ast.Expr{
-										ast.NewIdent("nodes"),
-									},
-									Rhs: []ast.Expr{
-										&ast.CallExpr{
-											Fun: &ast.SelectorExpr{
-												X:   ast.NewIdent("cacheMap"),
-												Sel: ast.NewIdent("Get"),
-											},
-											Args: []ast.Expr{
-												&ast.SelectorExpr{
-													X:   ast.NewIdent("request"),
-													Sel: ast.NewIdent("Query"),
-												},
-											},
-										},
-									},
-								},
-
-								&ast.IfStmt{
-									Cond: &ast.BinaryExpr{
-										X:  ast.NewIdent("nodes"),
-										Op: token.EQL,
-										Y:  ast.NewIdent("nil"),
-									},
-									Body: &ast.BlockStmt{
-										List: []ast.Stmt{
-											&ast.AssignStmt{
-												Lhs: []ast.Expr{
-													ast.NewIdent("nodes"),
-												},
-												Tok: token.ASSIGN,
-												Rhs: []ast.Expr{
-													&ast.CallExpr{
-														Fun: &ast.SelectorExpr{
-															X:   ast.NewIdent("executor"),
-															Sel: ast.NewIdent("PlanExecution"),
-														},
-														Args: []ast.Expr{
-															ast.NewIdent("rootSelectionSet"),
-															&ast.SelectorExpr{
-																X:   ast.NewIdent("parsedQuery"),
-																Sel: ast.NewIdent("FragmentDefinitions"),
-															},
-														},
-													},
-												},
-											},
-
-											&ast.ExprStmt{
-												X: &ast.CallExpr{
-													Fun: &ast.SelectorExpr{
-														X:   ast.NewIdent("cacheMap"),
-														Sel: ast.NewIdent("Set"),
-													},
-													Args: []ast.Expr{
-														&ast.SelectorExpr{
-															X:   ast.NewIdent("request"),
-															Sel: ast.NewIdent("Query"),
-														},
-														ast.NewIdent("nodes"),
-														&ast.BinaryExpr{
-															X: &ast.SelectorExpr{
-																X:   ast.NewIdent("time"),
-																Sel: ast.NewIdent("Minute"),
-															},
-															Op: token.MUL,
-															Y: &ast.BasicLit{
-																Kind:  token.INT,
-																Value: "1",
-															},
-														},
-													},
-												},
-											},
-										},
-									},
-								},
-							}, querySwitchCases...),
-						},
-
-						&ast.CaseClause{
-							List: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: "\"mutation\""}},
-							Body: append([]ast.Stmt{
-								&ast.AssignStmt{
-									Tok: token.DEFINE,
-									Lhs: []ast.Expr{
-										ast.NewIdent("rootSelectionSet"),
-									},
-									Rhs: []ast.Expr{
-										&ast.SelectorExpr{
-											X:   ast.NewIdent("utils"),
-											Sel: ast.NewIdent("ExtractExecuteSelector(parsedQuery.Operations.GetMutation(), request.OperationName)"),
-										},
-									},
-								},
+			generateServeHTTPValidationStmt(),
 
-								&ast.AssignStmt{
-									Lhs: []ast.Expr{
-										ast.NewIdent("cacheMap"),
-									},
-									Tok: token.DEFINE,
-									Rhs: []ast.Expr{
-										&ast.TypeAssertExpr{
-											X: &ast.CallExpr{
-												Fun: &ast.SelectorExpr{
-													X: &ast.SelectorExpr{
-														X:   ast.NewIdent("r"),
-														Sel: ast.NewIdent("pool"),
-													},
-													Sel: ast.NewIdent("Get"),
-												},
-											},
-											Type: &ast.SelectorExpr{
-												X:   ast.NewIdent("executor"),
-												Sel: ast.NewIdent("CacheMap"),
-											},
-										},
-									},
-								},
+			&ast.ExprStmt{X: &ast.BasicLit{}},
 
-								&ast.DeferStmt{
-									Call: &ast.CallExpr{
-										Fun: &ast.FuncLit{
-											Type: &ast.FuncType{
-												Params: &ast.FieldList{
-													List: []*ast.Field{},
-												},
-											},
-											Body: &ast.BlockStmt{
-												List: []ast.Stmt{
-													&ast.ExprStmt{
-														X: &ast.CallExpr{
-															Fun: &ast.SelectorExpr{
-																X: &ast.SelectorExpr{
-																	X:   ast.NewIdent("r"),
-																	Sel: ast.NewIdent("pool"),
-																},
-																Sel: ast.NewIdent("Put"),
-															},
-															Args: []ast.Expr{
-																ast.NewIdent("cacheMap"),
-															},
-														},
-													},
-												},
-											},
-										},
-									},
-								},
+			generateGetOperationAssignStmt(),
+			generateServeHTTPGetOperationErrorStmt(),
 
-								&ast.AssignStmt{
-									Tok: token.DEFINE,
-									Lhs: []ast.Expr{
-										ast.NewIdent("nodes"),
-									},
-									Rhs: []ast.Expr{
-										&ast.CallExpr{
-											Fun: &ast.SelectorExpr{
-												X:   ast.NewIdent("cacheMap"),
-												Sel: ast.NewIdent("Get"),
-											},
-											Args: []ast.Expr{
-												&ast.SelectorExpr{
-													X:   ast.NewIdent("request"),
-													Sel: ast.NewIdent("Query"),
-												},
-											},
-										},
-									},
-								},
+			generateCoerceVariablesAssignStmt("operation"),
+			generateServeHTTPCoerceVariablesErrorStmt(),
+			generatePlanKeyAssignStmt(),
 
-								&ast.IfStmt{
-									Cond: &ast.BinaryExpr{
-										X:  ast.NewIdent("nodes"),
-										Op: token.EQL,
-										Y:  ast.NewIdent("nil"),
-									},
-									Body: &ast.BlockStmt{
-										List: []ast.Stmt{
-											&ast.AssignStmt{
-												Lhs: []ast.Expr{
-													ast.NewIdent("nodes"),
-												},
-												Tok: token.ASSIGN,
-												Rhs: []ast.Expr{
-													&ast.CallExpr{
-														Fun: &ast.SelectorExpr{
-															X:   ast.NewIdent("executor"),
-															Sel: ast.NewIdent("PlanExecution"),
-														},
-														Args: []ast.Expr{
-															ast.NewIdent("rootSelectionSet"),
-															&ast.SelectorExpr{
-																X:   ast.NewIdent("parsedQuery"),
-																Sel: ast.NewIdent("FragmentDefinitions"),
-															},
-														},
-													},
-												},
-											},
+			&ast.SwitchStmt{
+				Tag: ast.NewIdent("operation.OperationType"),
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.CaseClause{
+							List: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: "\"query\""}},
+							Body: queryCaseBody,
+						},
 
-											&ast.ExprStmt{
-												X: &ast.CallExpr{
-													Fun: &ast.SelectorExpr{
-														X:   ast.NewIdent("cacheMap"),
-														Sel: ast.NewIdent("Set"),
-													},
-													Args: []ast.Expr{
-														&ast.SelectorExpr{
-															X:   ast.NewIdent("request"),
-															Sel: ast.NewIdent("Query"),
-														},
-														ast.NewIdent("nodes"),
-														&ast.BinaryExpr{
-															X: &ast.SelectorExpr{
-																X:   ast.NewIdent("time"),
-																Sel: ast.NewIdent("Minute"),
-															},
-															Op: token.MUL,
-															Y: &ast.BasicLit{
-																Kind:  token.INT,
-																Value: "1",
-															},
-														},
-													},
-												},
-											},
-										},
-									},
-								},
-							}, mutationSwitchCases...),
+						&ast.CaseClause{
+							List: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: "\"mutation\""}},
+							Body: mutationCaseBody,
 						},
 
 						&ast.CaseClause{
 							List: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: "\"subscription\""}},
-							Body: []ast.Stmt{
-								&ast.AssignStmt{
-									Tok: token.DEFINE,
-									Lhs: []ast.Expr{
-										ast.NewIdent("operationName"),
-									},
-									Rhs: []ast.Expr{
-										&ast.SelectorExpr{
-											X:   ast.NewIdent("utils"),
-											Sel: ast.NewIdent("ExtractSelectorName(parsedQuery.Operations.GetSubscription(), request.OperationName)"),
-										},
-									},
-								},
-								&ast.SwitchStmt{
-									Tag: ast.NewIdent("operationName"),
-									Body: &ast.BlockStmt{
-										List: subscriptionSwitchCases,
-									},
-								},
-							},
+							Body: subscriptionCaseBody,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// generateExecutePlannedOperationStmts plans the selected operation, reusing a cached plan, and writes the result of executing its root fields.
+func generateExecutePlannedOperationStmts(execute, operationExecutor string, useDataLoader bool) []ast.Stmt {
+	return []ast.Stmt{
+		&ast.AssignStmt{
+			Tok: token.DEFINE,
+			Lhs: []ast.Expr{
+				ast.NewIdent("rootSelectionSet"),
+			},
+			Rhs: []ast.Expr{
+				&ast.SelectorExpr{
+					X:   ast.NewIdent("operation"),
+					Sel: ast.NewIdent("Selections"),
+				},
+			},
+		},
+		generateCachedPlanExecutionAssignStmt(),
+		generateServeHTTPPlanExecutionErrorStmt(),
+		generateExecuteOperationStmt(execute, operationExecutor, useDataLoader),
+		generateResponseWrite(),
+	}
+}
+
+func generateOperationNotSupportedStmts(operationType string) []ast.Stmt {
+	return []ast.Stmt{
+		&ast.ExprStmt{X: &ast.CallExpr{
+			Fun: ast.NewIdent("http.Error"),
+			Args: []ast.Expr{
+				ast.NewIdent("w"),
+				&ast.BasicLit{Kind: token.STRING, Value: fmt.Sprintf("\"%s is not supported\"", operationType)},
+				ast.NewIdent("http.StatusBadRequest"),
+			},
+		}},
+	}
+}
+
+// generateExecuteOperationStmt executes every root field of the planned operation with the executor function of the operation type.
+func generateExecuteOperationStmt(execute, operationExecutor string, useDataLoader bool) ast.Stmt {
+	return &ast.AssignStmt{
+		Tok: token.DEFINE,
+		Lhs: []ast.Expr{
+			ast.NewIdent("resp"),
+		},
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("executor"),
+					Sel: ast.NewIdent(execute),
+				},
+				Args: []ast.Expr{
+					generateOperationContextExpr(&ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X:   ast.NewIdent("req"),
+							Sel: ast.NewIdent("Context"),
 						},
+					}, useDataLoader),
+					ast.NewIdent("nodes"),
+					ast.NewIdent("variables"),
+					&ast.SelectorExpr{
+						X:   ast.NewIdent("r"),
+						Sel: ast.NewIdent(operationExecutor),
 					},
 				},
 			},
@@ -1978,25 +1207,7 @@ func generateResponseWrite() ast.Stmt {
 						Sel: ast.NewIdent("Encode"),
 					},
 					Args: []ast.Expr{
-						&ast.UnaryExpr{
-							Op: token.AND,
-							X: &ast.CompositeLit{
-								Type: &ast.SelectorExpr{
-									X:   ast.NewIdent("executor"),
-									Sel: ast.NewIdent("GraphQLResponse"),
-								},
-								Elts: []ast.Expr{
-									&ast.KeyValueExpr{
-										Key:   ast.NewIdent("Data"),
-										Value: ast.NewIdent("data"),
-									},
-									&ast.KeyValueExpr{
-										Key:   ast.NewIdent("Errors"),
-										Value: ast.NewIdent("graphqlErrors"),
-									},
-								},
-							},
-						},
+						ast.NewIdent("resp"),
 					},
 				},
 			},
@@ -2133,6 +1344,16 @@ func generateResolverReturns(typePrefix string, field *schema.FieldDefinition, i
 	}
 }
 
+func generateSubscriptionResolverReturns(typePrefix string, field *schema.FieldDefinition, indexes *schema.Indexes) *ast.FieldList {
+	ret := generateResolverReturns(typePrefix, field, indexes)
+	ret.List[0].Type = &ast.ChanType{
+		Dir:   ast.RECV,
+		Value: ret.List[0].Type,
+	}
+
+	return ret
+}
+
 func generatePrefixCheck(operation string) *ast.IfStmt {
 	return &ast.IfStmt{
 		Cond: &ast.CallExpr{
@@ -2162,6 +1383,11 @@ func generateInterfaceField(typePrefix string, operation *schema.OperationDefini
 		fields := make([]*ast.Field, 0, len(field))
 
 		for _, f := range field {
+			results := generateResolverReturns(typePrefix, f, indexes)
+			if operation.OperationType.IsSubscription() {
+				results = generateSubscriptionResolverReturns(typePrefix, f, indexes)
+			}
+
 			fields = append(fields, &ast.Field{
 				Names: []*ast.Ident{
 					{
@@ -2170,7 +1396,7 @@ func generateInterfaceField(typePrefix string, operation *schema.OperationDefini
 				},
 				Type: &ast.FuncType{
 					Params:  generateResolverArgs(typePrefix, f, indexes),
-					Results: generateResolverReturns(typePrefix, f, indexes),
+					Results: results,
 				},
 			})
 		}
@@ -2249,12 +1475,54 @@ func generateResolverImplementationStruct() []ast.Decl {
 								},
 								{
 									Names: []*ast.Ident{
-										ast.NewIdent("pool"),
+										ast.NewIdent("cache"),
+									},
+									Type: &ast.StarExpr{
+										X: &ast.SelectorExpr{
+											X:   ast.NewIdent("executor"),
+											Sel: ast.NewIdent("Cache"),
+										},
+									},
+								},
+								{
+									Names: []*ast.Ident{
+										ast.NewIdent("persistedQueryStore"),
+									},
+									Type: &ast.SelectorExpr{
+										X:   ast.NewIdent("executor"),
+										Sel: ast.NewIdent("PersistedQueryStore"),
+									},
+								},
+								{
+									Names: []*ast.Ident{
+										ast.NewIdent("trustedDocuments"),
+									},
+									Type: &ast.StarExpr{
+										X: &ast.SelectorExpr{
+											X:   ast.NewIdent("executor"),
+											Sel: ast.NewIdent("TrustedDocuments"),
+										},
+									},
+								},
+								{
+									Names: []*ast.Ident{
+										ast.NewIdent("validator"),
+									},
+									Type: &ast.StarExpr{
+										X: &ast.SelectorExpr{
+											X:   ast.NewIdent("validator"),
+											Sel: ast.NewIdent("Validator"),
+										},
+									},
+								},
+								{
+									Names: []*ast.Ident{
+										ast.NewIdent("introspection"),
 									},
 									Type: &ast.StarExpr{
 										X: &ast.SelectorExpr{
-											X:   ast.NewIdent("sync"),
-											Sel: ast.NewIdent("Pool"),
+											X:   ast.NewIdent("executor"),
+											Sel: ast.NewIdent("Introspection"),
 										},
 									},
 								},
@@ -2285,9 +1553,48 @@ func generateResolverImplementationStruct() []ast.Decl {
 				},
 			},
 		},
+		&ast.GenDecl{
+			Tok: token.TYPE,
+			Specs: []ast.Spec{
+				&ast.TypeSpec{
+					Name: ast.NewIdent("ResolverOption"),
+					Type: &ast.FuncType{
+						Params: &ast.FieldList{
+							List: []*ast.Field{
+								{Type: &ast.StarExpr{X: ast.NewIdent("resolver")}},
+							},
+						},
+					},
+				},
+			},
+		},
+		generateResolverOptionFuncDecl("WithCache", "cache", &ast.StarExpr{
+			X: &ast.SelectorExpr{
+				X:   ast.NewIdent("executor"),
+				Sel: ast.NewIdent("Cache"),
+			},
+		}),
+		generateResolverOptionFuncDecl("WithPersistedQueryStore", "persistedQueryStore", &ast.SelectorExpr{
+			X:   ast.NewIdent("executor"),
+			Sel: ast.NewIdent("PersistedQueryStore"),
+		}),
+		generateResolverOptionFuncDecl("WithTrustedDocuments", "trustedDocuments", &ast.StarExpr{
+			X: &ast.SelectorExpr{
+				X:   ast.NewIdent("executor"),
+				Sel: ast.NewIdent("TrustedDocuments"),
+			},
+		}),
 		&ast.FuncDecl{
 			Name: ast.NewIdent("NewResolver"),
 			Type: &ast.FuncType{
+				Params: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Names: []*ast.Ident{ast.NewIdent("opts")},
+							Type:  &ast.Ellipsis{Elt: ast.NewIdent("ResolverOption")},
+						},
+					},
+				},
 				Results: &ast.FieldList{
 					List: []*ast.Field{
 						{
@@ -2300,8 +1607,19 @@ func generateResolverImplementationStruct() []ast.Decl {
 			},
 			Body: &ast.BlockStmt{
 				List: []ast.Stmt{
-					&ast.ReturnStmt{
-						Results: []ast.Expr{
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{ast.NewIdent("s")},
+						Tok: token.DEFINE,
+						Rhs: []ast.Expr{
+							&ast.CallExpr{
+								Fun: ast.NewIdent("newSchema"),
+							},
+						},
+					},
+					&ast.AssignStmt{
+						Lhs: []ast.Expr{ast.NewIdent("r")},
+						Tok: token.DEFINE,
+						Rhs: []ast.Expr{
 							&ast.CompositeLit{
 								Type: ast.NewIdent("&resolver"),
 								Elts: []ast.Expr{
@@ -2313,16 +1631,109 @@ func generateResolverImplementationStruct() []ast.Decl {
 										},
 									},
 									&ast.KeyValueExpr{
-										Key: ast.NewIdent("pool"),
+										Key: ast.NewIdent("cache"),
+										Value: &ast.CallExpr{
+											Fun: &ast.SelectorExpr{
+												X:   ast.NewIdent("executor"),
+												Sel: ast.NewIdent("NewCache"),
+											},
+										},
+									},
+									&ast.KeyValueExpr{
+										Key: ast.NewIdent("persistedQueryStore"),
+										Value: &ast.CallExpr{
+											Fun: &ast.SelectorExpr{
+												X:   ast.NewIdent("executor"),
+												Sel: ast.NewIdent("NewInMemoryPersistedQueryStore"),
+											},
+										},
+									},
+									&ast.KeyValueExpr{
+										Key:   ast.NewIdent("validator"),
+										Value: ast.NewIdent("validator.NewValidator(s, query.NewParserWithLexer())"),
+									},
+									&ast.KeyValueExpr{
+										Key: ast.NewIdent("introspection"),
 										Value: &ast.CallExpr{
 											Fun: &ast.SelectorExpr{
 												X:   ast.NewIdent("executor"),
-												Sel: ast.NewIdent("NewPool"),
+												Sel: ast.NewIdent("NewIntrospection"),
 											},
+											Args: []ast.Expr{ast.NewIdent("s")},
+										},
+									},
+								},
+							},
+						},
+					},
+					&ast.RangeStmt{
+						Key:   ast.NewIdent("_"),
+						Value: ast.NewIdent("opt"),
+						Tok:   token.DEFINE,
+						X:     ast.NewIdent("opts"),
+						Body: &ast.BlockStmt{
+							List: []ast.Stmt{
+								&ast.ExprStmt{
+									X: &ast.CallExpr{
+										Fun:  ast.NewIdent("opt"),
+										Args: []ast.Expr{ast.NewIdent("r")},
+									},
+								},
+							},
+						},
+					},
+					&ast.ReturnStmt{
+						Results: []ast.Expr{ast.NewIdent("r")},
+					},
+				},
+			},
+		},
+	}
+}
+
+// generateResolverOptionFuncDecl generates an option of NewResolver replacing the default value of a field of the resolver.
+func generateResolverOptionFuncDecl(name, field string, typ ast.Expr) *ast.FuncDecl {
+	return &ast.FuncDecl{
+		Name: ast.NewIdent(name),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent(field)},
+						Type:  typ,
+					},
+				},
+			},
+			Results: &ast.FieldList{
+				List: []*ast.Field{
+					{Type: ast.NewIdent("ResolverOption")},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						&ast.FuncLit{
+							Type: &ast.FuncType{
+								Params: &ast.FieldList{
+									List: []*ast.Field{
+										{
+											Names: []*ast.Ident{ast.NewIdent("r")},
+											Type:  &ast.StarExpr{X: ast.NewIdent("resolver")},
 										},
 									},
 								},
 							},
+							Body: &ast.BlockStmt{
+								List: []ast.Stmt{
+									&ast.AssignStmt{
+										Lhs: []ast.Expr{ast.NewIdent("r." + field)},
+										Tok: token.ASSIGN,
+										Rhs: []ast.Expr{ast.NewIdent(field)},
+									},
+								},
+							},
 						},
 					},
 				},
@@ -2379,6 +1790,15 @@ func generateResolverImplementation(typePrefix string, fields schema.FieldDefini
 	return decls
 }
 
+func generateSubscriptionResolverImplementation(typePrefix string, fields schema.FieldDefinitions, indexes *schema.Indexes) []ast.Decl {
+	decls := generateResolverImplementation(typePrefix, fields, indexes)
+	for i, decl := range decls {
+		decl.(*ast.FuncDecl).Type.Results = generateSubscriptionResolverReturns(typePrefix, fields[i], indexes)
+	}
+
+	return decls
+}
+
 var fieldsIntrospectionFieldDefinition = &schema.FieldDefinition{
 	Name: []byte("__fields"),
 	Arguments: schema.ArgumentDefinitions{
@@ -2531,11 +1951,7 @@ func generateCaseAssignStmts(arg *schema.ArgumentDefinition, indexes *schema.Ind
 	caseSelector := "ValueParserLiteral"
 	var body []ast.Stmt = generateValueParserLiteralCaseAssignStmts(arg, indexes)
 
-	scalar, isScalar := indexes.ScalarIndex[string(arg.Type.Name)]
-	if isScalar {
-		caseSelector = "ValueParserLiteral"
-		body = generateScalarValueParserLiteralCaseAssignStmts(arg, scalar, typePrefix)
-	}
+	_, isScalar := indexes.ScalarIndex[string(arg.Type.Name)]
 
 	enum, isEnum := indexes.EnumIndex[string(arg.Type.Name)]
 	if isEnum {
@@ -2630,41 +2046,6 @@ func generateValueParserLiteralCaseAssignStmts(arg *schema.ArgumentDefinition, i
 	}
 }
 
-func generateScalarValueParserLiteralCaseAssignStmts(arg *schema.ArgumentDefinition, scalar *schema.ScalarDefinition, prefixType string) []ast.Stmt {
-	if arg.Type.Nullable {
-		return []ast.Stmt{
-			&ast.ExprStmt{
-				X: &ast.CallExpr{
-					Fun: &ast.SelectorExpr{
-						X:   ast.NewIdent("json"),
-						Sel: ast.NewIdent("Unmarshal"),
-					},
-					Args: []ast.Expr{
-						&ast.SelectorExpr{
-							X:   ast.NewIdent("val"),
-							Sel: ast.NewIdent("Value"),
-						},
-						&ast.UnaryExpr{
-							Op: token.AND,
-							X:  ast.NewIdent(string(arg.Name)),
-						},
-					},
-				},
-			},
-		}
-	}
-
-	return []ast.Stmt{
-		&ast.AssignStmt{
-			Tok: token.ASSIGN,
-			Lhs: []ast.Expr{
-				ast.NewIdent(string(arg.Name)),
-			},
-			Rhs: []ast.Expr{},
-		},
-	}
-}
-
 func generateEnumValueParserLiteralCaseAssignStmts(arg *schema.ArgumentDefinition, enum *schema.EnumDefinition, prefixType string) []ast.Stmt {
 	if arg.Type.Nullable {
 		return []ast.Stmt{
@@ -2995,12 +2376,37 @@ func generateArgumentValue(field *schema.FieldDefinition) ast.Expr {
 	}
 }
 
+// hasCustomScalar reports whether the named type is a custom scalar or an input object containing one.
+func hasCustomScalar(name string, indexes *schema.Indexes, visited map[string]struct{}) bool {
+	if _, ok := indexes.ScalarIndex[name]; ok {
+		return true
+	}
+
+	input, ok := indexes.InputIndex[name]
+	if !ok {
+		return false
+	}
+
+	if _, ok := visited[name]; ok {
+		return false
+	}
+	visited[name] = struct{}{}
+
+	for _, field := range input.Fields {
+		if hasCustomScalar(string(field.Type.GetRootType().Name), indexes, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func generateDefaultValueAssignmentStmts(args schema.ArgumentDefinitions, indexes *schema.Indexes, typePrefix string) []ast.Stmt {
 	stmts := make([]ast.Stmt, 0, len(args))
 
 	argsCaseStmts := make([]ast.Stmt, 0, len(args))
 
-	returnExprs := make([]ast.Expr, 0, len(args)+1)
+	returnExprs := make([]ast.Expr, 0, len(args))
 	for _, arg := range args {
 		returnExprs = append(returnExprs, ast.NewIdent(string(arg.Name)))
 	}
@@ -3012,7 +2418,7 @@ func generateDefaultValueAssignmentStmts(args schema.ArgumentDefinitions, indexe
 			stmts = append(stmts, generateAssignDefaultValueStmt(arg, indexes))
 		}
 
-		// default is object
+		// variables are coerced by executor.CoerceVariableValues before execution, so they are decoded as JSON
 		var bindStmt ast.Stmt = &ast.IfStmt{
 			Init: &ast.AssignStmt{
 				Tok: token.DEFINE,
@@ -3042,121 +2448,78 @@ func generateDefaultValueAssignmentStmts(args schema.ArgumentDefinitions, indexe
 			},
 			Body: &ast.BlockStmt{
 				List: []ast.Stmt{
-					&ast.ReturnStmt{
-						Results: append(returnExprs, ast.NewIdent("err")),
-					},
+					&ast.ReturnStmt{
+						Results: append(returnExprs, ast.NewIdent("err")),
+					},
+				},
+			},
+		}
+
+		var literalStmt ast.Stmt = &ast.TypeSwitchStmt{
+			Assign: &ast.AssignStmt{
+				Tok: token.DEFINE,
+				Lhs: []ast.Expr{
+					ast.NewIdent("val"),
+				},
+				Rhs: []ast.Expr{
+					&ast.TypeAssertExpr{
+						X:    ast.NewIdent("ast"),
+						Type: ast.NewIdent("type"),
+					},
+				},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					generateValueCaseAssignStmt(arg, indexes, typePrefix),
 				},
 			},
 		}
 
-		if arg.Type.IsBoolean() {
-			var rh ast.Expr = &ast.BinaryExpr{
-				X: &ast.CallExpr{
-					Fun: ast.NewIdent("string"),
-					Args: []ast.Expr{
-						&ast.SliceExpr{
-							X:   ast.NewIdent("rawJSONValue"),
-							Low: ast.NewIdent("1"),
-							High: &ast.BinaryExpr{
-								X: &ast.CallExpr{
-									Fun: ast.NewIdent("len"),
-									Args: []ast.Expr{
-										ast.NewIdent("rawJSONValue"),
-									},
-								},
-								Op: token.SUB,
-								Y:  ast.NewIdent("1"),
-							},
-						},
+		// custom scalars decode literals through their UnmarshalJSON
+		if hasCustomScalar(string(arg.Type.GetRootType().Name), indexes, map[string]struct{}{}) {
+			literalStmt = &ast.IfStmt{
+				Init: &ast.AssignStmt{
+					Tok: token.DEFINE,
+					Lhs: []ast.Expr{
+						ast.NewIdent("err"),
 					},
-				},
-				Op: token.EQL,
-				Y: &ast.BasicLit{
-					Kind:  token.STRING,
-					Value: `"true"`,
-				},
-			}
-
-			if arg.Type.Nullable {
-				rh = generateBoolPointerExpr(rh)
-			}
-
-			bindStmt = &ast.AssignStmt{
-				Tok: token.ASSIGN,
-				Lhs: []ast.Expr{ast.NewIdent(string(arg.Name))},
-				Rhs: []ast.Expr{
-					rh,
-				},
-			}
-		}
-
-		if arg.Type.IsString() || arg.Type.IsID() {
-			var rh ast.Expr = &ast.CallExpr{
-				Fun: ast.NewIdent("string"),
-				Args: []ast.Expr{
-					&ast.SliceExpr{
-						X:   ast.NewIdent("rawJSONValue"),
-						Low: ast.NewIdent("1"),
-						High: &ast.BinaryExpr{
-							X: &ast.CallExpr{
-								Fun: ast.NewIdent("len"),
-								Args: []ast.Expr{
-									ast.NewIdent("rawJSONValue"),
+					Rhs: []ast.Expr{
+						&ast.CallExpr{
+							Fun: &ast.SelectorExpr{
+								X:   ast.NewIdent("goliteql"),
+								Sel: ast.NewIdent("UnmarshalValue"),
+							},
+							Args: []ast.Expr{
+								ast.NewIdent("ast"),
+								&ast.UnaryExpr{
+									Op: token.AND,
+									X:  ast.NewIdent(string(arg.Name)),
 								},
 							},
-							Op: token.SUB,
-							Y:  ast.NewIdent("1"),
 						},
 					},
 				},
-			}
-
-			if arg.Type.Nullable {
-				rh = generateStringPointerExpr(rh)
-			}
-
-			bindStmt = &ast.AssignStmt{
-				Tok: token.ASSIGN,
-				Lhs: []ast.Expr{ast.NewIdent(string(arg.Name))},
-				Rhs: []ast.Expr{
-					rh,
-				},
-			}
-		}
-
-		if arg.Type.IsInt() {
-			bindStmt = &ast.AssignStmt{
-				Tok: token.ASSIGN,
-				Lhs: []ast.Expr{
-					ast.NewIdent(string(arg.Name)),
-					ast.NewIdent("_"),
+				Cond: &ast.BinaryExpr{
+					X:  ast.NewIdent("err"),
+					Op: token.NEQ,
+					Y:  ast.NewIdent("nil"),
 				},
-				Rhs: []ast.Expr{
-					&ast.CallExpr{
-						Fun: &ast.SelectorExpr{
-							X:   ast.NewIdent("strconv"),
-							Sel: ast.NewIdent("Atoi"),
-						},
-						Args: []ast.Expr{
-							&ast.CallExpr{
-								Fun: ast.NewIdent("string"),
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ReturnStmt{
+							Results: append(returnExprs, &ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   ast.NewIdent("fmt"),
+									Sel: ast.NewIdent("Errorf"),
+								},
 								Args: []ast.Expr{
-									&ast.SliceExpr{
-										X:   ast.NewIdent("rawJSONValue"),
-										Low: ast.NewIdent("1"),
-										High: &ast.BinaryExpr{
-											X: &ast.CallExpr{
-												Fun: ast.NewIdent("len"),
-												Args: []ast.Expr{
-													ast.NewIdent("rawJSONValue"),
-												},
-											},
-											Op: token.SUB,
-											Y:  ast.NewIdent("1"),
-										},
+									&ast.BasicLit{
+										Kind:  token.STRING,
+										Value: fmt.Sprintf(`"argument %s: %%w"`, string(arg.Name)),
 									},
+									ast.NewIdent("err"),
 								},
-							},
+							}),
 						},
 					},
 				},
@@ -3221,25 +2584,7 @@ func generateDefaultValueAssignmentStmts(args schema.ArgumentDefinitions, indexe
 									},
 								},
 							},
-							&ast.TypeSwitchStmt{
-								Assign: &ast.AssignStmt{
-									Tok: token.DEFINE,
-									Lhs: []ast.Expr{
-										ast.NewIdent("val"),
-									},
-									Rhs: []ast.Expr{
-										&ast.TypeAssertExpr{
-											X:    ast.NewIdent("ast"),
-											Type: ast.NewIdent("type"),
-										},
-									},
-								},
-								Body: &ast.BlockStmt{
-									List: []ast.Stmt{
-										generateValueCaseAssignStmt(arg, indexes, typePrefix),
-									},
-								},
-							},
+							literalStmt,
 						},
 					},
 					Else: &ast.BlockStmt{
@@ -3267,21 +2612,11 @@ func generateDefaultValueAssignmentStmts(args schema.ArgumentDefinitions, indexe
 									Op: token.NOT,
 									X:  ast.NewIdent("ok"),
 								},
+								// an omitted variable leaves the argument unset, so the default value is kept
 								Body: &ast.BlockStmt{
 									List: []ast.Stmt{
-										&ast.ReturnStmt{
-											Results: append(returnExprs, &ast.CallExpr{
-												Fun: &ast.SelectorExpr{
-													X:   ast.NewIdent("fmt"),
-													Sel: ast.NewIdent("Errorf"),
-												},
-												Args: []ast.Expr{
-													&ast.BasicLit{
-														Kind:  token.STRING,
-														Value: fmt.Sprintf(`"argument %s is not provided"`, string(arg.Name)),
-													},
-												},
-											}),
+										&ast.BranchStmt{
+											Tok: token.CONTINUE,
 										},
 									},
 								},
@@ -3356,7 +2691,11 @@ func generateDefaultValueExpr(arg *schema.ArgumentDefinition, indexes *schema.In
 
 	if arg.Type.IsString() {
 		if arg.Type.Nullable {
-			return generateStringPointerAST(string(arg.Default))
+			// the default of a string keeps its quotes, so it is already a Go string literal
+			return generateStringPointerExpr(&ast.BasicLit{
+				Kind:  token.STRING,
+				Value: string(arg.Default),
+			})
 		} else {
 			return &ast.BasicLit{
 				Kind:  token.STRING,
@@ -3367,7 +2706,10 @@ func generateDefaultValueExpr(arg *schema.ArgumentDefinition, indexes *schema.In
 
 	if arg.Type.IsInt() {
 		if arg.Type.Nullable {
-			return generateIntPointerAST(string(arg.Default))
+			return generateIntPointerExpr(&ast.BasicLit{
+				Kind:  token.INT,
+				Value: string(arg.Default),
+			})
 		} else {
 			return &ast.BasicLit{
 				Kind:  token.STRING,
@@ -3389,7 +2731,10 @@ func generateDefaultValueExpr(arg *schema.ArgumentDefinition, indexes *schema.In
 
 	if arg.Type.IsID() {
 		if arg.Type.Nullable {
-			return generateStringPointerAST(string(arg.Default))
+			return generateStringPointerExpr(&ast.BasicLit{
+				Kind:  token.STRING,
+				Value: string(arg.Default),
+			})
 		} else {
 			return &ast.BasicLit{
 				Kind:  token.STRING,
@@ -3402,29 +2747,6 @@ func generateDefaultValueExpr(arg *schema.ArgumentDefinition, indexes *schema.In
 	return nil
 }
 
-func generateIntPointerAST(value string) ast.Expr {
-	return &ast.UnaryExpr{
-		Op: token.AND,
-		X: &ast.IndexExpr{
-			X: &ast.CompositeLit{
-				Type: &ast.ArrayType{
-					Elt: ast.NewIdent("int"),
-				},
-				Elts: []ast.Expr{
-					&ast.BasicLit{
-						Kind:  token.STRING,
-						Value: fmt.Sprintf("\\%q\\", value),
-					},
-				},
-			},
-			Index: &ast.BasicLit{
-				Kind:  token.INT,
-				Value: "0",
-			},
-		},
-	}
-}
-
 func generateIntPointerExpr(value ast.Expr) ast.Expr {
 	return &ast.UnaryExpr{
 		Op: token.AND,
@@ -3501,223 +2823,3 @@ func generateVarSpecs(typePrefix string, args schema.ArgumentDefinitions) []ast.
 
 	return specs
 }
-
-func generateOperationResponseStructDecls(schema *schema.Schema) []ast.Decl {
-	var decls []ast.Decl
-
-	for _, typeDefinition := range schema.Types {
-		if typeDefinition.IsIntrospection() {
-			continue
-		}
-
-		if typeDefinition.IsPrimitive() {
-			continue
-		}
-
-		decls = append(decls, generateResponseStructFromField(typeDefinition, schema.Unions))
-		decls = append(decls, generateResponseStructAliasFromTypeDefinition(typeDefinition))
-		decls = append(decls, generateResponseStructMarshalJSONFromTypeDefinition(typeDefinition))
-	}
-
-	for _, interfaceDefinition := range schema.Interfaces {
-		decls = append(decls, generateResponseInterfaceFromField(interfaceDefinition))
-	}
-
-	for _, unionDefinition := range schema.Unions {
-		decls = append(decls, generateResponseUnionFromDefinition(unionDefinition))
-	}
-
-	return decls
-}
-
-func generateResponseStructFromField(typeDefinition *schema.TypeDefinition, unionDefinitions schema.UnionDefinitions) ast.Decl {
-	return &ast.GenDecl{
-		Tok: token.TYPE,
-		Specs: []ast.Spec{
-			&ast.TypeSpec{
-				Name: ast.NewIdent(fmt.Sprintf("%sResponse", typeDefinition.Name)),
-				Type: &ast.StructType{
-					Fields: &ast.FieldList{
-						List: generateAllPointerFieldStructFromField(typeDefinition, unionDefinitions),
-					},
-				},
-			},
-		},
-	}
-}
-
-func generateAllPointerFieldStructFromField(typeDefinition *schema.TypeDefinition, unionDefinitions schema.UnionDefinitions) []*ast.Field {
-	fields := make([]*ast.Field, 0, len(typeDefinition.Fields))
-
-	for _, field := range typeDefinition.Fields {
-		var typeExpr ast.Expr = &ast.SelectorExpr{
-			X:   ast.NewIdent("executor"),
-			Sel: ast.NewIdent("Nullable"),
-		}
-		fields = append(fields, &ast.Field{
-			Names: []*ast.Ident{
-				ast.NewIdent(toUpperCase(string(field.Name))),
-			},
-			Type: typeExpr,
-			Tag: &ast.BasicLit{
-				Kind:  token.STRING,
-				Value: fmt.Sprintf("`json:\"%s,omitempty\"`", string(field.Name)),
-			},
-		})
-	}
-
-	fields = append(fields, &ast.Field{
-		Names: []*ast.Ident{
-			ast.NewIdent("GraphQLTypeName"),
-		},
-		Type: &ast.SelectorExpr{
-			X:   ast.NewIdent("executor"),
-			Sel: ast.NewIdent("Nullable"),
-		},
-		Tag: &ast.BasicLit{
-			Kind:  token.STRING,
-			Value: "`json:\"__typename,omitempty\"`",
-		},
-	})
-
-	if len(typeDefinition.Interfaces) > 0 {
-		fields = append(fields, &ast.Field{
-			Type: &ast.BasicLit{},
-		})
-	}
-
-	for _, i := range typeDefinition.Interfaces {
-		fields = append(fields, &ast.Field{
-			Type: ast.NewIdent(fmt.Sprintf("%sResponse", i)),
-			Tag: &ast.BasicLit{
-				Kind:  token.STRING,
-				Value: "`json:\"-\"`",
-			},
-		})
-	}
-
-	for _, union := range unionDefinitions {
-		for _, field := range union.Types {
-			if bytes.Equal(field, typeDefinition.Name) {
-				fields = append(fields, &ast.Field{
-					Type: ast.NewIdent(fmt.Sprintf("%sResponse", union.Name)),
-					Tag: &ast.BasicLit{
-						Kind:  token.STRING,
-						Value: "`json:\"-\"`",
-					},
-				})
-			}
-		}
-	}
-
-	return fields
-}
-
-func generateResponseStructMarshalJSONFromTypeDefinition(typeDefinition *schema.TypeDefinition) ast.Decl {
-	aliasTypeName := fmt.Sprintf("Alias%sResponse", typeDefinition.Name)
-	recvTypeName := fmt.Sprintf("%sResponse", typeDefinition.Name)
-	return &ast.FuncDecl{
-		Name: ast.NewIdent("MarshalJSON"),
-		Recv: &ast.FieldList{
-			List: []*ast.Field{
-				{
-					Names: []*ast.Ident{
-						ast.NewIdent("o"),
-					},
-					Type: &ast.StarExpr{ // レシーバは *XxxResponse 型でよいはず
-						X: ast.NewIdent(recvTypeName),
-					},
-				},
-			},
-		},
-		Type: &ast.FuncType{
-			Params: &ast.FieldList{}, // 引数なし
-			Results: &ast.FieldList{
-				List: []*ast.Field{
-					{
-						Type: &ast.ArrayType{
-							Elt: ast.NewIdent("byte"),
-						},
-					},
-					{
-						Type: ast.NewIdent("error"),
-					},
-				},
-			},
-		},
-		Body: &ast.BlockStmt{
-			List: []ast.Stmt{
-				&ast.ReturnStmt{
-					Results: []ast.Expr{
-						&ast.CallExpr{
-							Fun: &ast.SelectorExpr{
-								X:   ast.NewIdent("json"),
-								Sel: ast.NewIdent("Marshal"),
-							},
-							Args: []ast.Expr{
-								// (*AliasXxxResponse)(o)
-								&ast.CallExpr{
-									Fun: &ast.ParenExpr{
-										X: &ast.StarExpr{
-											X: ast.NewIdent(aliasTypeName),
-										},
-									},
-									Args: []ast.Expr{
-										ast.NewIdent("o"),
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-		},
-	}
-}
-
-func generateResponseStructAliasFromTypeDefinition(typeDefinition *schema.TypeDefinition) ast.Decl {
-	aliasTypeName := fmt.Sprintf("Alias%sResponse", typeDefinition.Name)
-	recvTypeName := fmt.Sprintf("%sResponse", typeDefinition.Name)
-
-	return &ast.GenDecl{
-		Tok: token.TYPE,
-		Specs: []ast.Spec{
-			&ast.TypeSpec{
-				Name: ast.NewIdent(aliasTypeName),
-				Type: ast.NewIdent(recvTypeName),
-			},
-		},
-	}
-}
-
-func generateResponseInterfaceFromField(interfaceDefinition *schema.InterfaceDefinition) ast.Decl {
-	return &ast.GenDecl{
-		Tok: token.TYPE,
-		Specs: []ast.Spec{
-			&ast.TypeSpec{
-				Name: ast.NewIdent(fmt.Sprintf("%sResponse", interfaceDefinition.Name)),
-				Type: &ast.InterfaceType{
-					Methods: &ast.FieldList{
-						List: []*ast.Field{},
-					},
-				},
-			},
-		},
-	}
-}
-
-func generateResponseUnionFromDefinition(unionDefinitions *schema.UnionDefinition) ast.Decl {
-	return &ast.GenDecl{
-		Tok: token.TYPE,
-		Specs: []ast.Spec{
-			&ast.TypeSpec{
-				Name: ast.NewIdent(fmt.Sprintf("%sResponse", unionDefinitions.Name)),
-				Type: &ast.InterfaceType{
-					Methods: &ast.FieldList{
-						List: []*ast.Field{},
-					},
-				},
-			},
-		},
-	}
-}
@@ -1,23 +1,26 @@
 package generator
 
 import (
+	"bytes"
 	"fmt"
 	"go/ast"
 	"go/token"
+	"sort"
+	"strconv"
 
 	"github.com/n9te9/goliteql/schema"
 )
 
-func generateApplyResponseFuncDeclFromOperationDefinition(definition *schema.OperationDefinition, typePrefix string, indexes *schema.Indexes) []ast.Decl {
+func generateApplyResponseFuncDeclFromOperationDefinition(definition *schema.OperationDefinition, typeName []byte, typePrefix string, indexes *schema.Indexes) []ast.Decl {
 	ret := make([]ast.Decl, 0, len(definition.Fields))
 	for _, field := range definition.Fields {
-		ret = append(ret, generateApplyResponseFuncDeclFromFieldDefinition(field, typePrefix, indexes))
+		ret = append(ret, generateApplyResponseFuncDeclFromFieldDefinition(field, typeName, typePrefix, indexes))
 	}
 
 	return ret
 }
 
-func generateApplyResponseFuncDeclFromFieldDefinition(fieldDefinition *schema.FieldDefinition, typePrefix string, indexes *schema.Indexes) ast.Decl {
+func generateApplyResponseFuncDeclFromFieldDefinition(fieldDefinition *schema.FieldDefinition, typeName []byte, typePrefix string, indexes *schema.Indexes) ast.Decl {
 	return &ast.FuncDecl{
 		Name: ast.NewIdent(fmt.Sprintf("apply%sQueryResponse", fieldDefinition.Name)),
 		Recv: &ast.FieldList{
@@ -31,6 +34,13 @@ func generateApplyResponseFuncDeclFromFieldDefinition(fieldDefinition *schema.Fi
 		Type: &ast.FuncType{
 			Params: &ast.FieldList{
 				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("ctx")},
+						Type: &ast.SelectorExpr{
+							X:   ast.NewIdent("context"),
+							Sel: ast.NewIdent("Context"),
+						},
+					},
 					{
 						Names: []*ast.Ident{ast.NewIdent("resolverRet")},
 						Type:  generateTypeExprFromFieldTypeForReturn(typePrefix, fieldDefinition.Type, indexes),
@@ -49,7 +59,10 @@ func generateApplyResponseFuncDeclFromFieldDefinition(fieldDefinition *schema.Fi
 			Results: &ast.FieldList{
 				List: []*ast.Field{
 					{
-						Type: generateResponseNullable(fieldDefinition.Type),
+						Type: &ast.SelectorExpr{
+							X:   ast.NewIdent("executor"),
+							Sel: ast.NewIdent("Nullable"),
+						},
 					},
 					{
 						Type: ast.NewIdent("error"),
@@ -58,24 +71,11 @@ func generateApplyResponseFuncDeclFromFieldDefinition(fieldDefinition *schema.Fi
 			},
 		},
 		Body: &ast.BlockStmt{
-			List: generateFieldTypeApplyBodyStmts(fieldDefinition.Type, indexes, typePrefix),
+			List: generateCompleteValueStmts(ast.NewIdent("resolverRet"), fieldDefinition.Type, indexes, typePrefix, ast.NewIdent("node"), fmt.Sprintf("%s.%s", typeName, fieldDefinition.Name), false, 0),
 		},
 	}
 }
 
-func generateResponseNullable(fieldType *schema.FieldType) ast.Expr {
-	if fieldType.IsList {
-		return &ast.ArrayType{
-			Elt: generateResponseNullable(fieldType.ListType),
-		}
-	}
-
-	return &ast.SelectorExpr{
-		X:   ast.NewIdent("executor"),
-		Sel: ast.NewIdent("Nullable"),
-	}
-}
-
 type Responsible interface {
 	*schema.TypeDefinition |
 		*schema.InterfaceDefinition |
@@ -122,6 +122,13 @@ func generateTypeApplyResponseFuncDecl(definition *schema.TypeDefinition, indexe
 		Type: &ast.FuncType{
 			Params: &ast.FieldList{
 				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("ctx")},
+						Type: &ast.SelectorExpr{
+							X:   ast.NewIdent("context"),
+							Sel: ast.NewIdent("Context"),
+						},
+					},
 					{
 						Names: []*ast.Ident{ast.NewIdent("resolverRet")},
 						Type: &ast.SelectorExpr{
@@ -155,74 +162,100 @@ func generateTypeApplyResponseFuncDecl(definition *schema.TypeDefinition, indexe
 			},
 		},
 		Body: &ast.BlockStmt{
-			List: generateTypeApplyResponseFuncBody(definition, indexes),
+			List: generateObjectDirectivesStmts(definition, indexes, typePrefix, generateTypeApplyResponseFuncBody(definition, indexes, typePrefix)),
 		},
 	}
 }
 
-func generateTypeApplyResponseFuncBody(definition *schema.TypeDefinition, indexes *schema.Indexes) []ast.Stmt {
+// generateObjectDirectivesStmts completes an object through the directives applied to its type.
+func generateObjectDirectivesStmts(definition *schema.TypeDefinition, indexes *schema.Indexes, typePrefix string, body []ast.Stmt) []ast.Stmt {
+	directives := executableDirectivesAt(definition.Directives, objectLocation, indexes)
+	if len(directives) == 0 {
+		return body
+	}
+
 	return []ast.Stmt{
+		&ast.ReturnStmt{
+			Results: []ast.Expr{
+				generateWithDirectivesExpr(
+					&ast.SelectorExpr{
+						X:   ast.NewIdent("executor"),
+						Sel: ast.NewIdent("Nullable"),
+					},
+					body,
+					generateDirectiveFuncExprs(directives, &ast.UnaryExpr{Op: token.AND, X: ast.NewIdent("resolverRet")}, typePrefix, indexes),
+				),
+			},
+		},
+	}
+}
+
+func generateTypeApplyResponseFuncBody(definition *schema.TypeDefinition, indexes *schema.Indexes, typePrefix string) []ast.Stmt {
+	stmts := []ast.Stmt{
 		&ast.AssignStmt{
 			Lhs: []ast.Expr{
 				ast.NewIdent("ret"),
 			},
 			Tok: token.DEFINE,
 			Rhs: []ast.Expr{
-				&ast.CompositeLit{
-					Type: ast.NewIdent(fmt.Sprintf("%sResponse", definition.Name)),
+				&ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X:   ast.NewIdent("executor"),
+						Sel: ast.NewIdent("NewObject"),
+					},
 				},
 			},
 		},
-		&ast.RangeStmt{
-			Key:   ast.NewIdent("_"),
-			Value: ast.NewIdent("child"),
-			Tok:   token.DEFINE,
-			X: &ast.SelectorExpr{
-				X:   ast.NewIdent("node"),
-				Sel: ast.NewIdent("Children"),
+	}
+
+	var fieldsExpr ast.Expr = &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent("node"),
+			Sel: ast.NewIdent("CollectFields"),
+		},
+		Args: generateCollectFieldsArgs(definition, indexes),
+	}
+	keyExpr := ast.NewIdent("_")
+
+	// the resolvers of the selected fields run before the fields are completed in order, so that sibling loads are batched together
+	if resolverFields := forceResolverFields(definition); len(resolverFields) > 0 {
+		stmts = append(stmts,
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{ast.NewIdent("fields")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{fieldsExpr},
 			},
-			Body: &ast.BlockStmt{
-				List: []ast.Stmt{
-					&ast.IfStmt{
-						Cond: &ast.CallExpr{
-							Fun: &ast.SelectorExpr{X: ast.NewIdent("node"), Sel: ast.NewIdent("HasFragment")},
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{ast.NewIdent("resolved")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{
+					&ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X:   ast.NewIdent("executor"),
+							Sel: ast.NewIdent("ResolveFields"),
 						},
-						Body: &ast.BlockStmt{
-							List: []ast.Stmt{
-								&ast.IfStmt{
-									Cond: &ast.BinaryExpr{
-										X: &ast.CallExpr{
-											Fun: &ast.SelectorExpr{X: ast.NewIdent("node"), Sel: ast.NewIdent("FragmentType")},
-										},
-										Op: token.NEQ,
-										Y:  ast.NewIdent(fmt.Sprintf("%q", definition.Name)),
-									},
-									Body: &ast.BlockStmt{
-										List: []ast.Stmt{
-											&ast.ReturnStmt{
-												Results: []ast.Expr{
-													ast.NewIdent("nil"),
-													&ast.CallExpr{
-														Fun: &ast.SelectorExpr{
-															X:   ast.NewIdent("fmt"),
-															Sel: ast.NewIdent("Errorf"),
-														},
-														Args: []ast.Expr{
-															ast.NewIdent(fmt.Sprintf(`"node type mismatch: expected %s"`, definition.Name)),
-														},
-													},
-												},
-											},
-										},
-									},
-								},
-								generateFragmentApplyResponseStmt(definition, indexes),
-							},
+						Args: []ast.Expr{
+							ast.NewIdent("ctx"),
+							ast.NewIdent("fields"),
+							generateFieldResolversExpr(definition, resolverFields, typePrefix, indexes),
 						},
 					},
-					generateFieldApplyResponseStmts(definition, indexes),
 				},
 			},
+		)
+		fieldsExpr = ast.NewIdent("fields")
+		keyExpr = ast.NewIdent("i")
+	}
+
+	return append(stmts,
+		&ast.RangeStmt{
+			Key:   keyExpr,
+			Value: ast.NewIdent("child"),
+			Tok:   token.DEFINE,
+			X:     fieldsExpr,
+			Body: &ast.BlockStmt{
+				List: generateFieldLoopBodyStmts(definition, indexes, typePrefix),
+			},
 		},
 		&ast.ReturnStmt{
 			Results: []ast.Expr{
@@ -238,694 +271,521 @@ func generateTypeApplyResponseFuncBody(definition *schema.TypeDefinition, indexe
 				ast.NewIdent("nil"),
 			},
 		},
-	}
+	)
 }
 
-func generateFragmentApplyResponseStmt(definition *schema.TypeDefinition, indexes *schema.Indexes) ast.Stmt {
-	return &ast.RangeStmt{
-		Key:   ast.NewIdent("_"),
-		Value: ast.NewIdent("fragmentChild"),
-		Tok:   token.DEFINE,
-		X: &ast.SelectorExpr{
-			X:   ast.NewIdent("child"),
-			Sel: ast.NewIdent("Children"),
-		},
-		Body: &ast.BlockStmt{
-			List: []ast.Stmt{
-				&ast.SwitchStmt{
-					Tag: &ast.SelectorExpr{
-						X:   ast.NewIdent("fragmentChild"),
-						Sel: ast.NewIdent("Name"),
-					},
-					Body: &ast.BlockStmt{
-						List: generateCaseStmtsForTypeDefinition(definition, indexes, ast.NewIdent("fragmentChild")),
+// generateFieldLoopBodyStmts extends the response path with the selected field, so that its errors are reported at the field.
+// Objects with only plain leaf fields without directives can not fail and skip it.
+func generateFieldLoopBodyStmts(definition *schema.TypeDefinition, indexes *schema.Indexes, typePrefix string) []ast.Stmt {
+	stmts := make([]ast.Stmt, 0, 2)
+	for _, field := range definition.Fields {
+		if isForceResolverField(field) || !isLeafType(field.Type, indexes) || len(executableDirectivesAt(field.Directives, fieldDefinitionLocation, indexes)) > 0 {
+			stmts = append(stmts,
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{ast.NewIdent("ctx")},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{
+						&ast.CallExpr{
+							Fun: &ast.SelectorExpr{
+								X:   ast.NewIdent("executor"),
+								Sel: ast.NewIdent("WithPath"),
+							},
+							Args: []ast.Expr{
+								ast.NewIdent("ctx"),
+								&ast.CallExpr{
+									Fun: &ast.SelectorExpr{
+										X:   ast.NewIdent("child"),
+										Sel: ast.NewIdent("ResponseKey"),
+									},
+								},
+							},
+						},
 					},
 				},
-			},
-		},
+			)
+			break
+		}
+	}
+
+	return append(stmts, generateFieldApplyResponseStmts(definition, indexes, typePrefix))
+}
+
+// generateCollectFieldsArgs returns the type names that fragments selected on an object of definition may be conditioned on.
+func generateCollectFieldsArgs(definition *schema.TypeDefinition, indexes *schema.Indexes) []ast.Expr {
+	args := []ast.Expr{
+		&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(string(definition.Name))},
+	}
+
+	for _, iface := range definition.Interfaces {
+		args = append(args, &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(string(iface))})
+	}
+
+	unionNames := make([]string, 0)
+	for name, union := range indexes.UnionIndex {
+		for _, typeName := range union.Types {
+			if bytes.Equal(typeName, definition.Name) {
+				unionNames = append(unionNames, name)
+				break
+			}
+		}
+	}
+	sort.Strings(unionNames)
+
+	for _, name := range unionNames {
+		args = append(args, &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(name)})
 	}
+
+	return args
 }
 
-func generateFieldApplyResponseStmts(definition *schema.TypeDefinition, indexes *schema.Indexes) ast.Stmt {
+func generateFieldApplyResponseStmts(definition *schema.TypeDefinition, indexes *schema.Indexes, typePrefix string) ast.Stmt {
 	return &ast.SwitchStmt{
 		Tag: &ast.SelectorExpr{
 			X:   ast.NewIdent("child"),
 			Sel: ast.NewIdent("Name"),
 		},
 		Body: &ast.BlockStmt{
-			List: generateCaseStmtsForTypeDefinition(definition, indexes, ast.NewIdent("child")),
+			List: generateCaseStmtsForTypeDefinition(definition, indexes, typePrefix, ast.NewIdent("child")),
 		},
 	}
 }
 
-func generateCaseStmtsForTypeDefinition(definition *schema.TypeDefinition, indexes *schema.Indexes, nestExpr ast.Expr) []ast.Stmt {
+func generateCaseStmtsForTypeDefinition(definition *schema.TypeDefinition, indexes *schema.Indexes, typePrefix string, nestExpr ast.Expr) []ast.Stmt {
 	ret := make([]ast.Stmt, 0, len(definition.Fields)+1)
 	ret = append(ret, &ast.CaseClause{
 		List: []ast.Expr{
 			ast.NewIdent(`"__typename"`),
 		},
 		Body: []ast.Stmt{
-			&ast.AssignStmt{
-				Lhs: []ast.Expr{
-					&ast.SelectorExpr{
-						X:   ast.NewIdent("ret"),
-						Sel: ast.NewIdent("GraphQLTypeName"),
-					},
+			generateResponseSetStmt(nestExpr, &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("executor"),
+					Sel: ast.NewIdent("NewNullable"),
 				},
-				Tok: token.ASSIGN,
-				Rhs: []ast.Expr{
-					&ast.CallExpr{
-						Fun: &ast.SelectorExpr{
-							X:   ast.NewIdent("executor"),
-							Sel: ast.NewIdent("NewNullable"),
-						},
-						Args: []ast.Expr{
-							&ast.BasicLit{
-								Kind:  token.STRING,
-								Value: fmt.Sprintf(`"%s"`, definition.Name),
-							},
-						},
+				Args: []ast.Expr{
+					&ast.BasicLit{
+						Kind:  token.STRING,
+						Value: fmt.Sprintf(`"%s"`, definition.Name),
 					},
 				},
-			},
+			}),
 		},
 	})
 
 	for _, field := range definition.Fields {
+		body := generateCaseBodyStmts(definition, field, indexes, typePrefix, nestExpr)
+		if isForceResolverField(field) {
+			body = append(generateResolvedFieldValueStmts(field, typePrefix, nestExpr), body...)
+		} else if directives := executableDirectivesAt(field.Directives, fieldDefinitionLocation, indexes); len(directives) > 0 {
+			body = append(generateFieldDirectiveStmts(field, directives, typePrefix, indexes, nestExpr), body...)
+		}
+
 		ret = append(ret, &ast.CaseClause{
 			List: []ast.Expr{
 				ast.NewIdent(fmt.Sprintf("%q", string(field.Name))),
 			},
-			Body: generateCaseBodyStmts(field, indexes, nestExpr),
+			Body: body,
 		})
 	}
 
 	return ret
 }
 
-func generateCaseBodyStmts(field *schema.FieldDefinition, indexes *schema.Indexes, nestExpr ast.Expr) []ast.Stmt {
-	stmts := make([]ast.Stmt, 0)
-
-	if field.Type.IsList {
-		stmts = append(stmts, generateAssignMakeSliceForResponse(field))
-		stmts = append(stmts, generateNestedArrayRangeStmts(field, field.Type, indexes, nestExpr, 0)...)
-		stmts = append(stmts, &ast.AssignStmt{
-			Lhs: []ast.Expr{
-				&ast.SelectorExpr{
-					X:   ast.NewIdent("ret"),
-					Sel: ast.NewIdent(toUpperCase(string(field.Name))),
-				},
+// generateResponseSetStmt sets value in the response object under the response key of the selected field node.
+func generateResponseSetStmt(nodeExpr ast.Expr, value ast.Expr) ast.Stmt {
+	return &ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("ret"),
+				Sel: ast.NewIdent("Set"),
 			},
-			Tok: token.ASSIGN,
-			Rhs: []ast.Expr{
+			Args: []ast.Expr{
 				&ast.CallExpr{
 					Fun: &ast.SelectorExpr{
-						X:   ast.NewIdent("executor"),
-						Sel: ast.NewIdent("NewNullable"),
-					},
-					Args: []ast.Expr{
-						ast.NewIdent(fmt.Sprintf("ret%s", toUpperCase(string(field.Name)))),
+						X:   nodeExpr,
+						Sel: ast.NewIdent("ResponseKey"),
 					},
 				},
+				value,
 			},
-		})
-	} else {
-		stmts = append(stmts, generateCaseRetAssignStmts(field, indexes, nestExpr)...)
+		},
 	}
-
-	return stmts
 }
 
-func generateNestedArrayRangeStmts(field *schema.FieldDefinition, fieldType *schema.FieldType, indexes *schema.Indexes, nestExpr ast.Expr, nestCount int) []ast.Stmt {
-	if fieldType.IsList {
-		var xExpr ast.Expr = &ast.SelectorExpr{
-			X:   ast.NewIdent("resolverRet"),
-			Sel: ast.NewIdent(toUpperCase(string(field.Name))),
-		}
-		if nestCount != 0 {
-			xExpr = ast.NewIdent(fmt.Sprintf("v%d", nestCount-1))
-		}
-
-		if fieldType.Nullable {
-			xExpr = &ast.StarExpr{
-				X: xExpr,
-			}
-		}
-
-		var sliceMakeStmt ast.Stmt = &ast.EmptyStmt{}
-		var sliceAppendStmt ast.Stmt = &ast.EmptyStmt{}
-		if nestCount > 0 {
-			sliceMakeStmt = &ast.AssignStmt{
-				Lhs: []ast.Expr{
-					ast.NewIdent(fmt.Sprintf("ret%d", nestCount)),
-				},
-				Tok: token.DEFINE,
-				Rhs: []ast.Expr{
-					&ast.CallExpr{
-						Fun: ast.NewIdent("make"),
-						Args: []ast.Expr{
-							&ast.ArrayType{
-								Elt: generateResponseTypeExpr(fieldType.ListType),
-							},
-							ast.NewIdent("0"),
-							&ast.CallExpr{
-								Fun: ast.NewIdent("len"),
-								Args: []ast.Expr{
-									xExpr,
-								},
-							},
-						},
-					},
-				},
-			}
-
-			sliceAppendTarget := fmt.Sprintf("ret%d", nestCount)
-			if nestCount == 1 {
-				sliceAppendTarget = fmt.Sprintf("ret%s", toUpperCase(string(field.Name)))
-			}
-
-			sliceAppendStmt = &ast.AssignStmt{
-				Lhs: []ast.Expr{
-					ast.NewIdent(sliceAppendTarget),
-				},
-				Tok: token.ASSIGN,
-				Rhs: []ast.Expr{
-					&ast.CallExpr{
-						Fun: ast.NewIdent("append"),
-						Args: []ast.Expr{
-							ast.NewIdent(sliceAppendTarget),
-							ast.NewIdent(fmt.Sprintf("ret%d", nestCount)),
-						},
-					},
-				},
-			}
-		}
+func generateCaseBodyStmts(definition *schema.TypeDefinition, field *schema.FieldDefinition, indexes *schema.Indexes, typePrefix string, nestExpr ast.Expr) []ast.Stmt {
+	var valueExpr ast.Expr = &ast.SelectorExpr{
+		X:   ast.NewIdent("resolverRet"),
+		Sel: ast.NewIdent(toUpperCase(string(field.Name))),
+	}
 
+	if isLeafType(field.Type, indexes) {
+		// scalars and enums are encoded by their MarshalJSON
 		return []ast.Stmt{
-			sliceMakeStmt,
-			&ast.RangeStmt{
-				X:     xExpr,
-				Tok:   token.DEFINE,
-				Key:   ast.NewIdent("_"),
-				Value: ast.NewIdent(fmt.Sprintf("v%d", nestCount)),
-				Body: &ast.BlockStmt{
-					List: generateNestedArrayRangeStmts(field, fieldType.ListType, indexes, nestExpr, nestCount+1),
-				},
-			},
-			sliceAppendStmt,
+			generateResponseSetStmt(nestExpr, generateNewNullableExpr(valueExpr)),
 		}
 	}
 
-	return generateCaseNestedRetAssignStmts(field, indexes, nestExpr, nestCount)
-}
-
-func generateCaseNestedRetAssignStmts(field *schema.FieldDefinition, indexes *schema.Indexes, nestExpr ast.Expr, nestCount int) []ast.Stmt {
 	stmts := make([]ast.Stmt, 0)
-	_, isObject := indexes.TypeIndex[string(field.Type.GetRootType().Name)]
-	_, isInterface := indexes.InterfaceIndex[string(field.Type.GetRootType().Name)]
-	_, isUnion := indexes.UnionIndex[string(field.Type.GetRootType().Name)]
-	if isObject || isInterface || isUnion {
-		var argExpr ast.Expr = ast.NewIdent(fmt.Sprintf("v%d", nestCount-1))
-		if field.Type.Nullable {
-			argExpr = &ast.StarExpr{
-				X: argExpr,
-			}
-		}
-
-		stmts = append(stmts, &ast.AssignStmt{
-			Lhs: []ast.Expr{
-				ast.NewIdent(fmt.Sprintf("ret%s", string(field.Type.GetRootType().Name))),
-				ast.NewIdent("err"),
+	if field.Type.Nullable {
+		stmts = append(stmts, &ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  valueExpr,
+				Op: token.EQL,
+				Y:  ast.NewIdent("nil"),
 			},
-			Tok: token.DEFINE,
-			Rhs: []ast.Expr{
-				&ast.CallExpr{
-					Fun: &ast.SelectorExpr{
-						X:   ast.NewIdent("r"),
-						Sel: ast.NewIdent(fmt.Sprintf("apply%sResponse", field.Type.GetRootType().Name)),
-					},
-					Args: []ast.Expr{
-						argExpr,
-						nestExpr,
-					},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					generateResponseSetStmt(nestExpr, generateNewNullableExpr(ast.NewIdent("nil"))),
+					&ast.BranchStmt{Tok: token.CONTINUE},
 				},
 			},
 		})
-		stmts = append(stmts, generateReturnErrorHandlingStmt([]ast.Expr{ast.NewIdent("nil")}))
-
-		appendTarget := fmt.Sprintf("ret%s", toUpperCase(string(field.Name)))
-		if nestCount > 1 {
-			appendTarget = fmt.Sprintf("ret%d", nestCount-1)
-		}
+		valueExpr = &ast.StarExpr{X: valueExpr}
+	}
 
-		stmts = append(stmts, &ast.AssignStmt{
-			Lhs: []ast.Expr{
-				ast.NewIdent(appendTarget),
+	retExpr := ast.NewIdent(fmt.Sprintf("ret%s", toUpperCase(string(field.Name))))
+	var completeExpr ast.Expr
+	if field.Type.IsList {
+		completeExpr = generateCompleteListExpr(valueExpr, field.Type, indexes, typePrefix, nestExpr, fmt.Sprintf("%s.%s", definition.Name, field.Name), true, 0)
+	} else {
+		completeExpr = &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("r"),
+				Sel: ast.NewIdent(fmt.Sprintf("apply%sResponse", field.Type.GetRootType().Name)),
 			},
-			Tok: token.ASSIGN,
-			Rhs: []ast.Expr{
-				&ast.CallExpr{
-					Fun: ast.NewIdent("append"),
-					Args: []ast.Expr{
-						ast.NewIdent(appendTarget),
-						ast.NewIdent(fmt.Sprintf("ret%s", string(field.Type.GetRootType().Name))),
-					},
-				},
+			Args: []ast.Expr{
+				ast.NewIdent("ctx"),
+				valueExpr,
+				nestExpr,
 			},
-		})
-	} else {
-		stmts = append(stmts, &ast.AssignStmt{
+		}
+	}
+
+	stmts = append(stmts,
+		&ast.AssignStmt{
 			Lhs: []ast.Expr{
-				&ast.SelectorExpr{
-					X:   ast.NewIdent("ret"),
-					Sel: ast.NewIdent(toUpperCase(string(field.Name))),
-				},
+				retExpr,
+				ast.NewIdent("err"),
 			},
-			Tok: token.ASSIGN,
-			Rhs: []ast.Expr{
-				&ast.CallExpr{
-					Fun: &ast.SelectorExpr{
-						X:   ast.NewIdent("executor"),
-						Sel: ast.NewIdent("NewNullable"),
-					},
-					Args: []ast.Expr{
-						&ast.SelectorExpr{
-							X:   ast.NewIdent("resolverRet"),
-							Sel: ast.NewIdent(toUpperCase(string(field.Name))),
-						},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{completeExpr},
+		},
+		generateFieldErrorHandlingStmt(field.Type, nestExpr),
+	)
+
+	if !field.Type.Nullable && !field.Type.IsList && isAbstractType(field.Type, indexes) {
+		stmts = append(stmts, generateNonNullCheckStmt(retExpr, fmt.Sprintf("%s.%s", definition.Name, field.Name), func(errExpr ast.Expr) []ast.Stmt {
+			return []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent("nil"),
+						generateAddFieldErrorExpr(errExpr),
 					},
 				},
-			},
-		})
+			}
+		}))
 	}
 
+	stmts = append(stmts, generateResponseSetStmt(nestExpr, generateNewNullableExpr(retExpr)))
+
 	return stmts
 }
 
-func generateCaseRetAssignStmts(field *schema.FieldDefinition, indexes *schema.Indexes, nestExpr ast.Expr) []ast.Stmt {
-	stmts := make([]ast.Stmt, 0)
-	_, isObject := indexes.TypeIndex[string(field.Type.GetRootType().Name)]
-	_, isInterface := indexes.InterfaceIndex[string(field.Type.GetRootType().Name)]
-	_, isUnion := indexes.UnionIndex[string(field.Type.GetRootType().Name)]
-	if isObject || isInterface || isUnion {
-		var argExpr ast.Expr = &ast.SelectorExpr{
-			X:   ast.NewIdent("resolverRet"),
-			Sel: ast.NewIdent(toUpperCase(string(field.Name))),
-		}
-		if field.Type.Nullable {
-			argExpr = &ast.StarExpr{
-				X: argExpr,
-			}
+// generateFieldErrorHandlingStmt records the error of the selected field.
+// A nullable field resolves to null and the sibling fields are still completed, while a non-nullable field propagates the null to its parent.
+func generateFieldErrorHandlingStmt(fieldType *schema.FieldType, nodeExpr ast.Expr) ast.Stmt {
+	body := []ast.Stmt{
+		&ast.ReturnStmt{
+			Results: []ast.Expr{
+				ast.NewIdent("nil"),
+				generateAddFieldErrorExpr(ast.NewIdent("err")),
+			},
+		},
+	}
+
+	if fieldType.Nullable {
+		body = []ast.Stmt{
+			&ast.ExprStmt{X: generateAddFieldErrorExpr(ast.NewIdent("err"))},
+			generateResponseSetStmt(nodeExpr, generateNewNullableExpr(ast.NewIdent("nil"))),
+			&ast.BranchStmt{Tok: token.CONTINUE},
 		}
+	}
 
-		stmts = append(stmts, &ast.AssignStmt{
-			Lhs: []ast.Expr{
-				ast.NewIdent(fmt.Sprintf("ret%s", toUpperCase(string(field.Name)))),
-				ast.NewIdent("err"),
-			},
-			Tok: token.DEFINE,
-			Rhs: []ast.Expr{
-				&ast.CallExpr{
-					Fun: &ast.SelectorExpr{
-						X:   ast.NewIdent("r"),
-						Sel: ast.NewIdent(fmt.Sprintf("apply%sResponse", field.Type.GetRootType().Name)),
-					},
-					Args: []ast.Expr{
-						argExpr,
-						nestExpr,
-					},
-				},
-			},
-		})
-		stmts = append(stmts, generateReturnErrorHandlingStmt([]ast.Expr{ast.NewIdent("nil")}))
-		stmts = append(stmts, &ast.AssignStmt{
-			Lhs: []ast.Expr{
-				&ast.SelectorExpr{
-					X:   ast.NewIdent("ret"),
-					Sel: ast.NewIdent(toUpperCase(string(field.Name))),
-				},
-			},
-			Tok: token.ASSIGN,
-			Rhs: []ast.Expr{
-				&ast.CallExpr{
-					Fun: &ast.SelectorExpr{
-						X:   ast.NewIdent("executor"),
-						Sel: ast.NewIdent("NewNullable"),
-					},
-					Args: []ast.Expr{
-						ast.NewIdent(fmt.Sprintf("ret%s", toUpperCase(string(field.Name)))),
-					},
-				},
-			},
-		})
-	} else {
-		stmts = append(stmts, &ast.AssignStmt{
-			Lhs: []ast.Expr{
-				&ast.SelectorExpr{
-					X:   ast.NewIdent("ret"),
-					Sel: ast.NewIdent(toUpperCase(string(field.Name))),
-				},
-			},
-			Tok: token.ASSIGN,
-			Rhs: []ast.Expr{
-				&ast.CallExpr{
-					Fun: &ast.SelectorExpr{
-						X:   ast.NewIdent("executor"),
-						Sel: ast.NewIdent("NewNullable"),
-					},
-					Args: []ast.Expr{
-						&ast.SelectorExpr{
-							X:   ast.NewIdent("resolverRet"),
-							Sel: ast.NewIdent(toUpperCase(string(field.Name))),
-						},
-					},
-				},
-			},
-		})
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("err"),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: body,
+		},
 	}
-
-	return stmts
 }
 
-func generateAssignMakeSliceForResponse(fieldDefinition *schema.FieldDefinition) ast.Stmt {
-	var retExpr ast.Expr = ast.NewIdent(fmt.Sprintf("ret%s", toUpperCase(string(fieldDefinition.Name))))
-
-	var argExpr ast.Expr = &ast.SelectorExpr{
-		X:   ast.NewIdent("resolverRet"),
-		Sel: ast.NewIdent(toUpperCase(string(fieldDefinition.Name))),
-	}
-	if fieldDefinition.Type.Nullable {
-		argExpr = &ast.StarExpr{
-			X: argExpr,
-		}
+func generateAddFieldErrorExpr(errExpr ast.Expr) ast.Expr {
+	return &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent("executor"),
+			Sel: ast.NewIdent("AddFieldError"),
+		},
+		Args: []ast.Expr{
+			ast.NewIdent("ctx"),
+			errExpr,
+		},
 	}
+}
 
-	return &ast.AssignStmt{
-		Lhs: []ast.Expr{retExpr},
-		Tok: token.DEFINE,
-		Rhs: []ast.Expr{
-			&ast.CallExpr{
-				Fun: ast.NewIdent("make"),
+// generateNonNullCheckStmt handles an interface or union value that did not resolve to any concrete type in a non-nullable position.
+func generateNonNullCheckStmt(retExpr ast.Expr, coordinate string, onError func(errExpr ast.Expr) []ast.Stmt) ast.Stmt {
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  retExpr,
+			Op: token.EQL,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: onError(&ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("executor"),
+					Sel: ast.NewIdent("NewNonNullError"),
+				},
 				Args: []ast.Expr{
-					generateResponseNullable(fieldDefinition.Type),
-					ast.NewIdent("0"),
-					&ast.CallExpr{
-						Fun: ast.NewIdent("len"),
-						Args: []ast.Expr{
-							argExpr,
-						},
-					},
+					&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(coordinate)},
 				},
-			},
+			}),
 		},
 	}
 }
 
-func generateResponseTypeExpr(fieldType *schema.FieldType) ast.Expr {
-	if fieldType.IsList {
-		return &ast.ArrayType{
-			Elt: generateResponseTypeExpr(fieldType.ListType),
-		}
-	}
+// generateCompleteListExpr completes every item of a list value with executor.CompleteList,
+// or with executor.CompleteListConcurrently when completing an item calls field resolvers, so that their loads are batched together.
+// Values read from model fields hold nullable lists and abstract types behind pointers, while values returned by resolvers do not.
+func generateCompleteListExpr(valueExpr ast.Expr, fieldType *schema.FieldType, indexes *schema.Indexes, typePrefix string, nodeExpr ast.Expr, coordinate string, fromModel bool, nestCount int) ast.Expr {
+	itemExpr := ast.NewIdent(fmt.Sprintf("v%d", nestCount))
 
-	return ast.NewIdent(fmt.Sprintf("%sResponse", fieldType.Name))
-}
+	var itemTypeExpr ast.Expr
+	if fromModel {
+		itemTypeExpr = generateModelTypeExpr(typePrefix, fieldType.ListType)
+	} else {
+		itemTypeExpr = generateTypeExprFromFieldTypeForReturn(typePrefix, fieldType.ListType, indexes)
+	}
 
-func generateFieldTypeApplyBodyStmts(fieldType *schema.FieldType, indexes *schema.Indexes, typePrefix string) []ast.Stmt {
-	ret := make([]ast.Stmt, 0)
+	completeList := "CompleteList"
+	if hasFieldResolvers(string(fieldType.ListType.GetRootType().Name), indexes, make(map[string]struct{})) {
+		completeList = "CompleteListConcurrently"
+	}
 
-	if fieldType.IsList {
-		ret = append(ret, generateFieldTypeRangeBodyStmts(fieldType, indexes, 0)...)
-		ret = append(ret, &ast.ReturnStmt{
-			Results: []ast.Expr{
-				ast.NewIdent("ret"),
-				ast.NewIdent("nil"),
-			},
-		})
-	} else {
-		_, isObject := indexes.TypeIndex[string(fieldType.GetRootType().Name)]
-		_, isInterface := indexes.InterfaceIndex[string(fieldType.GetRootType().Name)]
-		_, isUnion := indexes.UnionIndex[string(fieldType.GetRootType().Name)]
-		if isObject || isInterface || isUnion {
-			var nilAssignStmt ast.Stmt = &ast.EmptyStmt{}
-
-			var arg ast.Expr = ast.NewIdent("resolverRet")
-			if isObject && fieldType.Nullable {
-				nilAssignStmt = &ast.IfStmt{
-					Cond: &ast.BinaryExpr{
-						X:  ast.NewIdent("resolverRet"),
-						Op: token.EQL,
-						Y: &ast.BasicLit{
-							Kind:  token.STRING,
-							Value: "nil",
-						},
-					},
-					Body: &ast.BlockStmt{
-						List: []ast.Stmt{
-							&ast.ReturnStmt{
-								Results: []ast.Expr{
-									&ast.CallExpr{
-										Fun: &ast.SelectorExpr{
-											X:   ast.NewIdent("executor"),
-											Sel: ast.NewIdent("NewNullable"),
-										},
-										Args: []ast.Expr{
-											ast.NewIdent("nil"),
-										},
-									},
-									ast.NewIdent("nil"),
+	return &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent("executor"),
+			Sel: ast.NewIdent(completeList),
+		},
+		Args: []ast.Expr{
+			ast.NewIdent("ctx"),
+			valueExpr,
+			ast.NewIdent(strconv.FormatBool(fieldType.ListType.Nullable)),
+			&ast.FuncLit{
+				Type: &ast.FuncType{
+					Params: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{ast.NewIdent("ctx")},
+								Type: &ast.SelectorExpr{
+									X:   ast.NewIdent("context"),
+									Sel: ast.NewIdent("Context"),
 								},
 							},
+							{
+								Names: []*ast.Ident{itemExpr},
+								Type:  itemTypeExpr,
+							},
 						},
 					},
-				}
-				arg = &ast.StarExpr{
-					X: arg,
-				}
-			}
-
-			if isInterface || isUnion {
-				nilAssignStmt = &ast.IfStmt{
-					Cond: &ast.BinaryExpr{
-						X:  ast.NewIdent("resolverRet"),
-						Op: token.EQL,
-						Y: &ast.BasicLit{
-							Kind:  token.STRING,
-							Value: "nil",
-						},
-					},
-					Body: &ast.BlockStmt{
-						List: []ast.Stmt{
-							&ast.ReturnStmt{
-								Results: []ast.Expr{
-									&ast.CallExpr{
-										Fun: &ast.SelectorExpr{
-											X:   ast.NewIdent("executor"),
-											Sel: ast.NewIdent("NewNullable"),
-										},
-										Args: []ast.Expr{
-											ast.NewIdent("nil"),
-										},
-									},
-									ast.NewIdent("nil"),
+					Results: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Type: &ast.SelectorExpr{
+									X:   ast.NewIdent("executor"),
+									Sel: ast.NewIdent("Nullable"),
 								},
 							},
-						},
-					},
-				}
-			}
-
-			ret = append(ret, nilAssignStmt)
-			ret = append(ret, &ast.AssignStmt{
-				Lhs: []ast.Expr{
-					ast.NewIdent("ret"),
-					ast.NewIdent("err"),
-				},
-				Tok: token.DEFINE,
-				Rhs: []ast.Expr{
-					&ast.CallExpr{
-						Fun: &ast.SelectorExpr{
-							X:   ast.NewIdent("r"),
-							Sel: ast.NewIdent(fmt.Sprintf("apply%sResponse", fieldType.Name)),
-						},
-						Args: []ast.Expr{
-							arg,
-							ast.NewIdent("node"),
+							{
+								Type: ast.NewIdent("error"),
+							},
 						},
 					},
 				},
-			})
-			ret = append(ret, generateReturnErrorHandlingStmt([]ast.Expr{ast.NewIdent("nil")}))
-			ret = append(ret, &ast.ReturnStmt{
-				Results: []ast.Expr{
-					ast.NewIdent("ret"),
-					ast.NewIdent("nil"),
+				Body: &ast.BlockStmt{
+					List: generateCompleteValueStmts(itemExpr, fieldType.ListType, indexes, typePrefix, nodeExpr, coordinate, fromModel, nestCount+1),
 				},
-			})
-		}
+			},
+		},
 	}
-
-	return ret
 }
 
-func generateFieldTypeRangeBodyStmts(fieldType *schema.FieldType, indexes *schema.Indexes, nestCount int) []ast.Stmt {
-	var xExpr ast.Expr = ast.NewIdent("resolverRet")
-	var retExpr ast.Expr = ast.NewIdent("ret")
-	if nestCount != 0 {
-		xExpr = ast.NewIdent(fmt.Sprintf("v%d", nestCount-1))
-		retExpr = ast.NewIdent(fmt.Sprintf("ret%d", nestCount))
-	}
-
-	var nestValueExpr ast.Expr = ast.NewIdent(fmt.Sprintf("v%d", nestCount))
-	var appendStmt ast.Stmt = &ast.EmptyStmt{}
-	if nestCount > 0 {
-		ret := fmt.Sprintf("ret%d", nestCount)
-		if nestCount == 1 {
-			ret = "ret"
-		}
-
-		appendStmt = &ast.AssignStmt{
-			Lhs: []ast.Expr{
-				ast.NewIdent(ret),
-			},
-			Tok: token.ASSIGN,
-			Rhs: []ast.Expr{
-				&ast.CallExpr{
-					Fun: ast.NewIdent("append"),
-					Args: []ast.Expr{
-						ast.NewIdent(ret),
-						ast.NewIdent(fmt.Sprintf("ret%d", nestCount)),
-					},
+// generateCompleteValueStmts completes a value of fieldType as the body of a function returning executor.Nullable and error.
+func generateCompleteValueStmts(valueExpr ast.Expr, fieldType *schema.FieldType, indexes *schema.Indexes, typePrefix string, nodeExpr ast.Expr, coordinate string, fromModel bool, nestCount int) []ast.Stmt {
+	if isLeafType(fieldType, indexes) {
+		// scalars and enums are encoded by their MarshalJSON
+		return []ast.Stmt{
+			&ast.ReturnStmt{
+				Results: []ast.Expr{
+					generateNewNullableExpr(valueExpr),
+					ast.NewIdent("nil"),
 				},
 			},
 		}
 	}
 
-	if fieldType.Nullable && fieldType.IsList {
-		if _, ok := indexes.TypeIndex[string(fieldType.GetRootType().Name)]; ok {
-			xExpr = &ast.StarExpr{X: xExpr}
-		}
-
-		return []ast.Stmt{
-			&ast.AssignStmt{
-				Lhs: []ast.Expr{retExpr},
-				Tok: token.DEFINE,
-				Rhs: []ast.Expr{
-					&ast.CallExpr{
-						Fun: ast.NewIdent("make"),
-						Args: []ast.Expr{
-							&ast.ArrayType{
-								Elt: generateResponseNullable(fieldType.ListType),
-							},
-							ast.NewIdent("0"),
-							&ast.CallExpr{
-								Fun: ast.NewIdent("len"),
-								Args: []ast.Expr{
-									xExpr,
-								},
-							},
+	stmts := make([]ast.Stmt, 0)
+	isAbstract := isAbstractType(fieldType, indexes)
+	if fieldType.Nullable && (fromModel || !fieldType.IsList && !isAbstract) {
+		stmts = append(stmts, &ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  valueExpr,
+				Op: token.EQL,
+				Y:  ast.NewIdent("nil"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{
+							generateNewNullableExpr(ast.NewIdent("nil")),
+							ast.NewIdent("nil"),
 						},
 					},
 				},
 			},
-			&ast.RangeStmt{
-				Key:   ast.NewIdent("_"),
-				Value: nestValueExpr,
-				X:     xExpr,
-				Tok:   token.DEFINE,
-				Body: &ast.BlockStmt{
-					List: generateFieldTypeRangeBodyStmts(fieldType.ListType, indexes, nestCount+1),
-				},
-			},
-			appendStmt,
-		}
+		})
+		valueExpr = &ast.StarExpr{X: valueExpr}
 	}
+
+	retExpr := ast.NewIdent("ret")
 	if fieldType.IsList {
-		return []ast.Stmt{
+		return append(stmts,
 			&ast.AssignStmt{
-				Lhs: []ast.Expr{retExpr},
+				Lhs: []ast.Expr{
+					retExpr,
+					ast.NewIdent("err"),
+				},
 				Tok: token.DEFINE,
 				Rhs: []ast.Expr{
-					&ast.CallExpr{
-						Fun: ast.NewIdent("make"),
-						Args: []ast.Expr{
-							&ast.ArrayType{
-								Elt: generateResponseNullable(fieldType.ListType),
-							},
-							ast.NewIdent("0"),
-							&ast.CallExpr{
-								Fun: ast.NewIdent("len"),
-								Args: []ast.Expr{
-									xExpr,
-								},
-							},
-						},
-					},
+					generateCompleteListExpr(valueExpr, fieldType, indexes, typePrefix, nodeExpr, coordinate, fromModel, nestCount),
 				},
 			},
-			&ast.RangeStmt{
-				Key:   ast.NewIdent("_"),
-				Value: nestValueExpr,
-				X:     xExpr,
-				Tok:   token.DEFINE,
-				Body: &ast.BlockStmt{
-					List: generateFieldTypeRangeBodyStmts(fieldType.ListType, indexes, nestCount+1),
+			generateReturnErrorHandlingStmt([]ast.Expr{ast.NewIdent("nil")}),
+			&ast.ReturnStmt{
+				Results: []ast.Expr{
+					generateNewNullableExpr(retExpr),
+					ast.NewIdent("nil"),
 				},
 			},
-			appendStmt,
-		}
+		)
 	}
 
-	var argExpr ast.Expr = ast.NewIdent(fmt.Sprintf("v%d", nestCount-1))
-	if fieldType.Nullable {
-		argExpr = &ast.StarExpr{
-			X: argExpr,
-		}
+	applyExpr := &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent("r"),
+			Sel: ast.NewIdent(fmt.Sprintf("apply%sResponse", fieldType.Name)),
+		},
+		Args: []ast.Expr{
+			ast.NewIdent("ctx"),
+			valueExpr,
+			nodeExpr,
+		},
 	}
 
-	var appendCheckStmt ast.Stmt = appendStmt
-	_, isUnion := indexes.UnionIndex[string(fieldType.GetRootType().Name)]
-	if isUnion {
-		appendCheckStmt = &ast.IfStmt{
-			Cond: &ast.BinaryExpr{
-				X:  ast.NewIdent(fmt.Sprintf("ret%d", nestCount)),
-				Op: token.NEQ,
-				Y: &ast.BasicLit{
-					Kind:  token.STRING,
-					Value: "nil",
-				},
-			},
-			Body: &ast.BlockStmt{
-				List: []ast.Stmt{
-					appendStmt,
-				},
-			},
-		}
+	if fieldType.Nullable || !isAbstract {
+		return append(stmts, &ast.ReturnStmt{
+			Results: []ast.Expr{applyExpr},
+		})
 	}
 
-	return []ast.Stmt{
+	return append(stmts,
 		&ast.AssignStmt{
 			Lhs: []ast.Expr{
-				ast.NewIdent(fmt.Sprintf("ret%d", nestCount)),
+				retExpr,
 				ast.NewIdent("err"),
 			},
 			Tok: token.DEFINE,
-			Rhs: []ast.Expr{
-				&ast.CallExpr{
-					Fun: &ast.SelectorExpr{
-						X:   ast.NewIdent("r"),
-						Sel: ast.NewIdent(fmt.Sprintf("apply%sResponse", fieldType.Name)),
-					},
-					Args: []ast.Expr{
-						argExpr,
-						ast.NewIdent("node"),
+			Rhs: []ast.Expr{applyExpr},
+		},
+		generateReturnErrorHandlingStmt([]ast.Expr{ast.NewIdent("nil")}),
+		generateNonNullCheckStmt(retExpr, coordinate, func(errExpr ast.Expr) []ast.Stmt {
+			return []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent("nil"),
+						errExpr,
 					},
 				},
+			}
+		}),
+		&ast.ReturnStmt{
+			Results: []ast.Expr{
+				retExpr,
+				ast.NewIdent("nil"),
 			},
 		},
-		generateReturnErrorHandlingStmt([]ast.Expr{ast.NewIdent("nil")}),
-		appendCheckStmt,
+	)
+}
+
+func isAbstractType(fieldType *schema.FieldType, indexes *schema.Indexes) bool {
+	name := string(fieldType.GetRootType().Name)
+	_, isInterface := indexes.InterfaceIndex[name]
+	_, isUnion := indexes.UnionIndex[name]
+
+	return isInterface || isUnion
+}
+
+func isLeafType(fieldType *schema.FieldType, indexes *schema.Indexes) bool {
+	name := string(fieldType.GetRootType().Name)
+	_, isObject := indexes.TypeIndex[name]
+	_, isInterface := indexes.InterfaceIndex[name]
+	_, isUnion := indexes.UnionIndex[name]
+
+	return !isObject && !isInterface && !isUnion
+}
+
+// hasFieldResolvers reports whether completing a value of the named type calls a field resolver.
+func hasFieldResolvers(name string, indexes *schema.Indexes, visited map[string]struct{}) bool {
+	if _, ok := visited[name]; ok {
+		return false
+	}
+	visited[name] = struct{}{}
+
+	if definition, ok := indexes.TypeIndex[name]; ok {
+		for _, field := range definition.Fields {
+			if isForceResolverField(field) || hasFieldResolvers(string(field.Type.GetRootType().Name), indexes, visited) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	possibleTypes := make([][]byte, 0)
+	if union, ok := indexes.UnionIndex[name]; ok {
+		possibleTypes = union.Types
+	}
+	if _, ok := indexes.InterfaceIndex[name]; ok {
+		for _, definition := range indexes.TypeIndex {
+			for _, iface := range definition.Interfaces {
+				if string(iface) == name {
+					possibleTypes = append(possibleTypes, definition.Name)
+				}
+			}
+		}
+	}
+
+	for _, typeName := range possibleTypes {
+		if hasFieldResolvers(string(typeName), indexes, visited) {
+			return true
+		}
 	}
+
+	return false
 }
 
 func generateInterfaceApplyResponseFuncDecl(definition *schema.InterfaceDefinition, indexes *schema.Indexes, typePrefix string) ast.Decl {
@@ -942,6 +802,13 @@ func generateInterfaceApplyResponseFuncDecl(definition *schema.InterfaceDefiniti
 		Type: &ast.FuncType{
 			Params: &ast.FieldList{
 				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("ctx")},
+						Type: &ast.SelectorExpr{
+							X:   ast.NewIdent("context"),
+							Sel: ast.NewIdent("Context"),
+						},
+					},
 					{
 						Names: []*ast.Ident{ast.NewIdent("resolverRet")},
 						Type: &ast.SelectorExpr{
@@ -1007,92 +874,79 @@ func getUnionTypes(definition *schema.UnionDefinition, indexes *schema.Indexes)
 }
 
 func generateInterfaceApplySwitchStmtsForInterfaceDefinition(definition *schema.InterfaceDefinition, types schema.TypeDefinitions, indexes *schema.Indexes, typePrefix string) []ast.Stmt {
-	typeCases := make([]ast.Stmt, 0, len(types))
-	for _, typeDef := range types {
-		typeCases = append(typeCases, &ast.CaseClause{
-			List: []ast.Expr{
-				&ast.SelectorExpr{
-					X:   ast.NewIdent(typePrefix),
-					Sel: ast.NewIdent(string(typeDef.Name)),
-				},
-			},
-			Body: []ast.Stmt{
-				&ast.AssignStmt{
-					Lhs: []ast.Expr{
-						ast.NewIdent(fmt.Sprintf("ret%s", typeDef.Name)),
-						ast.NewIdent("err"),
-					},
-					Tok: token.DEFINE,
-					Rhs: []ast.Expr{
-						&ast.CallExpr{
-							Fun: &ast.SelectorExpr{
-								X:   ast.NewIdent("r"),
-								Sel: ast.NewIdent(fmt.Sprintf("apply%sResponse", typeDef.Name)),
-							},
-							Args: []ast.Expr{
-								ast.NewIdent("resolverRet"),
-								ast.NewIdent("node"),
-							},
+	typeCases := generateAbstractTypeCaseClauses(types, typePrefix)
+
+	return []ast.Stmt{
+		&ast.DeclStmt{
+			Decl: &ast.GenDecl{
+				Tok: token.VAR,
+				Specs: []ast.Spec{
+					&ast.ValueSpec{
+						Names: []*ast.Ident{ast.NewIdent("ret")},
+						Type: &ast.SelectorExpr{
+							X:   ast.NewIdent("executor"),
+							Sel: ast.NewIdent("Nullable"),
 						},
 					},
 				},
-				generateReturnErrorHandlingStmt([]ast.Expr{ast.NewIdent("nil")}),
-				&ast.AssignStmt{
-					Lhs: []ast.Expr{
-						ast.NewIdent("ret"),
-					},
-					Tok: token.ASSIGN,
-					Rhs: []ast.Expr{
-						ast.NewIdent(fmt.Sprintf("ret%s", typeDef.Name)),
+			},
+		},
+		&ast.TypeSwitchStmt{
+			Assign: &ast.AssignStmt{
+				Lhs: []ast.Expr{
+					ast.NewIdent("resolverRet"),
+				},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{
+					&ast.TypeAssertExpr{
+						X:    ast.NewIdent("resolverRet"),
+						Type: ast.NewIdent("type"),
 					},
 				},
 			},
-		})
-
-		typeCases = append(typeCases, &ast.CaseClause{
-			List: []ast.Expr{
-				&ast.StarExpr{
-					X: &ast.SelectorExpr{
-						X:   ast.NewIdent(typePrefix),
-						Sel: ast.NewIdent(string(typeDef.Name)),
-					},
+			Body: &ast.BlockStmt{
+				List: typeCases,
+			},
+		},
+		&ast.IfStmt{
+			Cond: &ast.BinaryExpr{
+				X:  ast.NewIdent("ret"),
+				Op: token.EQL,
+				Y: &ast.BasicLit{
+					Kind:  token.STRING,
+					Value: "nil",
 				},
 			},
-			Body: []ast.Stmt{
-				&ast.AssignStmt{
-					Lhs: []ast.Expr{
-						ast.NewIdent(fmt.Sprintf("ret%s", typeDef.Name)),
-						ast.NewIdent("err"),
-					},
-					Tok: token.DEFINE,
-					Rhs: []ast.Expr{
-						&ast.CallExpr{
-							Fun: &ast.SelectorExpr{
-								X:   ast.NewIdent("r"),
-								Sel: ast.NewIdent(fmt.Sprintf("apply%sResponse", typeDef.Name)),
-							},
-							Args: []ast.Expr{
-								&ast.StarExpr{
-									X: ast.NewIdent("resolverRet"),
-								},
-								ast.NewIdent("node"),
-							},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{
+							ast.NewIdent("nil"),
+							ast.NewIdent("nil"),
 						},
 					},
 				},
-				generateReturnErrorHandlingStmt([]ast.Expr{ast.NewIdent("nil")}),
-				&ast.AssignStmt{
-					Lhs: []ast.Expr{
-						ast.NewIdent("ret"),
+			},
+		},
+		&ast.ReturnStmt{
+			Results: []ast.Expr{
+				&ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X:   ast.NewIdent("executor"),
+						Sel: ast.NewIdent("NewNullable"),
 					},
-					Tok: token.ASSIGN,
-					Rhs: []ast.Expr{
-						ast.NewIdent(fmt.Sprintf("ret%s", typeDef.Name)),
+					Args: []ast.Expr{
+						ast.NewIdent("ret"),
 					},
 				},
+				ast.NewIdent("nil"),
 			},
-		})
+		},
 	}
+}
+
+func generateInterfaceApplySwitchStmtsForUnionDefinition(definition *schema.UnionDefinition, types schema.TypeDefinitions, indexes *schema.Indexes, typePrefix string) []ast.Stmt {
+	typeCases := generateAbstractTypeCaseClauses(types, typePrefix)
 
 	return []ast.Stmt{
 		&ast.DeclStmt{
@@ -1101,11 +955,15 @@ func generateInterfaceApplySwitchStmtsForInterfaceDefinition(definition *schema.
 				Specs: []ast.Spec{
 					&ast.ValueSpec{
 						Names: []*ast.Ident{ast.NewIdent("ret")},
-						Type:  ast.NewIdent(fmt.Sprintf("%sResponse", definition.Name)),
+						Type: &ast.SelectorExpr{
+							X:   ast.NewIdent("executor"),
+							Sel: ast.NewIdent("Nullable"),
+						},
 					},
 				},
 			},
 		},
+
 		&ast.TypeSwitchStmt{
 			Assign: &ast.AssignStmt{
 				Lhs: []ast.Expr{
@@ -1160,7 +1018,9 @@ func generateInterfaceApplySwitchStmtsForInterfaceDefinition(definition *schema.
 	}
 }
 
-func generateInterfaceApplySwitchStmtsForUnionDefinition(definition *schema.UnionDefinition, types schema.TypeDefinitions, indexes *schema.Indexes, typePrefix string) []ast.Stmt {
+// generateAbstractTypeCaseClauses dispatches an interface or union value to the apply function of its concrete type.
+// Each concrete type collects the fields of the fragments that apply to it.
+func generateAbstractTypeCaseClauses(types schema.TypeDefinitions, typePrefix string) []ast.Stmt {
 	typeCases := make([]ast.Stmt, 0, len(types))
 	for _, typeDef := range types {
 		typeCases = append(typeCases, &ast.CaseClause{
@@ -1171,54 +1031,36 @@ func generateInterfaceApplySwitchStmtsForUnionDefinition(definition *schema.Unio
 				},
 			},
 			Body: []ast.Stmt{
-				&ast.IfStmt{
-					Cond: &ast.BinaryExpr{
-						X: &ast.CallExpr{
-							Fun: &ast.SelectorExpr{
-								X:   ast.NewIdent("node"),
-								Sel: ast.NewIdent("FragmentType"),
-							},
-						},
-						Op: token.EQL,
-						Y: &ast.BasicLit{
-							Kind:  token.STRING,
-							Value: fmt.Sprintf(`"%s"`, typeDef.Name),
-						},
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent(fmt.Sprintf("ret%s", typeDef.Name)),
+						ast.NewIdent("err"),
 					},
-					Body: &ast.BlockStmt{
-						List: []ast.Stmt{
-							&ast.AssignStmt{
-								Lhs: []ast.Expr{
-									ast.NewIdent(fmt.Sprintf("ret%s", typeDef.Name)),
-									ast.NewIdent("err"),
-								},
-								Tok: token.DEFINE,
-								Rhs: []ast.Expr{
-									&ast.CallExpr{
-										Fun: &ast.SelectorExpr{
-											X:   ast.NewIdent("r"),
-											Sel: ast.NewIdent(fmt.Sprintf("apply%sResponse", typeDef.Name)),
-										},
-										Args: []ast.Expr{
-											ast.NewIdent("resolverRet"),
-											ast.NewIdent("child"),
-										},
-									},
-								},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{
+						&ast.CallExpr{
+							Fun: &ast.SelectorExpr{
+								X:   ast.NewIdent("r"),
+								Sel: ast.NewIdent(fmt.Sprintf("apply%sResponse", typeDef.Name)),
 							},
-							generateReturnErrorHandlingStmt([]ast.Expr{ast.NewIdent("nil")}),
-							&ast.AssignStmt{
-								Lhs: []ast.Expr{
-									ast.NewIdent("ret"),
-								},
-								Tok: token.ASSIGN,
-								Rhs: []ast.Expr{
-									ast.NewIdent(fmt.Sprintf("ret%s", typeDef.Name)),
-								},
+							Args: []ast.Expr{
+								ast.NewIdent("ctx"),
+								ast.NewIdent("resolverRet"),
+								ast.NewIdent("node"),
 							},
 						},
 					},
 				},
+				generateReturnErrorHandlingStmt([]ast.Expr{ast.NewIdent("nil")}),
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent("ret"),
+					},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						ast.NewIdent(fmt.Sprintf("ret%s", typeDef.Name)),
+					},
+				},
 			},
 		})
 
@@ -1234,165 +1076,53 @@ func generateInterfaceApplySwitchStmtsForUnionDefinition(definition *schema.Unio
 			Body: []ast.Stmt{
 				&ast.IfStmt{
 					Cond: &ast.BinaryExpr{
-						X: &ast.CallExpr{
-							Fun: &ast.SelectorExpr{
-								X:   ast.NewIdent("child"),
-								Sel: ast.NewIdent("FragmentType"),
-							},
-						},
+						X:  ast.NewIdent("resolverRet"),
 						Op: token.EQL,
-						Y: &ast.BasicLit{
-							Kind:  token.STRING,
-							Value: fmt.Sprintf(`"%s"`, typeDef.Name),
-						},
+						Y:  ast.NewIdent("nil"),
 					},
 					Body: &ast.BlockStmt{
 						List: []ast.Stmt{
-							&ast.AssignStmt{
-								Lhs: []ast.Expr{
-									ast.NewIdent(fmt.Sprintf("ret%s", typeDef.Name)),
-									ast.NewIdent("err"),
-								},
-								Tok: token.DEFINE,
-								Rhs: []ast.Expr{
-									&ast.CallExpr{
-										Fun: &ast.SelectorExpr{
-											X:   ast.NewIdent("r"),
-											Sel: ast.NewIdent(fmt.Sprintf("apply%sResponse", typeDef.Name)),
-										},
-										Args: []ast.Expr{
-											&ast.StarExpr{
-												X: ast.NewIdent("resolverRet"),
-											},
-											ast.NewIdent("child"),
-										},
-									},
-								},
-							},
-							generateReturnErrorHandlingStmt([]ast.Expr{ast.NewIdent("nil")}),
-							&ast.AssignStmt{
-								Lhs: []ast.Expr{
-									ast.NewIdent("ret"),
-								},
-								Tok: token.ASSIGN,
-								Rhs: []ast.Expr{
-									ast.NewIdent(fmt.Sprintf("ret%s", typeDef.Name)),
-								},
-							},
+							&ast.BranchStmt{Tok: token.BREAK},
 						},
 					},
 				},
-			},
-		})
-	}
-
-	return []ast.Stmt{
-		&ast.IfStmt{
-			Cond: &ast.BinaryExpr{
-				X:  ast.NewIdent("resolverRet"),
-				Op: token.EQL,
-				Y: &ast.BasicLit{
-					Kind:  token.STRING,
-					Value: "nil",
-				},
-			},
-			Body: &ast.BlockStmt{
-				List: []ast.Stmt{
-					&ast.ReturnStmt{
-						Results: []ast.Expr{
-							&ast.CallExpr{
-								Fun: &ast.SelectorExpr{
-									X:   ast.NewIdent("executor"),
-									Sel: ast.NewIdent("NewNullable"),
-								},
-								Args: []ast.Expr{
-									ast.NewIdent("nil"),
-								},
-							},
-							ast.NewIdent("nil"),
-						},
-					},
-				},
-			},
-		},
-
-		&ast.DeclStmt{
-			Decl: &ast.GenDecl{
-				Tok: token.VAR,
-				Specs: []ast.Spec{
-					&ast.ValueSpec{
-						Names: []*ast.Ident{ast.NewIdent("ret")},
-						Type:  ast.NewIdent(fmt.Sprintf("%sResponse", definition.Name)),
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent(fmt.Sprintf("ret%s", typeDef.Name)),
+						ast.NewIdent("err"),
 					},
-				},
-			},
-		},
-
-		&ast.RangeStmt{
-			Key:   ast.NewIdent("_"),
-			Value: ast.NewIdent("child"),
-			Tok:   token.DEFINE,
-			X: &ast.SelectorExpr{
-				X:   ast.NewIdent("node"),
-				Sel: ast.NewIdent("Children"),
-			},
-			Body: &ast.BlockStmt{
-				List: []ast.Stmt{
-					&ast.TypeSwitchStmt{
-						Assign: &ast.AssignStmt{
-							Lhs: []ast.Expr{
-								ast.NewIdent("resolverRet"),
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{
+						&ast.CallExpr{
+							Fun: &ast.SelectorExpr{
+								X:   ast.NewIdent("r"),
+								Sel: ast.NewIdent(fmt.Sprintf("apply%sResponse", typeDef.Name)),
 							},
-							Tok: token.DEFINE,
-							Rhs: []ast.Expr{
-								&ast.TypeAssertExpr{
-									X:    ast.NewIdent("resolverRet"),
-									Type: ast.NewIdent("type"),
+							Args: []ast.Expr{
+								ast.NewIdent("ctx"),
+								&ast.StarExpr{
+									X: ast.NewIdent("resolverRet"),
 								},
+								ast.NewIdent("node"),
 							},
 						},
-						Body: &ast.BlockStmt{
-							List: typeCases,
-						},
 					},
 				},
-			},
-		},
-		&ast.IfStmt{
-			Cond: &ast.BinaryExpr{
-				X:  ast.NewIdent("ret"),
-				Op: token.EQL,
-				Y: &ast.BasicLit{
-					Kind:  token.STRING,
-					Value: "nil",
-				},
-			},
-			Body: &ast.BlockStmt{
-				List: []ast.Stmt{
-					&ast.ReturnStmt{
-						Results: []ast.Expr{
-							ast.NewIdent("nil"),
-							ast.NewIdent("nil"),
-						},
-					},
-				},
-			},
-		},
-		&ast.ReturnStmt{
-			Results: []ast.Expr{
-				&ast.CallExpr{
-					Fun: &ast.SelectorExpr{
-						X:   ast.NewIdent("executor"),
-						Sel: ast.NewIdent("NewNullable"),
-					},
-					Args: []ast.Expr{
+				generateReturnErrorHandlingStmt([]ast.Expr{ast.NewIdent("nil")}),
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
 						ast.NewIdent("ret"),
 					},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						ast.NewIdent(fmt.Sprintf("ret%s", typeDef.Name)),
+					},
 				},
-				ast.NewIdent("nil"),
 			},
-		},
+		})
 	}
+
+	return typeCases
 }
 
 func generateUnionApplyResponseFuncDecl(definition *schema.UnionDefinition, indexes *schema.Indexes, typePrefix string) ast.Decl {
@@ -1410,6 +1140,13 @@ func generateUnionApplyResponseFuncDecl(definition *schema.UnionDefinition, inde
 		Type: &ast.FuncType{
 			Params: &ast.FieldList{
 				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("ctx")},
+						Type: &ast.SelectorExpr{
+							X:   ast.NewIdent("context"),
+							Sel: ast.NewIdent("Context"),
+						},
+					},
 					{
 						Names: []*ast.Ident{ast.NewIdent("resolverRet")},
 						Type: &ast.SelectorExpr{
@@ -1,19 +1,149 @@
 package generator
 
 import (
+	"bytes"
 	"fmt"
 	"go/ast"
 	"go/token"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/n9te9/goliteql/scalars"
+	"github.com/n9te9/goliteql/schema"
 )
 
-func (g *Generator) generateScalar() {
-	specs := make([]ast.Spec, 0)
+// resolveScalarConfigs binds scalars configured by name only to the scalars package.
+func resolveScalarConfigs(configs []ScalarConfig) {
+	for i, scalar := range configs {
+		if scalar.Type != "" {
+			continue
+		}
+
+		if _, ok := scalars.Lookup(scalar.Name); ok {
+			configs[i].Package = scalars.PackagePath
+			configs[i].Type = "scalars." + scalar.Name
+		}
+	}
+}
+
+// applyScalarSpecifiedBy adds the @specifiedBy URL of scalars bound to the scalars package
+// unless the schema already declares one, and returns the schema source extending them.
+func applyScalarSpecifiedBy(configs []ScalarConfig, indexes *schema.Indexes) []byte {
+	var buf bytes.Buffer
+	for _, scalar := range configs {
+		if scalar.Package != scalars.PackagePath {
+			continue
+		}
+
+		definition, ok := scalars.Lookup(strings.TrimPrefix(scalar.Type, "scalars."))
+		if !ok || definition.SpecifiedByURL == "" {
+			continue
+		}
+
+		scalarDefinition, ok := indexes.ScalarIndex[scalar.Name]
+		if !ok || hasSpecifiedBy(scalarDefinition) {
+			continue
+		}
+
+		url := strconv.Quote(definition.SpecifiedByURL)
+		scalarDefinition.Directives = append(scalarDefinition.Directives, &schema.Directive{
+			Name: []byte("specifiedBy"),
+			Arguments: []*schema.DirectiveArgument{
+				{Name: []byte("url"), Value: []byte(url)},
+			},
+		})
+		fmt.Fprintf(&buf, "\nextend scalar %s @specifiedBy(url: %s)\n", scalar.Name, url)
+	}
+
+	return buf.Bytes()
+}
+
+func hasSpecifiedBy(definition *schema.ScalarDefinition) bool {
+	for _, directive := range definition.Directives {
+		if string(directive.Name) == "specifiedBy" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateScalarConfigs(scalars []ScalarConfig, indexes *schema.Indexes, federation bool) error {
+	configured := make(map[string]struct{}, len(scalars))
+	for _, scalar := range scalars {
+		if scalar.Name == "" {
+			return fmt.Errorf("scalar requires name")
+		}
+
+		if scalar.Type == "" {
+			return fmt.Errorf("scalar %s requires type unless it is one of the scalars package", scalar.Name)
+		}
+
+		if (scalar.MarshalFunc == "") != (scalar.UnmarshalFunc == "") {
+			return fmt.Errorf("scalar %s requires both marshal_func and unmarshal_func", scalar.Name)
+		}
 
+		for _, f := range []string{scalar.MarshalFunc, scalar.UnmarshalFunc} {
+			if f != "" && !strings.Contains(f, ".") {
+				return fmt.Errorf("scalar %s function %s must be qualified by its package path", scalar.Name, f)
+			}
+		}
+
+		configured[scalar.Name] = struct{}{}
+	}
+
+	for name := range indexes.ScalarIndex {
+		if federation && isFederationTypeName(name) {
+			continue
+		}
+
+		if _, ok := configured[name]; !ok {
+			return fmt.Errorf("scalar %s is not configured in scalars", name)
+		}
+	}
+
+	return nil
+}
+
+// splitScalarFunc splits "github.com/foo/bar.MarshalBaz" into its import path and package qualified name.
+func splitScalarFunc(f string) (string, string) {
+	idx := strings.LastIndex(f, ".")
+	importPath := f[:idx]
+
+	return importPath, fmt.Sprintf("%s.%s", path.Base(importPath), f[idx+1:])
+}
+
+func (g *Generator) generateScalar() {
+	importPaths := []string{"github.com/n9te9/goliteql"}
 	for _, scalar := range g.config.Scalars {
+		if scalar.Package != "" {
+			importPaths = append(importPaths, scalar.Package)
+		}
+
+		for _, f := range []string{scalar.MarshalFunc, scalar.UnmarshalFunc} {
+			if f != "" {
+				importPath, _ := splitScalarFunc(f)
+				importPaths = append(importPaths, importPath)
+			}
+		}
+	}
+
+	sort.Strings(importPaths)
+
+	specs := make([]ast.Spec, 0, len(importPaths))
+	imported := make(map[string]struct{}, len(importPaths))
+	for _, importPath := range importPaths {
+		if _, ok := imported[importPath]; ok {
+			continue
+		}
+		imported[importPath] = struct{}{}
+
 		specs = append(specs, &ast.ImportSpec{
 			Path: &ast.BasicLit{
 				Kind:  token.STRING,
-				Value: fmt.Sprintf(`"%s"`, scalar.Package),
+				Value: fmt.Sprintf(`"%s"`, importPath),
 			},
 		})
 	}
@@ -42,6 +172,56 @@ func (g *Generator) generateScalar() {
 }
 
 func generateScalarUnmarshalJSON(scalar ScalarConfig) ast.Decl {
+	body := []ast.Stmt{
+		&ast.ReturnStmt{
+			Results: []ast.Expr{
+				ast.NewIdent(fmt.Sprintf("goliteql.UnmarshalScalar(data, (*%s)(s))", scalar.Type)),
+			},
+		},
+	}
+
+	if scalar.UnmarshalFunc != "" {
+		_, unmarshalFunc := splitScalarFunc(scalar.UnmarshalFunc)
+		body = []ast.Stmt{
+			&ast.AssignStmt{
+				Tok: token.DEFINE,
+				Lhs: []ast.Expr{
+					ast.NewIdent("v"),
+					ast.NewIdent("err"),
+				},
+				Rhs: []ast.Expr{
+					ast.NewIdent(fmt.Sprintf("goliteql.UnmarshalScalarFunc(data, %s)", unmarshalFunc)),
+				},
+			},
+			&ast.IfStmt{
+				Cond: &ast.BinaryExpr{
+					X:  ast.NewIdent("err"),
+					Op: token.NEQ,
+					Y:  ast.NewIdent("nil"),
+				},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ReturnStmt{
+							Results: []ast.Expr{ast.NewIdent("err")},
+						},
+					},
+				},
+			},
+			&ast.AssignStmt{
+				Tok: token.ASSIGN,
+				Lhs: []ast.Expr{
+					ast.NewIdent("*s"),
+				},
+				Rhs: []ast.Expr{
+					ast.NewIdent(fmt.Sprintf("%s(v)", scalar.Name)),
+				},
+			},
+			&ast.ReturnStmt{
+				Results: []ast.Expr{ast.NewIdent("nil")},
+			},
+		}
+	}
+
 	return &ast.FuncDecl{
 		Name: ast.NewIdent("UnmarshalJSON"),
 		Recv: &ast.FieldList{
@@ -78,31 +258,25 @@ func generateScalarUnmarshalJSON(scalar ScalarConfig) ast.Decl {
 			},
 		},
 		Body: &ast.BlockStmt{
-			List: []ast.Stmt{
-				&ast.ExprStmt{
-					X: &ast.CallExpr{
-						Fun: ast.NewIdent("panic"),
-						Args: []ast.Expr{
-							&ast.BasicLit{
-								Kind:  token.STRING,
-								Value: fmt.Sprintf(`"implement %s UnmarshalJSON"`, scalar.Name),
-							},
-						},
-					},
-				},
-			},
+			List: body,
 		},
 	}
 }
 
 func generateScalarMarshalJSON(scalar ScalarConfig) ast.Decl {
+	result := fmt.Sprintf("goliteql.MarshalScalar(%s(s))", scalar.Type)
+	if scalar.MarshalFunc != "" {
+		_, marshalFunc := splitScalarFunc(scalar.MarshalFunc)
+		result = fmt.Sprintf("goliteql.MarshalScalarFunc(%s(s), %s)", scalar.Type, marshalFunc)
+	}
+
 	return &ast.FuncDecl{
 		Name: ast.NewIdent("MarshalJSON"),
 		Recv: &ast.FieldList{
 			List: []*ast.Field{
 				{
 					Names: []*ast.Ident{ast.NewIdent("s")},
-					Type:  &ast.StarExpr{X: ast.NewIdent(scalar.Name)},
+					Type:  ast.NewIdent(scalar.Name),
 				},
 			},
 		},
@@ -127,15 +301,43 @@ func generateScalarMarshalJSON(scalar ScalarConfig) ast.Decl {
 		},
 		Body: &ast.BlockStmt{
 			List: []ast.Stmt{
-				&ast.ExprStmt{
-					X: &ast.CallExpr{
-						Fun: ast.NewIdent("panic"),
-						Args: []ast.Expr{
-							&ast.BasicLit{
-								Kind:  token.STRING,
-								Value: fmt.Sprintf(`"implement %s MarshalJSON"`, scalar.Name),
-							},
-						},
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent(result),
+					},
+				},
+			},
+		},
+	}
+}
+
+// generateScalarUnmarshalersDecl generates the scalarUnmarshalers variable that checks custom scalar variables with the UnmarshalJSON of their models.
+func generateScalarUnmarshalersDecl(scalars []ScalarConfig, typePrefix string) ast.Decl {
+	elts := make([]ast.Expr, 0, len(scalars))
+	for _, scalar := range scalars {
+		elts = append(elts, &ast.KeyValueExpr{
+			Key: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: strconv.Quote(scalar.Name),
+			},
+			Value: &ast.CallExpr{
+				Fun: &ast.IndexExpr{
+					X:     ast.NewIdent("executor.NewScalarUnmarshaler"),
+					Index: ast.NewIdent(fmt.Sprintf("%s.%s", typePrefix, scalar.Name)),
+				},
+			},
+		})
+	}
+
+	return &ast.GenDecl{
+		Tok: token.VAR,
+		Specs: []ast.Spec{
+			&ast.ValueSpec{
+				Names: []*ast.Ident{ast.NewIdent("scalarUnmarshalers")},
+				Values: []ast.Expr{
+					&ast.CompositeLit{
+						Type: ast.NewIdent("map[string]executor.ScalarUnmarshaler"),
+						Elts: elts,
 					},
 				},
 			},
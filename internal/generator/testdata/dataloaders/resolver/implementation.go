@@ -0,0 +1,74 @@
+package resolver
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"example.com/e2e/graphql/model"
+)
+
+// batches records the sorted keys of every call of BatchUserByID.
+var batches struct {
+	mu   sync.Mutex
+	keys []string
+}
+
+func (r *resolver) BatchUserByID(ctx context.Context, keys []string) ([]*model.User, []error) {
+	batches.mu.Lock()
+	batches.keys = append(batches.keys, strings.Join(slices.Sorted(slices.Values(keys)), ","))
+	batches.mu.Unlock()
+
+	users := make([]*model.User, 0, len(keys))
+	for _, key := range keys {
+		users = append(users, &model.User{Id: key, Name: "user " + key})
+	}
+
+	return users, nil
+}
+
+func (r *resolver) Posts(ctx context.Context) ([]model.Post, error) {
+	return []model.Post{{Id: "1"}, {Id: "2"}, {Id: "3"}}, nil
+}
+
+func (r *resolver) Batches(ctx context.Context) ([]string, error) {
+	batches.mu.Lock()
+	defer batches.mu.Unlock()
+
+	return batches.keys, nil
+}
+
+func (r *resolver) PostAdded(ctx context.Context) (<-chan model.Post, error) {
+	ch := make(chan model.Post)
+	go func() {
+		defer close(ch)
+		for _, id := range []string{"4", "4"} {
+			select {
+			case ch <- model.Post{Id: id}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+func (r *postResolver) Author(ctx context.Context, obj *model.Post) (model.User, error) {
+	user, err := LoadersFromContext(ctx).UserByID.Load(ctx, "u"+obj.Id)
+	if err != nil {
+		return model.User{}, err
+	}
+
+	return *user, nil
+}
+
+func (r *postResolver) Editor(ctx context.Context, obj *model.Post) (model.User, error) {
+	user, err := LoadersFromContext(ctx).UserByID.Load(ctx, "e"+obj.Id)
+	if err != nil {
+		return model.User{}, err
+	}
+
+	return *user, nil
+}
@@ -0,0 +1,72 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"example.com/e2e/graphql/model"
+)
+
+type roleKey struct{}
+
+func WithRole(ctx context.Context, role string) context.Context {
+	return context.WithValue(ctx, roleKey{}, role)
+}
+
+func (r *resolver) Posts(ctx context.Context, prefix *string) ([]model.Post, error) {
+	posts := []model.Post{
+		{Id: "1", Title: "hello", Secret: &model.Secret{Value: "s1"}},
+		{Id: "2", Title: "world"},
+	}
+
+	if prefix == nil {
+		return posts, nil
+	}
+
+	ret := make([]model.Post, 0, len(posts))
+	for _, post := range posts {
+		if strings.HasPrefix(post.Title, *prefix) {
+			ret = append(ret, post)
+		}
+	}
+
+	return ret, nil
+}
+
+func (r *resolver) Viewer(ctx context.Context) (*string, error) {
+	viewer := "viewer"
+	return &viewer, nil
+}
+
+func (r *directiveRoot) Upper(ctx context.Context, obj any, next func(ctx context.Context) (any, error)) (any, error) {
+	v, err := next(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.ToUpper(v.(string)), nil
+}
+
+func (r *directiveRoot) Auth(ctx context.Context, obj any, next func(ctx context.Context) (any, error), role string) (any, error) {
+	if ctx.Value(roleKey{}) != role {
+		return nil, fmt.Errorf("requires role %s", role)
+	}
+
+	return next(ctx)
+}
+
+func (r *directiveRoot) Trim(ctx context.Context, obj any, next func(ctx context.Context) (any, error)) (any, error) {
+	v, err := next(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := v.(*string)
+	if prefix == nil {
+		return prefix, nil
+	}
+
+	trimmed := strings.TrimSpace(*prefix)
+	return &trimmed, nil
+}
@@ -0,0 +1,14 @@
+package main
+
+import (
+	"net/http"
+
+	"example.com/e2e/graphql/resolver"
+)
+
+func newHandler() http.Handler {
+	r := resolver.NewResolver()
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.ServeHTTP(w, req.WithContext(resolver.WithRole(req.Context(), req.Header.Get("X-Role"))))
+	})
+}
@@ -0,0 +1,15 @@
+package resolver
+
+import (
+	"context"
+
+	"example.com/e2e/graphql/model"
+)
+
+func (r *resolver) Product(ctx context.Context, upc string) (*model.Product, error) {
+	return r.FindProductByUpc(ctx, upc)
+}
+
+func (r *resolver) FindProductByUpc(ctx context.Context, upc string) (*model.Product, error) {
+	return &model.Product{Upc: upc, Name: "product " + upc}, nil
+}
@@ -0,0 +1,24 @@
+package resolver
+
+import (
+	"context"
+	"fmt"
+
+	"example.com/e2e/graphql/model"
+)
+
+func (r *resolver) Posts(ctx context.Context) ([]model.Post, error) {
+	return []model.Post{
+		{Id: "p1", Title: "first"},
+		{Id: "p2", Title: "second"},
+	}, nil
+}
+
+func (r *postResolver) Comments(ctx context.Context, obj *model.Post, first *int) ([]model.Comment, error) {
+	comments := make([]model.Comment, 0, *first)
+	for i := range *first {
+		comments = append(comments, model.Comment{Id: fmt.Sprintf("%s-c%d", obj.Id, i+1)})
+	}
+
+	return comments, nil
+}
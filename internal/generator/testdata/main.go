@@ -0,0 +1,50 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+)
+
+type request struct {
+	Header map[string]string `json:"header"`
+	Body   string            `json:"body"`
+}
+
+type response struct {
+	Status int    `json:"status"`
+	Body   string `json:"body"`
+}
+
+// main serves the requests read from stdin one after another with the handler of newHandler and writes their responses to stdout.
+func main() {
+	var requests []request
+	if err := json.NewDecoder(os.Stdin).Decode(&requests); err != nil {
+		log.Fatalf("error decoding requests: %v", err)
+	}
+
+	handler := newHandler()
+	responses := make([]response, 0, len(requests))
+	for _, req := range requests {
+		r := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(req.Body))
+		r.Header.Set("Content-Type", "application/json")
+		for k, v := range req.Header {
+			r.Header.Set(k, v)
+		}
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		responses = append(responses, response{
+			Status: w.Code,
+			Body:   strings.TrimSpace(w.Body.String()),
+		})
+	}
+
+	if err := json.NewEncoder(os.Stdout).Encode(responses); err != nil {
+		log.Fatalf("error encoding responses: %v", err)
+	}
+}
@@ -0,0 +1,11 @@
+package main
+
+import (
+	"net/http"
+
+	"example.com/e2e/graphql/resolver"
+)
+
+func newHandler() http.Handler {
+	return resolver.NewResolver()
+}
@@ -0,0 +1,29 @@
+package resolver
+
+import (
+	"context"
+	"time"
+
+	"example.com/e2e/graphql/model"
+	"github.com/n9te9/goliteql/scalars"
+)
+
+func (r *resolver) Events(ctx context.Context, after *model.DateTime) ([]model.Event, error) {
+	events := []model.Event{
+		{Name: "opening", At: model.DateTime(scalars.DateTime(time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)))},
+		{Name: "closing", At: model.DateTime(scalars.DateTime(time.Date(2026, 1, 2, 18, 0, 0, 0, time.UTC)))},
+	}
+
+	if after == nil {
+		return events, nil
+	}
+
+	ret := make([]model.Event, 0, len(events))
+	for _, event := range events {
+		if time.Time(event.At).After(time.Time(*after)) {
+			ret = append(ret, event)
+		}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,18 @@
+package resolver
+
+import (
+	"context"
+
+	"example.com/e2e/graphql/model"
+)
+
+func (r *resolver) Posts(ctx context.Context) ([]model.Post, error) {
+	return []model.Post{
+		{Id: "p1", Title: "first"},
+		{Id: "p2", Title: "second"},
+	}, nil
+}
+
+func (r *resolver) Post(ctx context.Context, id string) (*model.Post, error) {
+	return &model.Post{Id: id, Title: "post " + id}, nil
+}
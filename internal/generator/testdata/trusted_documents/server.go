@@ -0,0 +1,18 @@
+package main
+
+import (
+	"net/http"
+
+	"example.com/e2e/graphql/resolver"
+	"github.com/n9te9/goliteql/executor"
+)
+
+const postsQuery = "query Posts { posts { id } }"
+
+func newHandler() http.Handler {
+	documents := executor.NewTrustedDocuments(map[string]string{
+		executor.QueryHash(postsQuery): postsQuery,
+	})
+
+	return resolver.NewResolver(resolver.WithTrustedDocuments(documents))
+}
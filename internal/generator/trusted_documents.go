@@ -0,0 +1,119 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/n9te9/goliteql/executor"
+	"github.com/n9te9/goliteql/query"
+	"github.com/n9te9/goliteql/schema"
+	"github.com/n9te9/goliteql/validator"
+)
+
+// BuildTrustedDocuments builds the trusted document manifest of the operation files under operationDirectory, keyed by the SHA-256 hash of each document without its surrounding whitespace.
+// Every document is validated against the schema the generated handler serves, and all violations are reported at once.
+// Clients hash the document of a single operation, so each file must hold exactly one operation besides its fragments.
+func BuildTrustedDocuments(config *Config, operationDirectory string) (map[string]string, error) {
+	s, err := newServedSchema(config)
+	if err != nil {
+		return nil, err
+	}
+	parser := query.NewParserWithLexer()
+	v := validator.NewValidator(s, parser)
+
+	documents := make(map[string]string)
+	var errs []error
+	err = filepath.Walk(operationDirectory, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() || !gqlFilePattern.MatchString(info.Name()) {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading operation file: %w", err)
+		}
+
+		if gqlErrs := v.Validate(content); len(gqlErrs) > 0 {
+			for _, gqlErr := range gqlErrs {
+				errs = append(errs, newOperationFileError(path, gqlErr))
+			}
+			return nil
+		}
+
+		doc, err := parser.Parse(content)
+		if err != nil {
+			return fmt.Errorf("error parsing operation file %s: %w", path, err)
+		}
+
+		if len(doc.Operations) != 1 {
+			errs = append(errs, fmt.Errorf("%s: operation file must hold exactly one operation, got %d", path, len(doc.Operations)))
+			return nil
+		}
+
+		document := strings.TrimSpace(string(content))
+		documents[executor.QueryHash(document)] = document
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error walking operation files: %w", err)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return documents, nil
+}
+
+// newServedSchema parses the schema of the generated handler, including the types federation adds to a subgraph.
+func newServedSchema(config *Config) (*schema.Schema, error) {
+	source, err := readGraphQLFiles(config.SchemaDirectory)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := parseSchema(source)
+	if err != nil {
+		return nil, err
+	}
+
+	if !config.Federation {
+		return s, nil
+	}
+
+	entities, err := extractFederationEntities(s)
+	if err != nil {
+		return nil, fmt.Errorf("error extracting federation entities: %w", err)
+	}
+
+	return parseSchema(newFederationSchemaSource(s, source, entities))
+}
+
+func parseSchema(source []byte) (*schema.Schema, error) {
+	s, err := schema.NewParser(schema.NewLexer()).Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing schema: %w", err)
+	}
+
+	s, err = s.Merge()
+	if err != nil {
+		return nil, fmt.Errorf("error merging schema: %w", err)
+	}
+
+	return s, nil
+}
+
+func newOperationFileError(path string, err executor.GraphQLError) error {
+	if len(err.Locations) == 0 {
+		return fmt.Errorf("%s: %s", path, err.Message)
+	}
+
+	return fmt.Errorf("%s:%d:%d: %s", path, err.Locations[0].Line, err.Locations[0].Column, err.Message)
+}
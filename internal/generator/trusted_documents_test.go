@@ -0,0 +1,88 @@
+package generator_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor"
+	"github.com/n9te9/goliteql/internal/generator"
+)
+
+func TestBuildTrustedDocuments(t *testing.T) {
+	postsQuery := "query Posts { posts { id } }"
+	postQuery := "query Post($id: ID!) { post(id: $id) { ...PostFields } }\n\nfragment PostFields on Post { title }"
+
+	tests := []struct {
+		name    string
+		files   map[string]string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name: "documents are keyed by their hash",
+			files: map[string]string{
+				"posts.graphql":   postsQuery + "\n",
+				"post/post.gql":   postQuery,
+				"posts.graphql~":  "not an operation file",
+				"post/README.txt": "not an operation file",
+			},
+			want: map[string]string{
+				executor.QueryHash(postsQuery): postsQuery,
+				executor.QueryHash(postQuery):  postQuery,
+			},
+		},
+		{
+			name: "all violations are reported",
+			files: map[string]string{
+				"posts.graphql": "query Posts { posts { id body author } }",
+			},
+			wantErr: "posts.graphql:1:26: cannot query field \"body\" on type \"Post\"\nposts.graphql:1:31: cannot query field \"author\" on type \"Post\"",
+		},
+		{
+			name: "several operations in a file",
+			files: map[string]string{
+				"posts.graphql": postsQuery + "\n\n" + postQuery,
+			},
+			wantErr: "posts.graphql: operation file must hold exactly one operation, got 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := generator.BuildTrustedDocuments(newConfig(t, "trusted_documents"), dir)
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatal("BuildTrustedDocuments() error = nil")
+				}
+
+				if d := cmp.Diff(tt.wantErr, strings.ReplaceAll(err.Error(), dir+string(filepath.Separator), "")); d != "" {
+					t.Errorf("BuildTrustedDocuments() error mismatch (-want +got):\n%s", d)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("BuildTrustedDocuments() error = %v", err)
+			}
+
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("BuildTrustedDocuments() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
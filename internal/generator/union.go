@@ -12,6 +12,7 @@ func generateUnionTypeDecls(unions schema.UnionDefinitions) []ast.Decl {
 
 	for _, u := range unions {
 		decls = append(decls, &ast.GenDecl{
+			Doc: generateDocComment(u.Description),
 			Tok: token.TYPE,
 			Specs: []ast.Spec{
 				&ast.TypeSpec{
@@ -0,0 +1,313 @@
+package generator
+
+import (
+	"bytes"
+	"go/ast"
+	"go/token"
+	"strconv"
+)
+
+func generateSchemaSourceDecl(source []byte) ast.Decl {
+	value := strconv.Quote(string(source))
+	if !bytes.ContainsRune(source, '`') {
+		value = "`" + string(source) + "`"
+	}
+
+	return &ast.GenDecl{
+		Tok: token.CONST,
+		Specs: []ast.Spec{
+			&ast.ValueSpec{
+				Names: []*ast.Ident{ast.NewIdent("schemaSource")},
+				Values: []ast.Expr{
+					&ast.BasicLit{
+						Kind:  token.STRING,
+						Value: value,
+					},
+				},
+			},
+		},
+	}
+}
+
+func generateNewSchemaFuncDecl() ast.Decl {
+	return &ast.FuncDecl{
+		Name: ast.NewIdent("newSchema"),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{},
+			Results: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Type: &ast.StarExpr{
+							X: &ast.SelectorExpr{
+								X:   ast.NewIdent("schema"),
+								Sel: ast.NewIdent("Schema"),
+							},
+						},
+					},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent("s"),
+						ast.NewIdent("err"),
+					},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{
+						ast.NewIdent("schema.NewParser(schema.NewLexer()).Parse([]byte(schemaSource))"),
+					},
+				},
+				generatePanicErrorHandlingStmt(),
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent("s"),
+						ast.NewIdent("err"),
+					},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						ast.NewIdent("s.Merge()"),
+					},
+				},
+				generatePanicErrorHandlingStmt(),
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent("s"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func generatePanicErrorHandlingStmt() ast.Stmt {
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("err"),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{
+					X: &ast.CallExpr{
+						Fun:  ast.NewIdent("panic"),
+						Args: []ast.Expr{ast.NewIdent("err")},
+					},
+				},
+			},
+		},
+	}
+}
+
+func generateValidationErrorsCond() (ast.Stmt, ast.Expr) {
+	init := &ast.AssignStmt{
+		Lhs: []ast.Expr{ast.NewIdent("errs")},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			ast.NewIdent("r.validator.ValidateDocument(parsedQuery)"),
+		},
+	}
+
+	cond := &ast.BinaryExpr{
+		X:  ast.NewIdent("len(errs)"),
+		Op: token.GTR,
+		Y:  &ast.BasicLit{Kind: token.INT, Value: "0"},
+	}
+
+	return init, cond
+}
+
+func generateServeHTTPValidationStmt() ast.Stmt {
+	init, cond := generateValidationErrorsCond()
+	return &ast.IfStmt{
+		Init: init,
+		Cond: cond,
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{
+					X: ast.NewIdent("w.WriteHeader(http.StatusBadRequest)"),
+				},
+				&ast.ExprStmt{
+					X: ast.NewIdent("json.NewEncoder(w).Encode(executor.NewErrorResponse(errs))"),
+				},
+				&ast.ReturnStmt{},
+			},
+		},
+	}
+}
+
+func generateSubscribeValidationStmt() ast.Stmt {
+	init, cond := generateValidationErrorsCond()
+	return &ast.IfStmt{
+		Init: init,
+		Cond: cond,
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent("nil"),
+						ast.NewIdent("executor.GraphQLErrors(errs)"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func generateServeHTTPCoerceVariablesErrorStmt() ast.Stmt {
+	return &ast.IfStmt{
+		Cond: generateCoerceVariablesErrorsCond(),
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{
+					X: ast.NewIdent("w.WriteHeader(http.StatusBadRequest)"),
+				},
+				&ast.ExprStmt{
+					X: ast.NewIdent("json.NewEncoder(w).Encode(executor.NewErrorResponse(errs))"),
+				},
+				&ast.ReturnStmt{},
+			},
+		},
+	}
+}
+
+func generateSubscribeCoerceVariablesErrorStmt() ast.Stmt {
+	return &ast.IfStmt{
+		Cond: generateCoerceVariablesErrorsCond(),
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent("nil"),
+						ast.NewIdent("executor.GraphQLErrors(errs)"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func generateCoerceVariablesAssignStmt(operation string) ast.Stmt {
+	return &ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("variables"),
+			ast.NewIdent("errs"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("executor"),
+					Sel: ast.NewIdent("CoerceVariableValues"),
+				},
+				Args: []ast.Expr{
+					ast.NewIdent("r.validator.Schema"),
+					ast.NewIdent(operation),
+					ast.NewIdent("request.Variables"),
+					ast.NewIdent("scalarUnmarshalers"),
+				},
+			},
+		},
+	}
+}
+
+func generateCoerceVariablesErrorsCond() ast.Expr {
+	return &ast.BinaryExpr{
+		X:  ast.NewIdent("len(errs)"),
+		Op: token.GTR,
+		Y:  &ast.BasicLit{Kind: token.INT, Value: "0"},
+	}
+}
+
+func generateGetOperationAssignStmt() ast.Stmt {
+	return &ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("operation"),
+			ast.NewIdent("err"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("utils"),
+					Sel: ast.NewIdent("GetOperation"),
+				},
+				Args: []ast.Expr{
+					ast.NewIdent("parsedQuery.Operations"),
+					ast.NewIdent("request.OperationName"),
+				},
+			},
+		},
+	}
+}
+
+func generateServeHTTPGetOperationErrorStmt() ast.Stmt {
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("err"),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{
+					X: ast.NewIdent("w.WriteHeader(http.StatusBadRequest)"),
+				},
+				&ast.ExprStmt{
+					X: ast.NewIdent("json.NewEncoder(w).Encode(executor.NewErrorResponse([]executor.GraphQLError{executor.NewOperationResolutionError(err)}))"),
+				},
+				&ast.ReturnStmt{},
+			},
+		},
+	}
+}
+
+// generatePlanKeyAssignStmt keys cached plans by operation name as well, since a document may hold several operations.
+func generatePlanKeyAssignStmt() ast.Stmt {
+	return &ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("planKey"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			ast.NewIdent("executor.PlanKey(request.OperationName, request.Query, operation.Selections, parsedQuery.FragmentDefinitions, variables)"),
+		},
+	}
+}
+
+// generateCachedPlanExecutionAssignStmt plans the root selections, evaluating @skip and @include with the coerced variables, only when no plan is cached under planKey.
+func generateCachedPlanExecutionAssignStmt() ast.Stmt {
+	return &ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("nodes"),
+			ast.NewIdent("err"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			ast.NewIdent("r.cache.PlanExecution(planKey, rootSelectionSet, parsedQuery.FragmentDefinitions, variables)"),
+		},
+	}
+}
+
+func generateServeHTTPPlanExecutionErrorStmt() ast.Stmt {
+	return &ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("err"),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ExprStmt{
+					X: ast.NewIdent("w.WriteHeader(http.StatusBadRequest)"),
+				},
+				&ast.ExprStmt{
+					X: ast.NewIdent("json.NewEncoder(w).Encode(executor.NewErrorResponse([]executor.GraphQLError{executor.NewGraphQLError(err)}))"),
+				},
+				&ast.ReturnStmt{},
+			},
+		},
+	}
+}
@@ -0,0 +1,345 @@
+package introspection_test
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor"
+	"github.com/n9te9/goliteql/query"
+	"github.com/n9te9/goliteql/query/utils"
+	"github.com/n9te9/goliteql/schema"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+var update = flag.Bool("update", false, "update the golden file from the gqlparser reference")
+
+const goldenFile = "introspection.golden.json"
+
+// unsupportedDirectives are part of the gqlparser prelude but are not implemented by goliteql.
+var unsupportedDirectives = map[string]struct{}{
+	"defer": {},
+	"oneOf": {},
+}
+
+func TestIntrospection(t *testing.T) {
+	schemaSource, err := os.ReadFile("schema.graphql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reference := normalize(t, gqlparserIntrospection(t, string(schemaSource)))
+	if *update {
+		b, err := json.MarshalIndent(reference, "", "  ")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(goldenFile, append(b, '\n'), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	b, err := os.ReadFile(goldenFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var golden any
+	if err := json.Unmarshal(b, &golden); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(golden, reference); diff != "" {
+		t.Fatalf("golden file is out of date, run go test with -update (-golden +gqlparser):\n%s", diff)
+	}
+
+	got := normalize(t, goliteqlIntrospection(t, schemaSource))
+	if diff := cmp.Diff(golden, got); diff != "" {
+		t.Errorf("introspection mismatch (-golden +got):\n%s", diff)
+	}
+}
+
+func goliteqlIntrospection(t *testing.T, schemaSource []byte) any {
+	t.Helper()
+
+	s, err := schema.NewParser(schema.NewLexer()).Parse(schemaSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = s.Merge()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q, err := os.ReadFile("introspection_query.graphql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := query.NewParserWithLexer().Parse(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operation, err := utils.GetOperation(doc.Operations, "IntrospectionQuery")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	introspection := executor.NewIntrospection(s)
+	data := make(map[string]any)
+	nodes, err := executor.PlanExecution(operation.Selections, doc.FragmentDefinitions, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, node := range nodes {
+		ret, err := introspection.Resolve(node, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data[node.Name] = ret
+	}
+
+	return data
+}
+
+// gqlparserIntrospection builds the response of introspection_query.graphql from the schema loaded by gqlparser.
+func gqlparserIntrospection(t *testing.T, schemaSource string) any {
+	t.Helper()
+
+	s, err := gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: schemaSource})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	types := make([]any, 0, len(s.Types))
+	for _, def := range s.Types {
+		types = append(types, fullType(s, def))
+	}
+
+	directives := make([]any, 0, len(s.Directives))
+	for _, d := range s.Directives {
+		locations := make([]any, 0, len(d.Locations))
+		for _, l := range d.Locations {
+			locations = append(locations, string(l))
+		}
+
+		directives = append(directives, map[string]any{
+			"name":         d.Name,
+			"description":  nullableString(d.Description),
+			"isRepeatable": d.IsRepeatable,
+			"locations":    locations,
+			"args":         inputValues(s, d.Arguments),
+		})
+	}
+
+	return map[string]any{
+		"__schema": map[string]any{
+			"description":      nullableString(s.Description),
+			"queryType":        namedType(s.Query),
+			"mutationType":     namedType(s.Mutation),
+			"subscriptionType": namedType(s.Subscription),
+			"types":            types,
+			"directives":       directives,
+		},
+	}
+}
+
+func namedType(def *ast.Definition) any {
+	if def == nil {
+		return nil
+	}
+
+	return map[string]any{"name": def.Name}
+}
+
+func fullType(s *ast.Schema, def *ast.Definition) any {
+	ret := map[string]any{
+		"__typename":     "__Type",
+		"kind":           string(def.Kind),
+		"name":           def.Name,
+		"description":    nullableString(def.Description),
+		"specifiedByURL": nil,
+		"fields":         nil,
+		"inputFields":    nil,
+		"interfaces":     nil,
+		"enumValues":     nil,
+		"possibleTypes":  nil,
+	}
+
+	switch def.Kind {
+	case ast.Scalar:
+		if d := def.Directives.ForName("specifiedBy"); d != nil {
+			ret["specifiedByURL"] = d.Arguments.ForName("url").Value.Raw
+		}
+	case ast.Object, ast.Interface:
+		fields := make([]any, 0, len(def.Fields))
+		for _, f := range def.Fields {
+			if strings.HasPrefix(f.Name, "__") {
+				continue
+			}
+
+			fields = append(fields, map[string]any{
+				"name":              f.Name,
+				"description":       nullableString(f.Description),
+				"args":              inputValues(s, f.Arguments),
+				"type":              typeRef(s, f.Type),
+				"isDeprecated":      f.Directives.ForName("deprecated") != nil,
+				"deprecationReason": deprecationReason(f.Directives),
+			})
+		}
+		ret["fields"] = fields
+
+		interfaces := make([]any, 0, len(def.Interfaces))
+		for _, name := range def.Interfaces {
+			interfaces = append(interfaces, typeRef(s, ast.NamedType(name, nil)))
+		}
+		ret["interfaces"] = interfaces
+	case ast.Enum:
+		values := make([]any, 0, len(def.EnumValues))
+		for _, v := range def.EnumValues {
+			values = append(values, map[string]any{
+				"name":              v.Name,
+				"description":       nullableString(v.Description),
+				"isDeprecated":      v.Directives.ForName("deprecated") != nil,
+				"deprecationReason": deprecationReason(v.Directives),
+			})
+		}
+		ret["enumValues"] = values
+	case ast.InputObject:
+		fields := make([]any, 0, len(def.Fields))
+		for _, f := range def.Fields {
+			fields = append(fields, inputValue(s, f.Name, f.Description, f.DefaultValue, f.Type, f.Directives))
+		}
+		ret["inputFields"] = fields
+	}
+
+	if def.IsAbstractType() {
+		possibleTypes := make([]any, 0)
+		for _, p := range s.GetPossibleTypes(def) {
+			// gqlparser also lists interfaces implementing def, which the spec excludes from possibleTypes.
+			if p.Kind != ast.Object {
+				continue
+			}
+
+			possibleTypes = append(possibleTypes, typeRef(s, ast.NamedType(p.Name, nil)))
+		}
+		ret["possibleTypes"] = possibleTypes
+	}
+
+	return ret
+}
+
+func inputValues(s *ast.Schema, args ast.ArgumentDefinitionList) []any {
+	ret := make([]any, 0, len(args))
+	for _, arg := range args {
+		ret = append(ret, inputValue(s, arg.Name, arg.Description, arg.DefaultValue, arg.Type, arg.Directives))
+	}
+
+	return ret
+}
+
+func inputValue(s *ast.Schema, name, description string, defaultValue *ast.Value, typ *ast.Type, directives ast.DirectiveList) any {
+	var def any
+	if defaultValue != nil {
+		def = defaultValue.String()
+	}
+
+	return map[string]any{
+		"name":              name,
+		"description":       nullableString(description),
+		"type":              typeRef(s, typ),
+		"defaultValue":      def,
+		"isDeprecated":      directives.ForName("deprecated") != nil,
+		"deprecationReason": deprecationReason(directives),
+	}
+}
+
+func typeRef(s *ast.Schema, typ *ast.Type) any {
+	if typ == nil {
+		return nil
+	}
+
+	if typ.NonNull {
+		inner := *typ
+		inner.NonNull = false
+		return map[string]any{"kind": "NON_NULL", "name": nil, "ofType": typeRef(s, &inner)}
+	}
+
+	if typ.Elem != nil {
+		return map[string]any{"kind": "LIST", "name": nil, "ofType": typeRef(s, typ.Elem)}
+	}
+
+	return map[string]any{"kind": string(s.Types[typ.NamedType].Kind), "name": typ.NamedType, "ofType": nil}
+}
+
+func deprecationReason(directives ast.DirectiveList) any {
+	d := directives.ForName("deprecated")
+	if d == nil {
+		return nil
+	}
+
+	if arg := d.Arguments.ForName("reason"); arg != nil {
+		return arg.Value.Raw
+	}
+
+	return "No longer supported"
+}
+
+func nullableString(s string) any {
+	if s == "" {
+		return nil
+	}
+
+	return s
+}
+
+// normalize round-trips v through JSON and sorts every list whose order is not defined by the schema source.
+func normalize(t *testing.T, v any) any {
+	t.Helper()
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ret map[string]any
+	if err := json.Unmarshal(b, &ret); err != nil {
+		t.Fatal(err)
+	}
+
+	s := ret["__schema"].(map[string]any)
+
+	types := s["types"].([]any)
+	for _, typ := range types {
+		if possibleTypes, ok := typ.(map[string]any)["possibleTypes"].([]any); ok {
+			sortByName(possibleTypes)
+		}
+	}
+	sortByName(types)
+
+	directives := make([]any, 0)
+	for _, d := range s["directives"].([]any) {
+		if _, ok := unsupportedDirectives[d.(map[string]any)["name"].(string)]; !ok {
+			directives = append(directives, d)
+		}
+	}
+	sortByName(directives)
+	s["directives"] = directives
+
+	return ret
+}
+
+func sortByName(list []any) {
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].(map[string]any)["name"].(string) < list[j].(map[string]any)["name"].(string)
+	})
+}
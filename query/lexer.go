@@ -69,12 +69,12 @@ func newBlockStringValueToken(input []byte, cur, col, line int) (*Token, int, in
 	start := cur
 	cur += 3
 
-	tokenStartLine := line
+	tokenStartLine, tokenStartCol := line, col
+	col += 3
 	for cur+2 < len(input) {
 		if input[cur] == '"' && input[cur+1] == '"' && input[cur+2] == '"' {
 			break
 		}
-		cur++
 
 		if input[cur] == '\n' {
 			line++
@@ -82,14 +82,16 @@ func newBlockStringValueToken(input []byte, cur, col, line int) (*Token, int, in
 		} else {
 			col++
 		}
+		cur++
 	}
 
-	if cur+2 > len(input) {
-		return nil, -1, -1, -1, fmt.Errorf("unterminated string at line %d, column %d", tokenStartLine, col)
+	if cur+2 >= len(input) {
+		return nil, -1, -1, -1, fmt.Errorf("unterminated string at line %d, column %d", tokenStartLine, tokenStartCol)
 	}
 	cur += 3
+	col += 3
 
-	return &Token{Type: Value, Value: input[start:cur], Column: col, Line: tokenStartLine}, cur, line, col, nil
+	return &Token{Type: Value, Value: input[start:cur], Column: tokenStartCol, Line: tokenStartLine}, cur, line, col, nil
 }
 
 func newStringValueToken(input []byte, cur, col, line int) (*Token, int, int, int, error) {
@@ -97,7 +99,7 @@ func newStringValueToken(input []byte, cur, col, line int) (*Token, int, int, in
 		return newBlockStringValueToken(input, cur, col, line)
 	}
 
-	start := cur
+	start, startCol := cur, col
 	cur++
 	escape := false
 	for (cur < len(input) && input[cur] != '"') || escape {
@@ -107,27 +109,25 @@ func newStringValueToken(input []byte, cur, col, line int) (*Token, int, int, in
 			escape = false
 		}
 		cur++
-		col++
 	}
 
 	if cur >= len(input) {
-		return nil, -1, -1, -1, fmt.Errorf("unterminated string at line %d, column %d", line, col)
+		return nil, -1, -1, -1, fmt.Errorf("unterminated string at line %d, column %d", line, startCol)
 	}
 
-	return &Token{Type: Value, Value: input[start : cur+1], Column: col, Line: line}, cur + 1, line, col, nil
+	return &Token{Type: Value, Value: input[start : cur+1], Column: startCol, Line: line}, cur + 1, line, startCol + cur + 1 - start, nil
 }
 
 func newValueToken(input []byte, cur, col, line int) (*Token, int, int, int) {
 	start := cur
-	for cur < len(input) && unicode.IsLetter(rune(input[cur])) || unicode.IsDigit(rune(input[cur])) || input[cur] == '.' {
+	for cur < len(input) && (unicode.IsLetter(rune(input[cur])) || unicode.IsDigit(rune(input[cur])) || input[cur] == '_' || input[cur] == '.') {
 		cur++
-		col++
 	}
 
 	if tokenType, ok := queryKeywords[string(input[start:cur])]; ok {
-		return &Token{Type: tokenType, Value: input[start:cur], Column: col, Line: line}, cur, line, col
+		return &Token{Type: tokenType, Value: input[start:cur], Column: col, Line: line}, cur, line, col + cur - start
 	}
-	return &Token{Type: Name, Value: input[start:cur], Column: col, Line: line}, cur, line, col
+	return &Token{Type: Name, Value: input[start:cur], Column: col, Line: line}, cur, line, col + cur - start
 }
 
 func newEOFToken(col, line int) *Token {
@@ -260,7 +260,6 @@ func (l *Lexer) Lex(input []byte) (Tokens, error) {
 			if unicode.IsLetter(rune(input[cur])) || unicode.IsDigit(rune(input[cur])) {
 				token, cur, line, col = newValueToken(input, cur, col, line)
 				tokens = append(tokens, token)
-				col += len(token.Value)
 				continue
 			}
 
@@ -271,7 +270,6 @@ func (l *Lexer) Lex(input []byte) (Tokens, error) {
 				}
 
 				tokens = append(tokens, token)
-				col += len(token.Value) + 2
 				continue
 			}
 		}
@@ -535,7 +535,7 @@ func TestQueryLex(t *testing.T) {
 			input: []byte(`query {
 					user(name: "Alice)
 			}`),
-			wantErr: errors.New("unterminated string at line 2, column 32"),
+			wantErr: errors.New("unterminated string at line 2, column 17"),
 		}, {
 			name:  "Single directive with complex arguments",
 			input: []byte(`query { user { name @include(if: true, reason: "test") } }`),
@@ -15,6 +15,15 @@ const (
 	SubscriptionOperation OperationType = "subscription"
 )
 
+type Position struct {
+	Line   int
+	Column int
+}
+
+func newPosition(token *Token) Position {
+	return Position{Line: token.Line, Column: token.Column}
+}
+
 type FieldType struct {
 	Name     []byte
 	Nullable bool
@@ -26,11 +35,13 @@ type Variable struct {
 	Name         []byte
 	Type         *FieldType
 	DefaultValue []byte
+	Position     Position
 }
 
 type Directive struct {
 	Name      []byte
 	Arguments []*DirectiveArgument
+	Position  Position
 }
 
 type Operation struct {
@@ -39,6 +50,7 @@ type Operation struct {
 	Variables     []*Variable
 	Selections    []Selection
 	Directives    []*Directive
+	Position      Position
 }
 
 type Operations []*Operation
@@ -79,8 +91,9 @@ type Selection interface {
 }
 
 type Argument struct {
-	Name  []byte
-	Value []byte
+	Name     []byte
+	Value    []byte
+	Position Position
 }
 
 func (a *Argument) IsVariable() bool {
@@ -98,17 +111,29 @@ type DirectiveArgument struct {
 	Name       []byte
 	Value      []byte
 	IsVariable bool
+	Position   Position
 }
 
 type Field struct {
+	Alias      []byte
 	Name       []byte
 	Arguments  []*Argument
 	Selections []Selection
 	Directives []*Directive
+	Position   Position
 }
 
 func (f *Field) isSelection() {}
 
+// ResponseName returns the key of the field in the response, which is its alias if it has one.
+func (f *Field) ResponseName() []byte {
+	if len(f.Alias) > 0 {
+		return f.Alias
+	}
+
+	return f.Name
+}
+
 func (f *Field) GetSelections() []Selection {
 	return f.Selections
 }
@@ -116,6 +141,7 @@ func (f *Field) GetSelections() []Selection {
 type FragmentSpread struct {
 	Name       []byte
 	Directives []*Directive
+	Position   Position
 }
 
 func (f *FragmentSpread) isSelection() {}
@@ -128,6 +154,7 @@ type InlineFragment struct {
 	TypeCondition []byte
 	Selections    []Selection
 	Directives    []*Directive
+	Position      Position
 }
 
 func (f *InlineFragment) isSelection() {}
@@ -159,6 +186,7 @@ type FragmentDefinition struct {
 	Name          []byte
 	BasedTypeName []byte
 	Selections    []Selection
+	Position      Position
 }
 
 func (f *FragmentDefinition) isSelection() {}
@@ -200,8 +228,9 @@ func (p *Parser) Parse(input []byte) (*Document, error) {
 		Operations: make([]*Operation, 0),
 	}
 
-	for cur < len(tokens) {
-		if tokens[cur].Type.IsOperation() {
+	for cur < len(tokens) && tokens[cur].Type != EOF {
+		switch {
+		case tokens[cur].Type.IsOperation(), tokens[cur].Type == CurlyOpen:
 			op, newCur, err := p.parseOperation(tokens, cur)
 			if err != nil {
 				return nil, err
@@ -209,9 +238,7 @@ func (p *Parser) Parse(input []byte) (*Document, error) {
 
 			cur = newCur
 			doc.Operations = append(doc.Operations, op)
-		}
-
-		if tokens[cur].Type == Fragment {
+		case tokens[cur].Type == Fragment:
 			fragmentDefinition, newCur, err := p.parseFragmentDefinition(tokens, cur)
 			if err != nil {
 				return nil, err
@@ -219,10 +246,8 @@ func (p *Parser) Parse(input []byte) (*Document, error) {
 
 			cur = newCur
 			doc.FragmentDefinitions = append(doc.FragmentDefinitions, fragmentDefinition)
-		}
-
-		if tokens[cur].Type == EOF {
-			break
+		default:
+			return nil, fmt.Errorf("expected operation or fragment definition but got %s", tokens[cur].Value)
 		}
 	}
 
@@ -230,6 +255,7 @@ func (p *Parser) Parse(input []byte) (*Document, error) {
 }
 
 func (p *Parser) parseFragmentDefinition(tokens Tokens, cur int) (*FragmentDefinition, int, error) {
+	position := newPosition(tokens[cur])
 	cur++
 	if tokens[cur].Type != Name {
 		return nil, cur, fmt.Errorf("expected fragment name but got %s", tokens[cur].Value)
@@ -270,10 +296,21 @@ func (p *Parser) parseFragmentDefinition(tokens Tokens, cur int) (*FragmentDefin
 		Name:          fragmentName,
 		BasedTypeName: typeName,
 		Selections:    selections,
+		Position:      position,
 	}, cur, nil
 }
 
 func (p *Parser) parseOperation(tokens Tokens, cur int) (*Operation, int, error) {
+	position := newPosition(tokens[cur])
+
+	// a query without a name, variables and directives may be written as its selection set alone
+	if tokens[cur].Type == CurlyOpen {
+		return p.parseOperationSelectionSet(tokens, cur, &Operation{
+			OperationType: QueryOperation,
+			Position:      position,
+		})
+	}
+
 	operationType := OperationType(tokens[cur].Value)
 	cur++
 
@@ -286,6 +323,7 @@ func (p *Parser) parseOperation(tokens Tokens, cur int) (*Operation, int, error)
 	op := &Operation{
 		OperationType: operationType,
 		Name:          operationName,
+		Position:      position,
 	}
 
 	if tokens[cur].Type == ParenOpen {
@@ -307,6 +345,10 @@ func (p *Parser) parseOperation(tokens Tokens, cur int) (*Operation, int, error)
 		op.Directives = append(op.Directives, directive)
 	}
 
+	return p.parseOperationSelectionSet(tokens, cur, op)
+}
+
+func (p *Parser) parseOperationSelectionSet(tokens Tokens, cur int, op *Operation) (*Operation, int, error) {
 	if tokens[cur].Type != CurlyOpen {
 		return nil, cur, fmt.Errorf("expected { after operation")
 	}
@@ -343,34 +385,33 @@ func (p *Parser) parseSelections(tokens Tokens, cur int) ([]Selection, int, erro
 
 func (p *Parser) parseSelection(tokens Tokens, cur int) (Selection, int, error) {
 	if tokens[cur].Type == Spread {
+		position := newPosition(tokens[cur])
 		cur++
-		return p.parseFragment(tokens, cur)
+		return p.parseFragment(tokens, cur, position)
 	}
 
 	return p.parseField(tokens, cur)
 }
 
-func (p *Parser) parseFragment(tokens Tokens, cur int) (Selection, int, error) {
+func (p *Parser) parseFragment(tokens Tokens, cur int, position Position) (Selection, int, error) {
 	if tokens[cur].Type == On {
 		cur++
-		return p.parseInlineFragment(tokens, cur)
-	}
+		if tokens[cur].Type != Name {
+			return nil, cur, fmt.Errorf("expected type name but got %s", tokens[cur].Value)
+		}
 
-	if tokens[cur].Type == CurlyOpen {
-		cur++
+		return p.parseInlineFragment(tokens, cur+1, position, tokens[cur].Value)
 	}
 
-	return p.parseFragmentSpread(tokens, cur)
-}
-
-func (p *Parser) parseInlineFragment(tokens Tokens, cur int) (*InlineFragment, int, error) {
-	if tokens[cur].Type != Name {
-		return nil, cur, fmt.Errorf("expected type name but got %s", tokens[cur].Value)
+	// an inline fragment may omit its type condition
+	if tokens[cur].Type == CurlyOpen || tokens[cur].Type == At {
+		return p.parseInlineFragment(tokens, cur, position, nil)
 	}
 
-	v := tokens[cur].Value
-	cur++
+	return p.parseFragmentSpread(tokens, cur, position)
+}
 
+func (p *Parser) parseInlineFragment(tokens Tokens, cur int, position Position, typeCondition []byte) (*InlineFragment, int, error) {
 	var directives []*Directive = nil
 	for tokens[cur].Type == At {
 		cur++
@@ -385,7 +426,7 @@ func (p *Parser) parseInlineFragment(tokens Tokens, cur int) (*InlineFragment, i
 	if tokens[cur].Type == CurlyOpen {
 		cur++
 	} else {
-		return nil, cur, fmt.Errorf("expected { after type name")
+		return nil, cur, fmt.Errorf("expected { after inline fragment")
 	}
 
 	selections, newCur, err := p.parseSelections(tokens, cur)
@@ -395,13 +436,14 @@ func (p *Parser) parseInlineFragment(tokens Tokens, cur int) (*InlineFragment, i
 	cur = newCur
 
 	return &InlineFragment{
-		TypeCondition: v,
+		TypeCondition: typeCondition,
 		Selections:    selections,
 		Directives:    directives,
+		Position:      position,
 	}, cur + 1, nil
 }
 
-func (p *Parser) parseFragmentSpread(tokens Tokens, cur int) (*FragmentSpread, int, error) {
+func (p *Parser) parseFragmentSpread(tokens Tokens, cur int, position Position) (*FragmentSpread, int, error) {
 	if tokens[cur].Type != Name {
 		return nil, cur, fmt.Errorf("expected fragment name but got %s", tokens[cur].Value)
 	}
@@ -423,6 +465,7 @@ func (p *Parser) parseFragmentSpread(tokens Tokens, cur int) (*FragmentSpread, i
 	return &FragmentSpread{
 		Name:       v,
 		Directives: directives,
+		Position:   position,
 	}, cur, nil
 }
 
@@ -431,6 +474,7 @@ func (p *Parser) parseDirective(tokens Tokens, cur int) (*Directive, int, error)
 		return nil, cur, fmt.Errorf("expected directive name but got %s", tokens[cur].Value)
 	}
 
+	position := newPosition(tokens[cur-1])
 	v := tokens[cur].Value
 
 	cur++
@@ -447,6 +491,7 @@ func (p *Parser) parseDirective(tokens Tokens, cur int) (*Directive, int, error)
 	return &Directive{
 		Arguments: arguments,
 		Name:      v,
+		Position:  position,
 	}, cur, nil
 }
 
@@ -478,6 +523,7 @@ func (p *Parser) parseDirectiveArgument(tokens Tokens, cur int) (*DirectiveArgum
 	}
 
 	name := tokens[cur].Value
+	position := newPosition(tokens[cur])
 	cur++
 
 	if tokens[cur].Type != Colon {
@@ -498,7 +544,8 @@ func (p *Parser) parseDirectiveArgument(tokens Tokens, cur int) (*DirectiveArgum
 			Name:       name,
 			Value:      tokens[cur].Value,
 			IsVariable: isVariable,
-		}, cur, nil
+			Position:   position,
+		}, cur + 1, nil
 	}
 
 	if tokens[cur].Type == Value || tokens[cur].Type == Name {
@@ -506,6 +553,7 @@ func (p *Parser) parseDirectiveArgument(tokens Tokens, cur int) (*DirectiveArgum
 			Name:       name,
 			Value:      tokens[cur].Value,
 			IsVariable: isVariable,
+			Position:   position,
 		}, cur + 1, nil
 	}
 
@@ -519,6 +567,7 @@ func (p *Parser) parseDirectiveArgument(tokens Tokens, cur int) (*DirectiveArgum
 			Name:       name,
 			Value:      newValue,
 			IsVariable: isVariable,
+			Position:   position,
 		}, newCur, nil
 	}
 
@@ -532,6 +581,7 @@ func (p *Parser) parseDirectiveArgument(tokens Tokens, cur int) (*DirectiveArgum
 			Name:       name,
 			Value:      newValue,
 			IsVariable: isVariable,
+			Position:   position,
 		}, newCur, nil
 	}
 
@@ -544,10 +594,22 @@ func (p *Parser) parseField(tokens Tokens, cur int) (*Field, int, error) {
 	}
 
 	field := &Field{
-		Name: tokens[cur].Value,
+		Name:     tokens[cur].Value,
+		Position: newPosition(tokens[cur]),
 	}
 	cur++
 
+	if tokens[cur].Type == Colon {
+		cur++
+		if tokens[cur].Type != Name {
+			return nil, cur, fmt.Errorf("expected field but got %s at %d row, %d col", tokens[cur].Value, tokens[cur].Line, tokens[cur].Column)
+		}
+
+		field.Alias = field.Name
+		field.Name = tokens[cur].Value
+		cur++
+	}
+
 	if tokens[cur].Type == ParenOpen {
 		arguments, newCur, err := p.parseFieldArguments(tokens, cur)
 		if err != nil {
@@ -614,7 +676,8 @@ func (p *Parser) parseFieldArgument(tokens Tokens, cur int) (*Argument, int, err
 	}
 
 	argument := &Argument{
-		Name: tokens[cur].Value,
+		Name:     tokens[cur].Value,
+		Position: newPosition(tokens[cur]),
 	}
 	cur++
 
@@ -623,6 +686,16 @@ func (p *Parser) parseFieldArgument(tokens Tokens, cur int) (*Argument, int, err
 	}
 	cur++
 
+	if tokens[cur].Type == CurlyOpen || tokens[cur].Type == BracketOpen {
+		v, newCur, err := p.parseDefaultValue(tokens, cur)
+		if err != nil {
+			return nil, newCur, err
+		}
+		argument.Value = v
+
+		return argument, newCur, nil
+	}
+
 	v := make([]byte, 0)
 	for tokens[cur].Type != Comma && tokens[cur].Type != ParenClose {
 		v = append(v, tokens[cur].Value...)
@@ -665,6 +738,7 @@ func (p *Parser) parseOperationVariable(tokens Tokens, cur int) (*Variable, int,
 	if tokens[cur].Type != Dollar {
 		return nil, cur, fmt.Errorf("expected $ before variable")
 	}
+	position := newPosition(tokens[cur])
 	cur++
 
 	if tokens[cur].Type != Name {
@@ -705,6 +779,7 @@ func (p *Parser) parseOperationVariable(tokens Tokens, cur int) (*Variable, int,
 		Name:         variableName,
 		Type:         variableType,
 		DefaultValue: defaultValue,
+		Position:     position,
 	}, cur, nil
 }
 
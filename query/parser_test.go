@@ -1,6 +1,7 @@
 package query_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -261,6 +262,40 @@ func TestQueryParse(t *testing.T) {
 					},
 				},
 			},
+		}, {
+			name: "Parse field with object list argument",
+			input: []byte(`query {
+				_entities(representations: [{ __typename: "Product", upc: "1" }, { __typename: "Product", upc: "2" }], first: 2) {
+					__typename
+				}
+			}`),
+			expected: &query.Document{
+				Operations: []*query.Operation{
+					{
+						OperationType: query.QueryOperation,
+						Selections: []query.Selection{
+							&query.Field{
+								Name: []byte("_entities"),
+								Arguments: []*query.Argument{
+									{
+										Name:  []byte("representations"),
+										Value: []byte(`[{__typename:"Product",upc:"1"},{__typename:"Product",upc:"2"}]`),
+									},
+									{
+										Name:  []byte("first"),
+										Value: []byte("2"),
+									},
+								},
+								Selections: []query.Selection{
+									&query.Field{
+										Name: []byte("__typename"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 		}, {
 			name: "Parse simple query selection",
 			input: []byte(`query MyQuery {
@@ -437,6 +472,55 @@ func TestQueryParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Parse fragment spread with a variable directive argument",
+			input: []byte(`query MyQuery($withFragment: Boolean!) {
+				field {
+					...FragmentName @include(if: $withFragment)
+				}
+			}`),
+			expected: &query.Document{
+				Operations: []*query.Operation{
+					{
+						OperationType: query.QueryOperation,
+						Name:          "MyQuery",
+						Variables: []*query.Variable{
+							{
+								Name: []byte("withFragment"),
+								Type: &query.FieldType{
+									Name:     []byte("Boolean"),
+									Nullable: false,
+									IsList:   false,
+								},
+								DefaultValue: nil,
+							},
+						},
+						Selections: []query.Selection{
+							&query.Field{
+								Name: []byte("field"),
+								Selections: []query.Selection{
+									&query.FragmentSpread{
+										Name: []byte("FragmentName"),
+										Directives: []*query.Directive{
+											{
+												Name: []byte("include"),
+												Arguments: []*query.DirectiveArgument{
+													{
+														Name:       []byte("if"),
+														Value:      []byte("withFragment"),
+														IsVariable: true,
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Parse fragment spread with multiple directives",
 			input: []byte(`query MyQuery {
@@ -638,6 +722,109 @@ func TestQueryParse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Parse query with aliases",
+			input: []byte(`query MyQuery {
+				small: picture(size: 10)
+				large: picture(size: 100)
+				user {
+					full_name: name
+				}
+			}`),
+			expected: &query.Document{
+				Operations: []*query.Operation{
+					{
+						OperationType: query.QueryOperation,
+						Name:          "MyQuery",
+						Selections: []query.Selection{
+							&query.Field{
+								Alias: []byte("small"),
+								Name:  []byte("picture"),
+								Arguments: []*query.Argument{
+									{
+										Name:  []byte("size"),
+										Value: []byte("10"),
+									},
+								},
+							},
+							&query.Field{
+								Alias: []byte("large"),
+								Name:  []byte("picture"),
+								Arguments: []*query.Argument{
+									{
+										Name:  []byte("size"),
+										Value: []byte("100"),
+									},
+								},
+							},
+							&query.Field{
+								Name: []byte("user"),
+								Selections: []query.Selection{
+									&query.Field{
+										Alias: []byte("full_name"),
+										Name:  []byte("name"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Parse inline fragment without type condition",
+			input: []byte(`query MyQuery {
+				user {
+					... @include(if: $withName) {
+						name
+					}
+					... {
+						id
+					}
+				}
+			}`),
+			expected: &query.Document{
+				Operations: []*query.Operation{
+					{
+						OperationType: query.QueryOperation,
+						Name:          "MyQuery",
+						Selections: []query.Selection{
+							&query.Field{
+								Name: []byte("user"),
+								Selections: []query.Selection{
+									&query.InlineFragment{
+										Directives: []*query.Directive{
+											{
+												Name: []byte("include"),
+												Arguments: []*query.DirectiveArgument{
+													{
+														Name:       []byte("if"),
+														Value:      []byte("withName"),
+														IsVariable: true,
+													},
+												},
+											},
+										},
+										Selections: []query.Selection{
+											&query.Field{
+												Name: []byte("name"),
+											},
+										},
+									},
+									&query.InlineFragment{
+										Selections: []query.Selection{
+											&query.Field{
+												Name: []byte("id"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Parse query operation with simple directive",
 			input: []byte(`query MyQuery @deprecated {
@@ -753,6 +940,36 @@ func TestQueryParse(t *testing.T) {
 					},
 				},
 			},
+		}, {
+			name:  "Parse shorthand query operation",
+			input: []byte(`{ posts users }`),
+			expected: &query.Document{
+				Operations: []*query.Operation{
+					{
+						OperationType: query.QueryOperation,
+						Selections: []query.Selection{
+							&query.Field{
+								Name: []byte("posts"),
+							},
+							&query.Field{
+								Name: []byte("users"),
+							},
+						},
+					},
+				},
+			},
+		}, {
+			name:    "Unexpected token at document level",
+			input:   []byte(`posts { id }`),
+			wantErr: errors.New("expected operation or fragment definition but got posts"),
+		}, {
+			name:    "Missing selection set of fragment definition",
+			input:   []byte(`fragment F on User id { name }`),
+			wantErr: errors.New("expected { after type name"),
+		}, {
+			name:    "Missing selection set of inline fragment",
+			input:   []byte(`{ users { ... on User id } }`),
+			wantErr: errors.New("expected { after inline fragment"),
 		}, {
 			name: "Parse fragment definition",
 			input: []byte(`fragment MyFragment on User {
@@ -780,7 +997,7 @@ func TestQueryParse(t *testing.T) {
 	}
 
 	opts := cmp.FilterPath(func(p cmp.Path) bool {
-		return p.Last().String() == ".tokens" || p.Last().String() == ".isVariable"
+		return p.Last().String() == ".tokens" || p.Last().String() == ".isVariable" || p.Last().String() == ".Position"
 	}, cmp.Ignore())
 
 	for _, tt := range tests {
@@ -804,3 +1021,115 @@ func TestQueryParse(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryParsePosition(t *testing.T) {
+	input := []byte(`query GetUser($id: ID!, $withPosts: Boolean = false) {
+	user(id: $id) {
+		name @include(if: $withPosts)
+		...UserFragment
+		... on Admin {
+			role
+		}
+	}
+}
+
+fragment UserFragment on User {
+	id
+}`)
+
+	expected := &query.Document{
+		Operations: []*query.Operation{
+			{
+				OperationType: query.QueryOperation,
+				Name:          "GetUser",
+				Position:      query.Position{Line: 1, Column: 1},
+				Variables: []*query.Variable{
+					{
+						Name:     []byte("id"),
+						Type:     &query.FieldType{Name: []byte("ID"), Nullable: false},
+						Position: query.Position{Line: 1, Column: 15},
+					},
+					{
+						Name:         []byte("withPosts"),
+						Type:         &query.FieldType{Name: []byte("Boolean"), Nullable: true},
+						DefaultValue: []byte("false"),
+						Position:     query.Position{Line: 1, Column: 25},
+					},
+				},
+				Selections: []query.Selection{
+					&query.Field{
+						Name:     []byte("user"),
+						Position: query.Position{Line: 2, Column: 2},
+						Arguments: []*query.Argument{
+							{
+								Name:     []byte("id"),
+								Value:    []byte("$id"),
+								Position: query.Position{Line: 2, Column: 7},
+							},
+						},
+						Selections: []query.Selection{
+							&query.Field{
+								Name:     []byte("name"),
+								Position: query.Position{Line: 3, Column: 3},
+								Directives: []*query.Directive{
+									{
+										Name:     []byte("include"),
+										Position: query.Position{Line: 3, Column: 8},
+										Arguments: []*query.DirectiveArgument{
+											{
+												Name:       []byte("if"),
+												Value:      []byte("withPosts"),
+												IsVariable: true,
+												Position:   query.Position{Line: 3, Column: 17},
+											},
+										},
+									},
+								},
+							},
+							&query.FragmentSpread{
+								Name:     []byte("UserFragment"),
+								Position: query.Position{Line: 4, Column: 3},
+							},
+							&query.InlineFragment{
+								TypeCondition: []byte("Admin"),
+								Position:      query.Position{Line: 5, Column: 3},
+								Selections: []query.Selection{
+									&query.Field{
+										Name:     []byte("role"),
+										Position: query.Position{Line: 6, Column: 4},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		FragmentDefinitions: query.FragmentDefinitions{
+			{
+				Name:          []byte("UserFragment"),
+				BasedTypeName: []byte("User"),
+				Position:      query.Position{Line: 11, Column: 1},
+				Selections: []query.Selection{
+					&query.Field{
+						Name:     []byte("id"),
+						Position: query.Position{Line: 12, Column: 2},
+					},
+				},
+			},
+		},
+	}
+
+	opts := cmp.FilterPath(func(p cmp.Path) bool {
+		return p.Last().String() == ".tokens"
+	}, cmp.Ignore())
+
+	got, err := query.NewParser(query.NewLexer()).Parse(input)
+	if err != nil {
+		t.Fatalf("Parse() error %v", err)
+	}
+
+	if diff := cmp.Diff(got, expected, opts); diff != "" {
+		t.Errorf("Parse() mismatch (-got +want):\n%s", diff)
+	}
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/n9te9/goliteql/query"
@@ -47,44 +48,27 @@ func ExtractSelectorArgs(op *query.Operation, operationName string) []*query.Arg
 	return nil
 }
 
-func GetOperationType(operations query.Operations, operationName string) string {
-	if q := ExtractExecuteSelector(operations.GetQuery(), operationName); len(q) > 0 {
-		return "query"
-	}
-
-	if m := ExtractExecuteSelector(operations.GetMutation(), operationName); len(m) > 0 {
-		return "mutation"
-	}
-
-	if s := ExtractExecuteSelector(operations.GetSubscription(), operationName); len(s) > 0 {
-		return "subscription"
-	}
-
-	return ""
-}
-
-func ExtractExecuteSelector(op *query.Operation, operationName string) []query.Selection {
-	if op == nil {
-		return nil
-	}
-
-	for _, sel := range op.Selections {
-		switch s := sel.(type) {
-		case *query.Field:
-			if string(s.Name) == operationName {
-				return []query.Selection{s}
-			}
+// GetOperation selects the operation to execute from a document, following GetOperation of the spec.
+// When operationName is empty the document must contain exactly one operation.
+func GetOperation(operations query.Operations, operationName string) (*query.Operation, error) {
+	if operationName == "" {
+		switch len(operations) {
+		case 0:
+			return nil, errors.New("must provide an operation")
+		case 1:
+			return operations[0], nil
 		}
+
+		return nil, errors.New("must provide operation name if query contains multiple operations")
 	}
 
-	if len(op.Selections) == 1 {
-		switch s := op.Selections[0].(type) {
-		case *query.Field:
-			return []query.Selection{s}
+	for _, op := range operations {
+		if op.Name == operationName {
+			return op, nil
 		}
 	}
 
-	return nil
+	return nil, fmt.Errorf("unknown operation named %q", operationName)
 }
 
 func ConvRequestBodyFromVariables(variables json.RawMessage, args []*query.Argument) ([]byte, error) {
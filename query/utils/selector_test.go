@@ -0,0 +1,97 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/query"
+	"github.com/n9te9/goliteql/query/utils"
+)
+
+func TestGetOperation(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		operationName string
+		want          string
+		wantErr       string
+	}{
+		{
+			name:  "single anonymous operation",
+			query: `query { users { id } }`,
+			want:  "",
+		},
+		{
+			name:  "shorthand query",
+			query: `{ posts users }`,
+			want:  "",
+		},
+		{
+			name:          "shorthand query with operation name",
+			query:         `{ posts users }`,
+			operationName: "A",
+			wantErr:       `unknown operation named "A"`,
+		},
+		{
+			name:    "shorthand query next to another operation",
+			query:   `{ posts } query B { users { id } }`,
+			wantErr: "must provide operation name if query contains multiple operations",
+		},
+		{
+			name:  "single named operation without operation name",
+			query: `mutation CreateUser { createUser { id } }`,
+			want:  "CreateUser",
+		},
+		{
+			name:          "select by operation name",
+			query:         `query A { users { id } } mutation B { createUser { id } } query C { posts { id } }`,
+			operationName: "C",
+			want:          "C",
+		},
+		{
+			name:    "ambiguous operation",
+			query:   `query A { users { id } } query B { posts { id } }`,
+			wantErr: "must provide operation name if query contains multiple operations",
+		},
+		{
+			name:          "unknown operation",
+			query:         `query A { users { id } }`,
+			operationName: "B",
+			wantErr:       `unknown operation named "B"`,
+		},
+		{
+			name:    "no operations",
+			query:   `fragment F on User { id }`,
+			wantErr: "must provide an operation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := query.NewParserWithLexer().Parse([]byte(tt.query))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := utils.GetOperation(doc.Operations, tt.operationName)
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatalf("GetOperation() error = nil, want %q", tt.wantErr)
+				}
+
+				if d := cmp.Diff(tt.wantErr, err.Error()); d != "" {
+					t.Errorf("GetOperation() error mismatch (-want +got):\n%s", d)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("GetOperation() error = %v", err)
+			}
+
+			if d := cmp.Diff(tt.want, got.Name); d != "" {
+				t.Errorf("GetOperation() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package goliteql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Marshaler is implemented by custom scalar types that encode their own response value.
+// MarshalGQL returns a value encodable by encoding/json.
+type Marshaler interface {
+	MarshalGQL() (any, error)
+}
+
+// Unmarshaler is implemented by custom scalar types that decode their own input value.
+// UnmarshalGQL receives the input decoded as string, bool, int64, float64, json.Number, nil, []any or map[string]any.
+type Unmarshaler interface {
+	UnmarshalGQL(v any) error
+}
+
+// MarshalScalar encodes v with MarshalGQL when v implements Marshaler, otherwise with encoding/json.
+func MarshalScalar(v any) ([]byte, error) {
+	m, ok := v.(Marshaler)
+	if !ok {
+		return json.Marshal(v)
+	}
+
+	value, err := m.MarshalGQL()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(value)
+}
+
+// UnmarshalScalar decodes data into v with UnmarshalGQL when v implements Unmarshaler, otherwise with encoding/json.
+func UnmarshalScalar(data []byte, v any) error {
+	u, ok := v.(Unmarshaler)
+	if !ok {
+		return json.Unmarshal(data, v)
+	}
+
+	value, err := DecodeScalarValue(data)
+	if err != nil {
+		return err
+	}
+
+	return u.UnmarshalGQL(value)
+}
+
+// MarshalScalarFunc encodes v with a marshal function configured for the scalar.
+func MarshalScalarFunc[T any](v T, marshal func(T) (any, error)) ([]byte, error) {
+	value, err := marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(value)
+}
+
+// UnmarshalScalarFunc decodes data with an unmarshal function configured for the scalar.
+func UnmarshalScalarFunc[T any](data []byte, unmarshal func(any) (T, error)) (T, error) {
+	value, err := DecodeScalarValue(data)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return unmarshal(value)
+}
+
+// DecodeScalarValue decodes a JSON input value into the form passed to UnmarshalGQL.
+func DecodeScalarValue(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("invalid scalar value: %w", err)
+	}
+
+	return normalizeNumbers(value), nil
+}
+
+func normalizeNumbers(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+
+		if strings.ContainsAny(v.String(), ".eE") {
+			if f, err := v.Float64(); err == nil {
+				return f
+			}
+		}
+
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeNumbers(item)
+		}
+	case map[string]any:
+		for key, item := range v {
+			v[key] = normalizeNumbers(item)
+		}
+	}
+
+	return value
+}
+
+// ValueToJSON converts a parsed input literal into JSON.
+func ValueToJSON(expr ValueParserExpr) ([]byte, error) {
+	switch v := expr.(type) {
+	case *ValueParserLiteral:
+		if v.IsEnum() {
+			return json.Marshal(string(v.Value))
+		}
+
+		return v.Value, nil
+	case *ValueParserArray:
+		items := make([]json.RawMessage, 0, len(v.Items))
+		for _, item := range v.Items {
+			b, err := ValueToJSON(item)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, b)
+		}
+
+		return json.Marshal(items)
+	case *ValueParserObject:
+		fields := make(map[string]json.RawMessage, len(v.Fields))
+		for key, field := range v.Fields {
+			b, err := ValueToJSON(field)
+			if err != nil {
+				return nil, err
+			}
+			fields[key] = b
+		}
+
+		return json.Marshal(fields)
+	}
+
+	return nil, fmt.Errorf("unexpected value %T", expr)
+}
+
+// UnmarshalValue decodes a parsed input literal into v through encoding/json, so that custom scalars are decoded by their UnmarshalJSON.
+func UnmarshalValue(expr ValueParserExpr, v any) error {
+	b, err := ValueToJSON(expr)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
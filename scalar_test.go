@@ -0,0 +1,184 @@
+package goliteql_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql"
+)
+
+type upperString string
+
+func (u upperString) MarshalGQL() (any, error) {
+	return strings.ToUpper(string(u)), nil
+}
+
+func (u *upperString) UnmarshalGQL(v any) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("upperString must be a string, got %T", v)
+	}
+
+	*u = upperString(strings.ToLower(s))
+	return nil
+}
+
+type rawInput struct {
+	value any
+}
+
+func (r *rawInput) UnmarshalGQL(v any) error {
+	r.value = v
+	return nil
+}
+
+func TestMarshalScalar(t *testing.T) {
+	tests := []struct {
+		name  string
+		input any
+		want  string
+	}{
+		{
+			name:  "Marshaler",
+			input: upperString("abc"),
+			want:  `"ABC"`,
+		},
+		{
+			name:  "encoding/json fallback",
+			input: 42,
+			want:  `42`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := goliteql.MarshalScalar(tt.input)
+			if err != nil {
+				t.Fatalf("MarshalScalar() error = %v", err)
+			}
+
+			if d := cmp.Diff(tt.want, string(got)); d != "" {
+				t.Errorf("MarshalScalar() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestUnmarshalScalar(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    any
+		wantErr bool
+	}{
+		{
+			name:  "string",
+			input: `"abc"`,
+			want:  "abc",
+		},
+		{
+			name:  "int",
+			input: `9007199254740993`,
+			want:  int64(9007199254740993),
+		},
+		{
+			name:  "float",
+			input: `1.5`,
+			want:  1.5,
+		},
+		{
+			name:  "big int",
+			input: `123456789012345678901234567890`,
+			want:  json.Number("123456789012345678901234567890"),
+		},
+		{
+			name:  "object",
+			input: `{"a":[1,true,null]}`,
+			want:  map[string]any{"a": []any{int64(1), true, nil}},
+		},
+		{
+			name:    "invalid",
+			input:   `{`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got rawInput
+			err := goliteql.UnmarshalScalar([]byte(tt.input), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalScalar() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if d := cmp.Diff(tt.want, got.value); d != "" {
+				t.Errorf("UnmarshalScalar() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestScalarFunc(t *testing.T) {
+	marshal := func(v int) (any, error) {
+		return fmt.Sprintf("#%d", v), nil
+	}
+	unmarshal := func(v any) (int, error) {
+		s, ok := v.(string)
+		if !ok {
+			return 0, fmt.Errorf("unexpected %T", v)
+		}
+
+		var ret int
+		_, err := fmt.Sscanf(s, "#%d", &ret)
+		return ret, err
+	}
+
+	b, err := goliteql.MarshalScalarFunc(7, marshal)
+	if err != nil {
+		t.Fatalf("MarshalScalarFunc() error = %v", err)
+	}
+
+	got, err := goliteql.UnmarshalScalarFunc(b, unmarshal)
+	if err != nil {
+		t.Fatalf("UnmarshalScalarFunc() error = %v", err)
+	}
+
+	if d := cmp.Diff(7, got); d != "" {
+		t.Errorf("round trip mismatch (-want +got):\n%s", d)
+	}
+
+	if _, err := goliteql.UnmarshalScalarFunc([]byte(`7`), unmarshal); err == nil {
+		t.Errorf("UnmarshalScalarFunc() expected error for int input")
+	}
+}
+
+func TestUnmarshalValue(t *testing.T) {
+	expr, err := goliteql.NewValueParser(goliteql.NewValueLexer()).Parse([]byte(`{values: ["abc", "DEF"], count: 2, role: ADMIN}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	var got struct {
+		Values []upperString `json:"values"`
+		Count  int           `json:"count"`
+		Role   string        `json:"role"`
+	}
+	if err := goliteql.UnmarshalValue(expr, &got); err != nil {
+		t.Fatalf("UnmarshalValue() error = %v", err)
+	}
+
+	if d := cmp.Diff([]upperString{"abc", "DEF"}, got.Values); d != "" {
+		t.Errorf("UnmarshalValue() values mismatch (-want +got):\n%s", d)
+	}
+
+	if d := cmp.Diff(2, got.Count); d != "" {
+		t.Errorf("UnmarshalValue() count mismatch (-want +got):\n%s", d)
+	}
+
+	if d := cmp.Diff("ADMIN", got.Role); d != "" {
+		t.Errorf("UnmarshalValue() role mismatch (-want +got):\n%s", d)
+	}
+}
@@ -0,0 +1,45 @@
+package scalars
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// BigInt is an arbitrary precision integer.
+// It is encoded as a decimal string so that clients do not lose precision, and accepts both strings and integers as input.
+type BigInt struct {
+	*big.Int
+}
+
+func (b BigInt) MarshalGQL() (any, error) {
+	if b.Int == nil {
+		return nil, errors.New("BigInt is nil")
+	}
+
+	return b.Int.String(), nil
+}
+
+func (b *BigInt) UnmarshalGQL(v any) error {
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case json.Number:
+		s = v.String()
+	case int64:
+		b.Int = big.NewInt(v)
+		return nil
+	default:
+		return fmt.Errorf("BigInt must be an integer or a string, got %T", v)
+	}
+
+	i, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return fmt.Errorf("BigInt %q is not a base 10 integer", s)
+	}
+
+	b.Int = i
+	return nil
+}
@@ -0,0 +1,28 @@
+package scalars
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// Bytes is binary data encoded as standard padded base64.
+type Bytes []byte
+
+func (b Bytes) MarshalGQL() (any, error) {
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+func (b *Bytes) UnmarshalGQL(v any) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("Bytes must be a base64 string, got %T", v)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return fmt.Errorf("Bytes must be a base64 string: %w", err)
+	}
+
+	*b = decoded
+	return nil
+}
@@ -0,0 +1,32 @@
+package scalars
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// JSON is an arbitrary JSON value kept in its encoded form.
+type JSON json.RawMessage
+
+func (j JSON) MarshalGQL() (any, error) {
+	if len(j) == 0 {
+		return nil, nil
+	}
+
+	if !json.Valid(j) {
+		return nil, errors.New("JSON contains invalid JSON")
+	}
+
+	return json.RawMessage(j), nil
+}
+
+func (j *JSON) UnmarshalGQL(v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("JSON must be a JSON value: %w", err)
+	}
+
+	*j = JSON(b)
+	return nil
+}
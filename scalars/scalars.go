@@ -0,0 +1,38 @@
+package scalars
+
+// PackagePath is the import path of this package in generated code.
+const PackagePath = "github.com/n9te9/goliteql/scalars"
+
+// Definition describes a scalar shipped by this package.
+type Definition struct {
+	Name           string
+	SpecifiedByURL string
+}
+
+var definitions = []Definition{
+	{Name: "DateTime", SpecifiedByURL: "https://scalars.graphql.org/andimarek/date-time"},
+	{Name: "Date", SpecifiedByURL: "https://scalars.graphql.org/andimarek/local-date"},
+	{Name: "JSON", SpecifiedByURL: "https://www.rfc-editor.org/rfc/rfc8259"},
+	{Name: "UUID", SpecifiedByURL: "https://www.rfc-editor.org/rfc/rfc9562"},
+	{Name: "BigInt"},
+	{Name: "Bytes", SpecifiedByURL: "https://www.rfc-editor.org/rfc/rfc4648#section-4"},
+}
+
+// Definitions returns every scalar shipped by this package.
+func Definitions() []Definition {
+	ret := make([]Definition, len(definitions))
+	copy(ret, definitions)
+
+	return ret
+}
+
+// Lookup returns the scalar named name.
+func Lookup(name string) (Definition, bool) {
+	for _, definition := range definitions {
+		if definition.Name == name {
+			return definition, true
+		}
+	}
+
+	return Definition{}, false
+}
@@ -0,0 +1,204 @@
+package scalars_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql"
+	"github.com/n9te9/goliteql/scalars"
+)
+
+func TestScalars_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   func() any
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "DateTime with offset",
+			value: func() any { return new(scalars.DateTime) },
+			input: `"2026-01-02T03:04:05.5+09:00"`,
+			want:  `"2026-01-02T03:04:05.5+09:00"`,
+		},
+		{
+			name:    "DateTime without offset",
+			value:   func() any { return new(scalars.DateTime) },
+			input:   `"2026-01-02T03:04:05"`,
+			wantErr: true,
+		},
+		{
+			name:    "DateTime number",
+			value:   func() any { return new(scalars.DateTime) },
+			input:   `1767322800`,
+			wantErr: true,
+		},
+		{
+			name:  "Date",
+			value: func() any { return new(scalars.Date) },
+			input: `"2026-02-28"`,
+			want:  `"2026-02-28"`,
+		},
+		{
+			name:    "Date with time",
+			value:   func() any { return new(scalars.Date) },
+			input:   `"2026-02-28T00:00:00Z"`,
+			wantErr: true,
+		},
+		{
+			name:  "JSON object",
+			value: func() any { return new(scalars.JSON) },
+			input: `{"a":[1,2.5,"x",null],"b":{"c":true}}`,
+			want:  `{"a":[1,2.5,"x",null],"b":{"c":true}}`,
+		},
+		{
+			name:  "JSON big number",
+			value: func() any { return new(scalars.JSON) },
+			input: `123456789012345678901234567890`,
+			want:  `123456789012345678901234567890`,
+		},
+		{
+			name:  "UUID is lowercased",
+			value: func() any { return new(scalars.UUID) },
+			input: `"6BA7B810-9DAD-11D1-80B4-00C04FD430C8"`,
+			want:  `"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`,
+		},
+		{
+			name:    "UUID without hyphens",
+			value:   func() any { return new(scalars.UUID) },
+			input:   `"6ba7b8109dad11d180b400c04fd430c8"`,
+			wantErr: true,
+		},
+		{
+			name:  "BigInt from string",
+			value: func() any { return new(scalars.BigInt) },
+			input: `"-123456789012345678901234567890"`,
+			want:  `"-123456789012345678901234567890"`,
+		},
+		{
+			name:  "BigInt from number",
+			value: func() any { return new(scalars.BigInt) },
+			input: `123456789012345678901234567890`,
+			want:  `"123456789012345678901234567890"`,
+		},
+		{
+			name:    "BigInt from float",
+			value:   func() any { return new(scalars.BigInt) },
+			input:   `1.5`,
+			wantErr: true,
+		},
+		{
+			name:  "Bytes",
+			value: func() any { return new(scalars.Bytes) },
+			input: `"aGVsbG8="`,
+			want:  `"aGVsbG8="`,
+		},
+		{
+			name:    "Bytes invalid base64",
+			value:   func() any { return new(scalars.Bytes) },
+			input:   `"not base64"`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := tt.value()
+			err := goliteql.UnmarshalScalar([]byte(tt.input), v)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalScalar() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			got, err := goliteql.MarshalScalar(v)
+			if err != nil {
+				t.Fatalf("MarshalScalar() error = %v", err)
+			}
+
+			if d := cmp.Diff(tt.want, string(got)); d != "" {
+				t.Errorf("round trip mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestScalars_Marshal(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "DateTime in UTC",
+			value: scalars.DateTime(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)),
+			want:  `"2026-01-02T03:04:05Z"`,
+		},
+		{
+			name:  "Date drops time",
+			value: scalars.Date(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)),
+			want:  `"2026-01-02"`,
+		},
+		{
+			name:  "empty JSON is null",
+			value: scalars.JSON(nil),
+			want:  `null`,
+		},
+		{
+			name:    "invalid JSON",
+			value:   scalars.JSON(`{`),
+			wantErr: true,
+		},
+		{
+			name:    "invalid UUID",
+			value:   scalars.UUID("x"),
+			wantErr: true,
+		},
+		{
+			name:  "BigInt",
+			value: scalars.BigInt{Int: big.NewInt(42)},
+			want:  `"42"`,
+		},
+		{
+			name:    "nil BigInt",
+			value:   scalars.BigInt{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := goliteql.MarshalScalar(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MarshalScalar() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if d := cmp.Diff(tt.want, string(got)); d != "" {
+				t.Errorf("MarshalScalar() mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	for _, definition := range scalars.Definitions() {
+		got, ok := scalars.Lookup(definition.Name)
+		if !ok {
+			t.Fatalf("Lookup(%q) not found", definition.Name)
+		}
+
+		if d := cmp.Diff(definition, got); d != "" {
+			t.Errorf("Lookup(%q) mismatch (-want +got):\n%s", definition.Name, d)
+		}
+	}
+
+	if _, ok := scalars.Lookup("Upload"); ok {
+		t.Errorf("Lookup(%q) should not be found", "Upload")
+	}
+}
@@ -0,0 +1,52 @@
+package scalars
+
+import (
+	"fmt"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// DateTime is an RFC 3339 date-time with a mandatory offset.
+type DateTime time.Time
+
+func (d DateTime) MarshalGQL() (any, error) {
+	return time.Time(d).Format(time.RFC3339Nano), nil
+}
+
+func (d *DateTime) UnmarshalGQL(v any) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("DateTime must be a string, got %T", v)
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return fmt.Errorf("DateTime must be an RFC 3339 date-time: %w", err)
+	}
+
+	*d = DateTime(t)
+	return nil
+}
+
+// Date is a calendar date without time or offset, formatted as YYYY-MM-DD.
+type Date time.Time
+
+func (d Date) MarshalGQL() (any, error) {
+	return time.Time(d).Format(dateLayout), nil
+}
+
+func (d *Date) UnmarshalGQL(v any) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("Date must be a string, got %T", v)
+	}
+
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return fmt.Errorf("Date must be formatted as YYYY-MM-DD: %w", err)
+	}
+
+	*d = Date(t)
+	return nil
+}
@@ -0,0 +1,56 @@
+package scalars
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UUID is an RFC 9562 UUID in its canonical lowercase 8-4-4-4-12 form.
+type UUID string
+
+func (u UUID) MarshalGQL() (any, error) {
+	if !isUUID(string(u)) {
+		return nil, fmt.Errorf("UUID %q is not a canonical UUID", string(u))
+	}
+
+	return strings.ToLower(string(u)), nil
+}
+
+func (u *UUID) UnmarshalGQL(v any) error {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("UUID must be a string, got %T", v)
+	}
+
+	if !isUUID(s) {
+		return fmt.Errorf("UUID %q is not a canonical UUID", s)
+	}
+
+	*u = UUID(strings.ToLower(s))
+	return nil
+}
+
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return false
+			}
+		default:
+			if !isHex(s[i]) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func isHex(b byte) bool {
+	return ('0' <= b && b <= '9') || ('a' <= b && b <= 'f') || ('A' <= b && b <= 'F')
+}
@@ -43,9 +43,11 @@ var typesValidator = map[string]func([]byte) error{
 }
 
 type ArgumentDefinition struct {
-	Name []byte
-	Default []byte
-	Type *FieldType
+	Name        []byte
+	Description []byte
+	Default     []byte
+	Type        *FieldType
+	Directives  Directives
 }
 
 func (a *ArgumentDefinition) ValidateValueType(value []byte) error {
@@ -2,19 +2,24 @@ package schema
 
 func WithBuiltin(s *Schema) *Schema {
 	s.Scalars = append(s.Scalars, &ScalarDefinition{
-		Name: []byte("Int"),
+		Name:        []byte("Int"),
+		Description: []byte("The `Int` scalar type represents non-fractional signed whole numeric values. Int can represent values between -(2^31) and 2^31 - 1."),
 	})
 	s.Scalars = append(s.Scalars, &ScalarDefinition{
-		Name: []byte("Float"),
+		Name:        []byte("Float"),
+		Description: []byte("The `Float` scalar type represents signed double-precision fractional values as specified by [IEEE 754](http://en.wikipedia.org/wiki/IEEE_floating_point)."),
 	})
 	s.Scalars = append(s.Scalars, &ScalarDefinition{
-		Name: []byte("String"),
+		Name:        []byte("String"),
+		Description: []byte("The `String`scalar type represents textual data, represented as UTF-8 character sequences. The String type is most often used by GraphQL to represent free-form human-readable text."),
 	})
 	s.Scalars = append(s.Scalars, &ScalarDefinition{
-		Name: []byte("Boolean"),
+		Name:        []byte("Boolean"),
+		Description: []byte("The `Boolean` scalar type represents `true` or `false`."),
 	})
 	s.Scalars = append(s.Scalars, &ScalarDefinition{
-		Name: []byte("ID"),
+		Name:        []byte("ID"),
+		Description: []byte("The `ID` scalar type represents a unique identifier, often used to refetch an object or as key for a cache. The ID type appears in a JSON response as a String; however, it is not intended to be human-readable. When expected as an input type, any string (such as \"4\") or integer (such as 4) input value will be accepted as an ID."),
 	})
 
 	return s
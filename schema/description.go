@@ -0,0 +1,120 @@
+package schema
+
+import (
+	"bytes"
+	"strconv"
+	"unicode/utf8"
+)
+
+func descriptionBefore(tokens Tokens, cur int) []byte {
+	for i := cur - 1; i >= 0; i-- {
+		switch tokens[i].Type {
+		case Comment:
+			continue
+		case Description:
+			return descriptionValue(tokens[i].Value)
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+func descriptionValue(raw []byte) []byte {
+	if bytes.HasPrefix(raw, blockStringQuote) {
+		raw = bytes.TrimPrefix(raw, blockStringQuote)
+		raw = bytes.TrimSuffix(raw, blockStringQuote)
+		return blockStringValue(bytes.ReplaceAll(raw, []byte(`\"""`), blockStringQuote))
+	}
+
+	raw = bytes.TrimPrefix(raw, []byte(`"`))
+	raw = bytes.TrimSuffix(raw, []byte(`"`))
+	return unescapeString(raw)
+}
+
+func blockStringValue(raw []byte) []byte {
+	lines := bytes.Split(bytes.ReplaceAll(bytes.ReplaceAll(raw, []byte("\r\n"), []byte("\n")), []byte("\r"), []byte("\n")), []byte("\n"))
+
+	commonIndent := -1
+	for i, line := range lines {
+		if i == 0 {
+			continue
+		}
+
+		indent := leadingWhitespace(line)
+		if indent == len(line) {
+			continue
+		}
+
+		if commonIndent < 0 || indent < commonIndent {
+			commonIndent = indent
+		}
+	}
+
+	if commonIndent > 0 {
+		for i := 1; i < len(lines); i++ {
+			lines[i] = lines[i][min(commonIndent, len(lines[i])):]
+		}
+	}
+
+	for len(lines) > 0 && leadingWhitespace(lines[0]) == len(lines[0]) {
+		lines = lines[1:]
+	}
+
+	for len(lines) > 0 && leadingWhitespace(lines[len(lines)-1]) == len(lines[len(lines)-1]) {
+		lines = lines[:len(lines)-1]
+	}
+
+	return bytes.Join(lines, []byte("\n"))
+}
+
+func leadingWhitespace(line []byte) int {
+	i := 0
+	for i < len(line) && (line[i] == ' ' || line[i] == '\t') {
+		i++
+	}
+
+	return i
+}
+
+func unescapeString(raw []byte) []byte {
+	if !bytes.ContainsRune(raw, '\\') {
+		return raw
+	}
+
+	ret := make([]byte, 0, len(raw))
+	for i := 0; i < len(raw); i++ {
+		if raw[i] != '\\' || i+1 >= len(raw) {
+			ret = append(ret, raw[i])
+			continue
+		}
+
+		i++
+		switch raw[i] {
+		case 'b':
+			ret = append(ret, '\b')
+		case 'f':
+			ret = append(ret, '\f')
+		case 'n':
+			ret = append(ret, '\n')
+		case 'r':
+			ret = append(ret, '\r')
+		case 't':
+			ret = append(ret, '\t')
+		case 'u':
+			if i+4 < len(raw) {
+				if r, err := strconv.ParseUint(string(raw[i+1:i+5]), 16, 32); err == nil {
+					ret = utf8.AppendRune(ret, rune(r))
+					i += 4
+					continue
+				}
+			}
+			ret = append(ret, '\\', 'u')
+		default:
+			ret = append(ret, raw[i])
+		}
+	}
+
+	return ret
+}
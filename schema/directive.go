@@ -83,11 +83,14 @@ func (d *DirectiveDefinition) ValidateArguments(args []*query.DirectiveArgument)
 		found := false
 		for _, arg := range args {
 			if bytes.Equal(def.Name, arg.Name) {
+				found = true
+				if arg.IsVariable {
+					continue
+				}
+
 				if err := def.ValidateValueType(arg.Value); err != nil {
 					return fmt.Errorf("error validating argument %s: %w", def.Name, err)
 				}
-
-				found = true
 			}
 		}
 
@@ -142,8 +145,9 @@ func NewBuildInDirectives() []*DirectiveDefinition {
 			Description: []byte("Directs the executor to skip this field or fragment when the `if` argument is true."),
 			Arguments: []*ArgumentDefinition{
 				{
-					Name: []byte("if"),
-					Type: &FieldType{Name: []byte("Boolean"), Nullable: false},
+					Name:        []byte("if"),
+					Description: []byte("Skipped when true."),
+					Type:        &FieldType{Name: []byte("Boolean"), Nullable: false},
 				},
 			},
 			Repeatable: false,
@@ -164,8 +168,9 @@ func NewBuildInDirectives() []*DirectiveDefinition {
 			Description: []byte("Directs the executor to include this field or fragment only when the `if` argument is true."),
 			Arguments: []*ArgumentDefinition{
 				{
-					Name: []byte("if"),
-					Type: &FieldType{Name: []byte("Boolean"), Nullable: false},
+					Name:        []byte("if"),
+					Description: []byte("Included when true."),
+					Type:        &FieldType{Name: []byte("Boolean"), Nullable: false},
 				},
 			},
 			Repeatable: false,
@@ -186,9 +191,10 @@ func NewBuildInDirectives() []*DirectiveDefinition {
 			Description: []byte("Marks an element of a GraphQL schema as no longer supported."),
 			Arguments: []*ArgumentDefinition{
 				{
-					Name:    []byte("reason"),
-					Type:    &FieldType{Name: []byte("String"), Nullable: true},
-					Default: []byte("No longer supported"),
+					Name:        []byte("reason"),
+					Description: []byte("Explains why this element was deprecated, usually also including a suggestion for how to access supported similar data. Formatted using the Markdown syntax, as specified by [CommonMark](https://commonmark.org/)."),
+					Type:        &FieldType{Name: []byte("String"), Nullable: true},
+					Default:     []byte(`"No longer supported"`),
 				},
 			},
 			Repeatable: false,
@@ -196,6 +202,12 @@ func NewBuildInDirectives() []*DirectiveDefinition {
 				{
 					Name: []byte("FIELD_DEFINITION"),
 				},
+				{
+					Name: []byte("ARGUMENT_DEFINITION"),
+				},
+				{
+					Name: []byte("INPUT_FIELD_DEFINITION"),
+				},
 				{
 					Name: []byte("ENUM_VALUE"),
 				},
@@ -203,11 +215,12 @@ func NewBuildInDirectives() []*DirectiveDefinition {
 		},
 		{
 			Name:        []byte("specifiedBy"),
-			Description: []byte("Exposes a URL that specifies the behaviour of this scalar."),
+			Description: []byte("Exposes a URL that specifies the behavior of this scalar."),
 			Arguments: []*ArgumentDefinition{
 				{
-					Name: []byte("url"),
-					Type: &FieldType{Name: []byte("String"), Nullable: false},
+					Name:        []byte("url"),
+					Description: []byte("The URL that specifies the behavior of this scalar."),
+					Type:        &FieldType{Name: []byte("String"), Nullable: false},
 				},
 			},
 			Repeatable: false,
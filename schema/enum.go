@@ -1,10 +1,11 @@
 package schema
 
 type EnumDefinition struct {
-	Name       []byte
-	Type       *FieldType
-	Values     []*EnumElement
-	Extentions []*EnumDefinition
+	Name        []byte
+	Description []byte
+	Type        *FieldType
+	Values      []*EnumElement
+	Extentions  []*EnumDefinition
 	Directives
 }
 
@@ -40,9 +41,10 @@ func (e EnumDefinitions) Has(name string) bool {
 }
 
 type EnumElement struct {
-	Name       []byte
-	Value      []byte
-	Directives Directives
+	Name        []byte
+	Description []byte
+	Value       []byte
+	Directives  Directives
 }
 
 func (e *EnumElement) Location() *Location {
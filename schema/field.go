@@ -1,12 +1,13 @@
 package schema
 
 type FieldDefinition struct {
-	Name       []byte
-	Arguments  []*ArgumentDefinition
-	Type       *FieldType
-	Directives Directives
-	Default    []byte
-	Location   *Location
+	Name        []byte
+	Description []byte
+	Arguments   []*ArgumentDefinition
+	Type        *FieldType
+	Directives  Directives
+	Default     []byte
+	Location    *Location
 }
 
 func (f *FieldDefinition) IsPrimitive() bool {
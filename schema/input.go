@@ -1,9 +1,10 @@
 package schema
 
 type InputDefinition struct {
-	Name       []byte
-	Fields     FieldDefinitions
-	Extentions []*InputDefinition
+	Name        []byte
+	Description []byte
+	Fields      FieldDefinitions
+	Extentions  []*InputDefinition
 }
 
 func (i *InputDefinition) Location() *Location {
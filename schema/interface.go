@@ -3,11 +3,12 @@ package schema
 import "bytes"
 
 type InterfaceDefinition struct {
-	Name       []byte
-	Fields     FieldDefinitions
-	Extentions []*InterfaceDefinition
-	Interfaces [][]byte
-	Directives []*Directive
+	Name        []byte
+	Description []byte
+	Fields      FieldDefinitions
+	Extentions  []*InterfaceDefinition
+	Interfaces  [][]byte
+	Directives  []*Directive
 }
 
 func (i *InterfaceDefinition) Location() *Location {
@@ -3,124 +3,99 @@ package schema
 var (
 	schemaIntrospectionFields = []*FieldDefinition{
 		{
-			Name:      []byte("description"),
-			Arguments: nil,
+			Name: []byte("description"),
 			Type: &FieldType{
 				Name:     []byte("String"),
 				IsList:   false,
 				Nullable: true,
-				ListType: nil,
 			},
-			Directives: nil,
-			Default:    nil,
-			Location:   nil,
 		},
 		{
-			Name:      []byte("queryType"),
-			Arguments: nil,
+			Name:        []byte("types"),
+			Description: []byte("A list of all types supported by this server."),
 			Type: &FieldType{
-				Name:     []byte("__Type"),
-				IsList:   false,
-				Nullable: true,
-				ListType: nil,
+				IsList:   true,
+				Nullable: false,
+				ListType: &FieldType{
+					Name:     []byte("__Type"),
+					IsList:   false,
+					Nullable: false,
+				},
 			},
-			Directives: nil,
-			Default:    nil,
-			Location:   nil,
 		},
 		{
-			Name:      []byte("mutationType"),
-			Arguments: nil,
+			Name:        []byte("queryType"),
+			Description: []byte("The type that query operations will be rooted at."),
 			Type: &FieldType{
 				Name:     []byte("__Type"),
 				IsList:   false,
-				Nullable: true,
-				ListType: nil,
+				Nullable: false,
 			},
-			Directives: nil,
-			Default:    nil,
-			Location:   nil,
 		},
 		{
-			Name:      []byte("subscriptionType"),
-			Arguments: nil,
+			Name:        []byte("mutationType"),
+			Description: []byte("If this server supports mutation, the type that mutation operations will be rooted at."),
 			Type: &FieldType{
 				Name:     []byte("__Type"),
 				IsList:   false,
 				Nullable: true,
-				ListType: nil,
 			},
-			Directives: nil,
-			Default:    nil,
-			Location:   nil,
 		},
 		{
-			Name:      []byte("types"),
-			Arguments: nil,
+			Name:        []byte("subscriptionType"),
+			Description: []byte("If this server support subscription, the type that subscription operations will be rooted at."),
 			Type: &FieldType{
 				Name:     []byte("__Type"),
-				IsList:   true,
-				Nullable: false,
-				ListType: &FieldType{
-					Name:     []byte("__Type"),
-					IsList:   false,
-					Nullable: false,
-					ListType: nil,
-				},
+				IsList:   false,
+				Nullable: true,
 			},
 		},
 		{
-			Name:      []byte("directives"),
-			Arguments: nil,
+			Name:        []byte("directives"),
+			Description: []byte("A list of all directives supported by this server."),
 			Type: &FieldType{
-				Name:     []byte("__Directive"),
 				IsList:   true,
 				Nullable: false,
 				ListType: &FieldType{
 					Name:     []byte("__Directive"),
 					IsList:   false,
 					Nullable: false,
-					ListType: nil,
 				},
 			},
 		},
 	}
 	typeIntrospectionFields = []*FieldDefinition{
 		{
-			Name:      []byte("kind"),
-			Arguments: nil,
+			Name: []byte("kind"),
 			Type: &FieldType{
 				Name:     []byte("__TypeKind"),
 				IsList:   false,
 				Nullable: false,
-				ListType: nil,
 			},
-			Directives: nil,
-			Default:    nil,
-			Location:   nil,
 		},
 		{
-			Name:      []byte("name"),
-			Arguments: nil,
+			Name: []byte("name"),
 			Type: &FieldType{
 				Name:     []byte("String"),
 				IsList:   false,
 				Nullable: true,
-				ListType: nil,
 			},
 		},
 		{
-			Name:      []byte("description"),
-			Arguments: nil,
+			Name: []byte("description"),
+			Type: &FieldType{
+				Name:     []byte("String"),
+				IsList:   false,
+				Nullable: true,
+			},
+		},
+		{
+			Name: []byte("specifiedByURL"),
 			Type: &FieldType{
 				Name:     []byte("String"),
 				IsList:   false,
 				Nullable: true,
-				ListType: nil,
 			},
-			Directives: nil,
-			Default:    nil,
-			Location:   nil,
 		},
 		{
 			Name: []byte("fields"),
@@ -131,50 +106,41 @@ var (
 						Name:     []byte("Boolean"),
 						IsList:   false,
 						Nullable: true,
-						ListType: nil,
 					},
 					Default: []byte("false"),
 				},
 			},
 			Type: &FieldType{
-				Name:     nil,
 				IsList:   true,
 				Nullable: true,
 				ListType: &FieldType{
 					Name:     []byte("__Field"),
 					IsList:   false,
 					Nullable: false,
-					ListType: nil,
 				},
 			},
 		},
 		{
-			Name:      []byte("interfaces"),
-			Arguments: nil,
+			Name: []byte("interfaces"),
 			Type: &FieldType{
-				Name:     nil,
 				IsList:   true,
 				Nullable: true,
 				ListType: &FieldType{
 					Name:     []byte("__Type"),
 					IsList:   false,
 					Nullable: false,
-					ListType: nil,
 				},
 			},
 		},
 		{
-			Name:      []byte("possibleTypes"),
-			Arguments: nil,
+			Name: []byte("possibleTypes"),
 			Type: &FieldType{
-				Name:     nil,
 				IsList:   true,
 				Nullable: true,
 				ListType: &FieldType{
 					Name:     []byte("__Type"),
 					IsList:   false,
 					Nullable: false,
-					ListType: nil,
 				},
 			},
 		},
@@ -187,20 +153,17 @@ var (
 						Name:     []byte("Boolean"),
 						IsList:   false,
 						Nullable: true,
-						ListType: nil,
 					},
 					Default: []byte("false"),
 				},
 			},
 			Type: &FieldType{
-				Name:     nil,
 				IsList:   true,
 				Nullable: true,
 				ListType: &FieldType{
 					Name:     []byte("__EnumValue"),
 					IsList:   false,
 					Nullable: false,
-					ListType: nil,
 				},
 			},
 		},
@@ -213,123 +176,99 @@ var (
 						Name:     []byte("Boolean"),
 						IsList:   false,
 						Nullable: true,
-						ListType: nil,
 					},
+					Default: []byte("false"),
 				},
 			},
 			Type: &FieldType{
-				Name:     nil,
 				IsList:   true,
 				Nullable: true,
 				ListType: &FieldType{
 					Name:     []byte("__InputValue"),
 					IsList:   false,
 					Nullable: false,
-					ListType: nil,
 				},
 			},
 		},
 		{
-			Name:      []byte("ofType"),
-			Arguments: nil,
+			Name: []byte("ofType"),
 			Type: &FieldType{
 				Name:     []byte("__Type"),
 				IsList:   false,
 				Nullable: true,
-				ListType: nil,
 			},
 		},
 		{
-			Name:      []byte("specifiedByURL"),
-			Arguments: nil,
-			Type: &FieldType{
-				Name:     []byte("String"),
-				IsList:   false,
-				Nullable: true,
-				ListType: nil,
-			},
-			Directives: nil,
-			Default:    nil,
-			Location:   nil,
-		},
-		{
-			Name:      []byte("isOneOf"),
-			Arguments: nil,
+			Name: []byte("isOneOf"),
 			Type: &FieldType{
 				Name:     []byte("Boolean"),
 				IsList:   false,
-				Nullable: false,
-				ListType: nil,
+				Nullable: true,
 			},
-			Directives: nil,
-			Default:    nil,
-			Location:   nil,
 		},
 	}
 	fieldIntrospectionFields = []*FieldDefinition{
 		{
-			Name:      []byte("name"),
-			Arguments: nil,
+			Name: []byte("name"),
 			Type: &FieldType{
 				Name:     []byte("String"),
 				IsList:   false,
 				Nullable: false,
-				ListType: nil,
 			},
 		},
 		{
-			Name:      []byte("description"),
-			Arguments: nil,
+			Name: []byte("description"),
 			Type: &FieldType{
 				Name:     []byte("String"),
 				IsList:   false,
 				Nullable: true,
-				ListType: nil,
 			},
 		},
 		{
-			Name:      []byte("args"),
-			Arguments: nil,
+			Name: []byte("args"),
+			Arguments: []*ArgumentDefinition{
+				{
+					Name: []byte("includeDeprecated"),
+					Type: &FieldType{
+						Name:     []byte("Boolean"),
+						IsList:   false,
+						Nullable: true,
+					},
+					Default: []byte("false"),
+				},
+			},
 			Type: &FieldType{
-				Name:     nil,
 				IsList:   true,
 				Nullable: false,
 				ListType: &FieldType{
 					Name:     []byte("__InputValue"),
 					IsList:   false,
 					Nullable: false,
-					ListType: nil,
 				},
 			},
 		},
 		{
-			Name:      []byte("type"),
-			Arguments: nil,
+			Name: []byte("type"),
 			Type: &FieldType{
 				Name:     []byte("__Type"),
 				IsList:   false,
 				Nullable: false,
-				ListType: nil,
 			},
 		},
 		{
-			Name:      []byte("isDeprecated"),
-			Arguments: nil,
+			Name: []byte("isDeprecated"),
 			Type: &FieldType{
 				Name:     []byte("Boolean"),
 				IsList:   false,
-				Nullable: true,
-				ListType: nil,
+				Nullable: false,
 			},
 		},
 		{
-			Name:      []byte("deprecationReason"),
-			Arguments: nil,
+			Name: []byte("deprecationReason"),
 			Type: &FieldType{
 				Name:     []byte("String"),
 				IsList:   false,
 				Nullable: true,
-				ListType: nil,
 			},
 		},
 	}
@@ -359,7 +298,8 @@ var (
 			},
 		},
 		{
-			Name: []byte("defaultValue"),
+			Name:        []byte("defaultValue"),
+			Description: []byte("A GraphQL-formatted string representing the default value for this input value."),
 			Type: &FieldType{
 				Name:     []byte("String"),
 				IsList:   false,
@@ -371,7 +311,7 @@ var (
 			Type: &FieldType{
 				Name:     []byte("Boolean"),
 				IsList:   false,
-				Nullable: true,
+				Nullable: false,
 			},
 		},
 		{
@@ -383,7 +323,6 @@ var (
 			},
 		},
 	}
-
 	enumValueIntrospectionFields = []*FieldDefinition{
 		{
 			Name: []byte("name"),
@@ -406,7 +345,7 @@ var (
 			Type: &FieldType{
 				Name:     []byte("Boolean"),
 				IsList:   false,
-				Nullable: true,
+				Nullable: false,
 			},
 		},
 		{
@@ -418,7 +357,6 @@ var (
 			},
 		},
 	}
-
 	directiveIntrospectionFields = []*FieldDefinition{
 		{
 			Name: []byte("name"),
@@ -436,6 +374,14 @@ var (
 				Nullable: true,
 			},
 		},
+		{
+			Name: []byte("isRepeatable"),
+			Type: &FieldType{
+				Name:     []byte("Boolean"),
+				IsList:   false,
+				Nullable: false,
+			},
+		},
 		{
 			Name: []byte("locations"),
 			Type: &FieldType{
@@ -450,6 +396,17 @@ var (
 		},
 		{
 			Name: []byte("args"),
+			Arguments: []*ArgumentDefinition{
+				{
+					Name: []byte("includeDeprecated"),
+					Type: &FieldType{
+						Name:     []byte("Boolean"),
+						IsList:   false,
+						Nullable: true,
+					},
+					Default: []byte("false"),
+				},
+			},
 			Type: &FieldType{
 				IsList:   true,
 				Nullable: false,
@@ -460,152 +417,166 @@ var (
 				},
 			},
 		},
-		{
-			Name: []byte("isRepeatable"),
-			Type: &FieldType{
-				Name:     []byte("Boolean"),
-				IsList:   false,
-				Nullable: false,
-			},
-		},
 	}
-
 	typeKindIntrospectionFields = []*EnumDefinition{
 		{
-			Name: []byte("__TypeKind"),
+			Name:        []byte("__TypeKind"),
+			Description: []byte("An enum describing what kind of type a given `__Type` is."),
 			Type: &FieldType{
 				Name:     []byte("__TypeKind"),
 				IsList:   false,
 				Nullable: false,
-				ListType: nil,
 			},
 			Values: []*EnumElement{
 				{
-					Name:  []byte("__TypeKind_SCALAR"),
-					Value: []byte("SCALAR"),
+					Name:        []byte("__TypeKind_SCALAR"),
+					Description: []byte("Indicates this type is a scalar."),
+					Value:       []byte("SCALAR"),
 				},
 				{
-					Name:  []byte("__TypeKind_OBJECT"),
-					Value: []byte("OBJECT"),
+					Name:        []byte("__TypeKind_OBJECT"),
+					Description: []byte("Indicates this type is an object. `fields` and `interfaces` are valid fields."),
+					Value:       []byte("OBJECT"),
 				},
 				{
-					Name:  []byte("__TypeKind_INTERFACE"),
-					Value: []byte("INTERFACE"),
+					Name:        []byte("__TypeKind_INTERFACE"),
+					Description: []byte("Indicates this type is an interface. `fields`, `interfaces`, and `possibleTypes` are valid fields."),
+					Value:       []byte("INTERFACE"),
 				},
 				{
-					Name:  []byte("__TypeKind_UNION"),
-					Value: []byte("UNION"),
+					Name:        []byte("__TypeKind_UNION"),
+					Description: []byte("Indicates this type is a union. `possibleTypes` is a valid field."),
+					Value:       []byte("UNION"),
 				},
 				{
-					Name:  []byte("__TypeKind_ENUM"),
-					Value: []byte("ENUM"),
+					Name:        []byte("__TypeKind_ENUM"),
+					Description: []byte("Indicates this type is an enum. `enumValues` is a valid field."),
+					Value:       []byte("ENUM"),
 				},
 				{
-					Name:  []byte("__TypeKind_INPUT_OBJECT"),
-					Value: []byte("INPUT_OBJECT"),
+					Name:        []byte("__TypeKind_INPUT_OBJECT"),
+					Description: []byte("Indicates this type is an input object. `inputFields` is a valid field."),
+					Value:       []byte("INPUT_OBJECT"),
 				},
 				{
-					Name:  []byte("__TypeKind_LIST"),
-					Value: []byte("LIST"),
+					Name:        []byte("__TypeKind_LIST"),
+					Description: []byte("Indicates this type is a list. `ofType` is a valid field."),
+					Value:       []byte("LIST"),
 				},
 				{
-					Name:  []byte("__TypeKind_NON_NULL"),
-					Value: []byte("NON_NULL"),
+					Name:        []byte("__TypeKind_NON_NULL"),
+					Description: []byte("Indicates this type is a non-null. `ofType` is a valid field."),
+					Value:       []byte("NON_NULL"),
 				},
 			},
-			Directives: nil,
-			Extentions: nil,
 		},
 	}
 	directiveLocationIntrospectionFields = []*EnumDefinition{
 		{
-			Name: []byte("__DirectiveLocation"),
+			Name:        []byte("__DirectiveLocation"),
+			Description: []byte("A Directive can be adjacent to many parts of the GraphQL language, a __DirectiveLocation describes one such possible adjacencies."),
 			Type: &FieldType{
 				Name:     []byte("__DirectiveLocation"),
 				IsList:   false,
 				Nullable: false,
-				ListType: nil,
 			},
 			Values: []*EnumElement{
 				{
-					Name:  []byte("__DirectiveLocation_QUERY"),
-					Value: []byte("QUERY"),
+					Name:        []byte("__DirectiveLocation_QUERY"),
+					Description: []byte("Location adjacent to a query operation."),
+					Value:       []byte("QUERY"),
 				},
 				{
-					Name:  []byte("__DirectiveLocation_MUTATION"),
-					Value: []byte("MUTATION"),
+					Name:        []byte("__DirectiveLocation_MUTATION"),
+					Description: []byte("Location adjacent to a mutation operation."),
+					Value:       []byte("MUTATION"),
 				},
 				{
-					Name:  []byte("__DirectiveLocation_SUBSCRIPTION"),
-					Value: []byte("SUBSCRIPTION"),
+					Name:        []byte("__DirectiveLocation_SUBSCRIPTION"),
+					Description: []byte("Location adjacent to a subscription operation."),
+					Value:       []byte("SUBSCRIPTION"),
 				},
 				{
-					Name:  []byte("__DirectiveLocation_FIELD"),
-					Value: []byte("FIELD"),
+					Name:        []byte("__DirectiveLocation_FIELD"),
+					Description: []byte("Location adjacent to a field."),
+					Value:       []byte("FIELD"),
 				},
 				{
-					Name:  []byte("__DirectiveLocation_FRAGMENT_DEFINITION"),
-					Value: []byte("FRAGMENT_DEFINITION"),
+					Name:        []byte("__DirectiveLocation_FRAGMENT_DEFINITION"),
+					Description: []byte("Location adjacent to a fragment definition."),
+					Value:       []byte("FRAGMENT_DEFINITION"),
 				},
 				{
-					Name:  []byte("__DirectiveLocation_FRAGMENT_SPREAD"),
-					Value: []byte("FRAGMENT_SPREAD"),
+					Name:        []byte("__DirectiveLocation_FRAGMENT_SPREAD"),
+					Description: []byte("Location adjacent to a fragment spread."),
+					Value:       []byte("FRAGMENT_SPREAD"),
 				},
 				{
-					Name:  []byte("__DirectiveLocation_INLINE_FRAGMENT"),
-					Value: []byte("INLINE_FRAGMENT"),
+					Name:        []byte("__DirectiveLocation_INLINE_FRAGMENT"),
+					Description: []byte("Location adjacent to an inline fragment."),
+					Value:       []byte("INLINE_FRAGMENT"),
 				},
 				{
-					Name:  []byte("__DirectiveLocation_VARIABLE_DEFINITION"),
-					Value: []byte("VARIABLE_DEFINITION"),
+					Name:        []byte("__DirectiveLocation_VARIABLE_DEFINITION"),
+					Description: []byte("Location adjacent to a variable definition."),
+					Value:       []byte("VARIABLE_DEFINITION"),
 				},
 				{
-					Name:  []byte("__DirectiveLocation_SCHEMA"),
-					Value: []byte("SCHEMA"),
+					Name:        []byte("__DirectiveLocation_SCHEMA"),
+					Description: []byte("Location adjacent to a schema definition."),
+					Value:       []byte("SCHEMA"),
 				},
 				{
-					Name:  []byte("__DirectiveLocation_SCALAR"),
-					Value: []byte("SCALAR"),
+					Name:        []byte("__DirectiveLocation_SCALAR"),
+					Description: []byte("Location adjacent to a scalar definition."),
+					Value:       []byte("SCALAR"),
 				},
 				{
-					Name:  []byte("__DirectiveLocation_OBJECT"),
-					Value: []byte("OBJECT"),
+					Name:        []byte("__DirectiveLocation_OBJECT"),
+					Description: []byte("Location adjacent to an object type definition."),
+					Value:       []byte("OBJECT"),
 				},
 				{
-					Name:  []byte("__DirectiveLocation_FIELD_DEFINITION"),
-					Value: []byte("FIELD_DEFINITION"),
+					Name:        []byte("__DirectiveLocation_FIELD_DEFINITION"),
+					Description: []byte("Location adjacent to a field definition."),
+					Value:       []byte("FIELD_DEFINITION"),
 				},
 				{
-					Name:  []byte("__DirectiveLocation_ARGUMENT_DEFINITION"),
-					Value: []byte("ARGUMENT_DEFINITION"),
+					Name:        []byte("__DirectiveLocation_ARGUMENT_DEFINITION"),
+					Description: []byte("Location adjacent to an argument definition."),
+					Value:       []byte("ARGUMENT_DEFINITION"),
 				},
 				{
-					Name:  []byte("__DirectiveLocation_INTERFACE"),
-					Value: []byte("INTERFACE"),
+					Name:        []byte("__DirectiveLocation_INTERFACE"),
+					Description: []byte("Location adjacent to an interface definition."),
+					Value:       []byte("INTERFACE"),
 				},
 				{
-					Name:  []byte("__DirectiveLocation_UNION"),
-					Value: []byte("UNION"),
+					Name:        []byte("__DirectiveLocation_UNION"),
+					Description: []byte("Location adjacent to a union definition."),
+					Value:       []byte("UNION"),
 				},
 				{
-					Name:  []byte("__DirectiveLocation_ENUM"),
-					Value: []byte("ENUM"),
+					Name:        []byte("__DirectiveLocation_ENUM"),
+					Description: []byte("Location adjacent to an enum definition."),
+					Value:       []byte("ENUM"),
 				},
 				{
-					Name:  []byte("__DirectiveLocation_ENUM_VALUE"),
-					Value: []byte("ENUM_VALUE"),
+					Name:        []byte("__DirectiveLocation_ENUM_VALUE"),
+					Description: []byte("Location adjacent to an enum value definition."),
+					Value:       []byte("ENUM_VALUE"),
 				},
 				{
-					Name:  []byte("__DirectiveLocation_INPUT_OBJECT"),
-					Value: []byte("INPUT_OBJECT"),
+					Name:        []byte("__DirectiveLocation_INPUT_OBJECT"),
+					Description: []byte("Location adjacent to an input object type definition."),
+					Value:       []byte("INPUT_OBJECT"),
 				},
 				{
-					Name:  []byte("__DirectiveLocation_INPUT_FIELD_DEFINITION"),
-					Value: []byte("INPUT_FIELD_DEFINITION"),
+					Name:        []byte("__DirectiveLocation_INPUT_FIELD_DEFINITION"),
+					Description: []byte("Location adjacent to an input object field definition."),
+					Value:       []byte("INPUT_FIELD_DEFINITION"),
 				},
 			},
-			Directives: nil,
-			Extentions: nil,
 		},
 	}
 )
@@ -613,52 +584,40 @@ var (
 func WithTypeIntrospection(schema *Schema) *Schema {
 	types := []*TypeDefinition{
 		{
-			Name:       []byte("__Schema"),
-			Fields:     schemaIntrospectionFields,
-			Required:   make(map[*FieldDefinition]struct{}),
-			Interfaces: nil,
-			Directives: nil,
-			Extentions: nil,
+			Name:        []byte("__Schema"),
+			Description: []byte("A GraphQL Schema defines the capabilities of a GraphQL server. It exposes all available types and directives on the server, as well as the entry points for query, mutation, and subscription operations."),
+			Fields:      schemaIntrospectionFields,
+			Required:    make(map[*FieldDefinition]struct{}),
 		},
 		{
-			Name:       []byte("__Type"),
-			Fields:     typeIntrospectionFields,
-			Required:   make(map[*FieldDefinition]struct{}),
-			Interfaces: nil,
-			Directives: nil,
-			Extentions: nil,
+			Name:        []byte("__Type"),
+			Description: []byte("The fundamental unit of any GraphQL Schema is the type. There are many kinds of types in GraphQL as represented by the `__TypeKind` enum.\n\nDepending on the kind of a type, certain fields describe information about that type. Scalar types provide no information beyond a name, description and optional `specifiedByURL`, while Enum types provide their values. Object and Interface types provide the fields they describe. Abstract types, Union and Interface, provide the Object types possible at runtime. List and NonNull types compose other types."),
+			Fields:      typeIntrospectionFields,
+			Required:    make(map[*FieldDefinition]struct{}),
 		},
 		{
-			Name:       []byte("__Field"),
-			Fields:     fieldIntrospectionFields,
-			Required:   make(map[*FieldDefinition]struct{}),
-			Interfaces: nil,
-			Directives: nil,
-			Extentions: nil,
+			Name:        []byte("__Field"),
+			Description: []byte("Object and Interface types are described by a list of Fields, each of which has a name, potentially a list of arguments, and a return type."),
+			Fields:      fieldIntrospectionFields,
+			Required:    make(map[*FieldDefinition]struct{}),
 		},
 		{
-			Name:       []byte("__InputValue"),
-			Fields:     inputValueIntrospectionFields,
-			Required:   make(map[*FieldDefinition]struct{}),
-			Interfaces: nil,
-			Directives: nil,
-			Extentions: nil,
+			Name:        []byte("__InputValue"),
+			Description: []byte("Arguments provided to Fields or Directives and the input fields of an InputObject are represented as Input Values which describe their type and optionally a default value."),
+			Fields:      inputValueIntrospectionFields,
+			Required:    make(map[*FieldDefinition]struct{}),
 		},
 		{
-			Name:       []byte("__EnumValue"),
-			Fields:     enumValueIntrospectionFields,
-			Required:   make(map[*FieldDefinition]struct{}),
-			Interfaces: nil,
-			Directives: nil,
-			Extentions: nil,
+			Name:        []byte("__EnumValue"),
+			Description: []byte("One possible value for a given Enum. Enum values are unique values, not a placeholder for a string or numeric value. However an Enum value is returned in a JSON response as a string."),
+			Fields:      enumValueIntrospectionFields,
+			Required:    make(map[*FieldDefinition]struct{}),
 		},
 		{
-			Name:       []byte("__Directive"),
-			Fields:     directiveIntrospectionFields,
-			Required:   make(map[*FieldDefinition]struct{}),
-			Interfaces: nil,
-			Directives: nil,
-			Extentions: nil,
+			Name:        []byte("__Directive"),
+			Description: []byte("A Directive provides a way to describe alternate runtime execution and type validation behavior in a GraphQL document.\n\nIn some cases, you need to provide options to alter GraphQL's execution behavior in ways field arguments will not suffice, such as conditionally including or skipping a field. Directives provide this by describing additional information to the executor."),
+			Fields:      directiveIntrospectionFields,
+			Required:    make(map[*FieldDefinition]struct{}),
 		},
 	}
 
@@ -1,6 +1,7 @@
 package schema
 
 import (
+	"bytes"
 	"fmt"
 	"unicode"
 )
@@ -14,13 +15,14 @@ const (
 	Identifier        Type = "IDENTIFIER"
 	Field             Type = "FIELD"
 
-	Extend    Type = "EXTEND"
-	Scalar    Type = "SCALAR"
-	Enum      Type = "ENUM"
-	Input     Type = "INPUT"
-	Interface Type = "INTERFACE"
-	Union     Type = "UNION"
-	Comment   Type = "COMMENT"
+	Extend      Type = "EXTEND"
+	Scalar      Type = "SCALAR"
+	Enum        Type = "ENUM"
+	Input       Type = "INPUT"
+	Interface   Type = "INTERFACE"
+	Union       Type = "UNION"
+	Comment     Type = "COMMENT"
+	Description Type = "DESCRIPTION"
 
 	Value Type = "VALUE"
 
@@ -90,7 +92,7 @@ var (
 
 func newIdentifierToken(input []byte, cur, col, line int) (*Token, int) {
 	start := cur
-	for cur < len(input) && unicode.IsLetter(rune(input[cur])) {
+	for cur < len(input) && (unicode.IsLetter(rune(input[cur])) || unicode.IsDigit(rune(input[cur])) || input[cur] == '_') {
 		cur++
 	}
 
@@ -213,6 +215,17 @@ func newDirectiveDeclearationArgumentTokens(input []byte, cur, col, line int) (T
 			continue
 		}
 
+		if input[cur] == '#' {
+			cur = skipComment(input, cur)
+			continue
+		}
+
+		if input[cur] == '"' {
+			token, cur, line, col = newDescriptionToken(input, cur, col, line)
+			tokens = append(tokens, token)
+			continue
+		}
+
 		if t, ok := punctuators[punctuator(input[cur])]; ok {
 			token, cur = newPunctuatorToken(input, t, cur, col, line)
 			tokens = append(tokens, token)
@@ -269,6 +282,17 @@ func newEnumTokens(input []byte, cur, col, line int) (Tokens, int, int, int) {
 			continue
 		}
 
+		if input[cur] == '#' {
+			cur = skipComment(input, cur)
+			continue
+		}
+
+		if input[cur] == '"' {
+			token, cur, line, col = newDescriptionToken(input, cur, col, line)
+			tokens = append(tokens, token)
+			continue
+		}
+
 		if tokens.isDirectiveApplication() {
 			newTokens, newCur := newDirectiveApplication(input, cur, line, col)
 			tokens = append(tokens, newTokens...)
@@ -582,6 +606,61 @@ func newComment(input []byte, cur, col, line int) (*Token, int) {
 	return &Token{Type: Comment, Value: input[start:cur], Column: col, Line: line}, cur
 }
 
+var blockStringQuote = []byte(`"""`)
+
+func newDescriptionToken(input []byte, cur, col, line int) (*Token, int, int, int) {
+	start, startCol, startLine := cur, col, line
+
+	if bytes.HasPrefix(input[cur:], blockStringQuote) {
+		cur += 3
+		col += 3
+		for cur < len(input) && !bytes.HasPrefix(input[cur:], blockStringQuote) {
+			if input[cur] == '\\' && bytes.HasPrefix(input[cur+1:], blockStringQuote) {
+				cur += 4
+				col += 4
+				continue
+			}
+
+			if input[cur] == '\n' {
+				line++
+				col = 1
+			} else {
+				col++
+			}
+			cur++
+		}
+
+		cur = min(cur+3, len(input))
+		col += 3
+
+		return &Token{Type: Description, Value: input[start:cur], Column: startCol, Line: startLine}, cur, line, col
+	}
+
+	cur++
+	col++
+	for cur < len(input) && input[cur] != '"' && input[cur] != '\n' {
+		if input[cur] == '\\' {
+			cur++
+			col++
+		}
+		cur++
+		col++
+	}
+
+	cur = min(cur+1, len(input))
+	col++
+
+	return &Token{Type: Description, Value: input[start:cur], Column: startCol, Line: startLine}, cur, line, col
+}
+
+func skipComment(input []byte, cur int) int {
+	for cur < len(input) && input[cur] != '\n' {
+		cur++
+	}
+
+	return cur
+}
+
 type Lexer struct{}
 
 func NewLexer() *Lexer {
@@ -616,16 +695,10 @@ func (l *Lexer) Lex(input []byte) ([]*Token, error) {
 			continue
 		}
 
-		if input[cur] == '"' {
-			if len(input) > cur+2 {
-				if input[cur+1] == '"' && input[cur+2] == '"' {
-					comment, newCur := newComment(input, cur, col, line)
-					tokens = append(tokens, comment)
-					col = 1
-					cur = newCur
-					continue
-				}
-			}
+		if input[cur] == '"' && !tokens.isDefaultArgument() && !tokens.isDirectiveArgument() {
+			token, cur, line, col = newDescriptionToken(input, cur, col, line)
+			tokens = append(tokens, token)
+			continue
 		}
 
 		if tokens.isEnum() {
@@ -688,7 +761,7 @@ func (l *Lexer) Lex(input []byte) ([]*Token, error) {
 			continue
 		}
 
-		if unicode.IsLetter(rune(input[cur])) || unicode.IsDigit(rune(input[cur])) {
+		if unicode.IsLetter(rune(input[cur])) || unicode.IsDigit(rune(input[cur])) || input[cur] == '_' {
 			keyword := keyword(input[cur:end])
 			if t, ok := keywords[keyword]; ok && tokens.isTopLevel() {
 				token, cur = newKeywordToken(input, t, cur, col, line)
@@ -893,6 +893,39 @@ func TestLexer_Lex(t *testing.T) {
 				{Type: schema.EOF, Value: nil, Column: 4, Line: 7},
 			},
 		},
+		{
+			name: "Lex descriptions",
+			input: []byte(`"""
+Represents a user.
+"""
+type User {
+	"the user id"
+	id: ID!
+}
+enum Role {
+	"administrator"
+	ADMIN
+}`),
+			expected: []*schema.Token{
+				{Type: schema.Description, Value: []byte("\"\"\"\nRepresents a user.\n\"\"\""), Line: 1, Column: 1},
+				{Type: schema.ReservedType, Value: []byte("type"), Line: 4, Column: 1},
+				{Type: schema.Identifier, Value: []byte("User"), Line: 4, Column: 6},
+				{Type: schema.CurlyOpen, Value: []byte("{"), Line: 4, Column: 11},
+				{Type: schema.Description, Value: []byte(`"the user id"`), Line: 5, Column: 2},
+				{Type: schema.Field, Value: []byte("id"), Line: 6, Column: 2},
+				{Type: schema.Colon, Value: []byte(":"), Line: 6, Column: 4},
+				{Type: schema.Identifier, Value: []byte("ID"), Line: 6, Column: 6},
+				{Type: schema.Exclamation, Value: []byte("!"), Line: 6, Column: 8},
+				{Type: schema.CurlyClose, Value: []byte("}"), Line: 7, Column: 1},
+				{Type: schema.Enum, Value: []byte("enum"), Line: 8, Column: 1},
+				{Type: schema.Identifier, Value: []byte("Role"), Line: 8, Column: 6},
+				{Type: schema.CurlyOpen, Value: []byte("{"), Line: 8, Column: 11},
+				{Type: schema.Description, Value: []byte(`"administrator"`), Line: 9, Column: 2},
+				{Type: schema.Identifier, Value: []byte("ADMIN"), Line: 10, Column: 2},
+				{Type: schema.CurlyClose, Value: []byte("}"), Line: 11, Column: 1},
+				{Type: schema.EOF, Value: nil, Line: 11, Column: 2},
+			},
+		},
 		{
 			name: "Lex extend schema definition",
 			input: []byte(`schema {
@@ -1514,7 +1547,7 @@ func TestLexer_Lex(t *testing.T) {
 				{Type: schema.Colon, Value: []byte(":"), Line: 3, Column: 7},
 				{Type: schema.Identifier, Value: []byte("ID"), Line: 3, Column: 9},
 				{Type: schema.Exclamation, Value: []byte("!"), Line: 3, Column: 11},
-				{Type: schema.Comment, Value: []byte(`"""hoge"""`), Line: 4, Column: 5},
+				{Type: schema.Description, Value: []byte(`"""hoge"""`), Line: 4, Column: 5},
 				{Type: schema.Field, Value: []byte("hoge"), Line: 5, Column: 5},
 				{Type: schema.Colon, Value: []byte(":"), Line: 5, Column: 9},
 				{Type: schema.Identifier, Value: []byte("String"), Line: 5, Column: 11},
@@ -25,7 +25,7 @@ func (p *Parser) Parse(input []byte) (*Schema, error) {
 	cur := 0
 	for cur < len(tokens) {
 		switch tokens[cur].Type {
-		case Comment:
+		case Comment, Description:
 			cur++
 		case Extend:
 			cur++
@@ -163,7 +163,8 @@ func (p *Parser) parseScalarDefinition(tokens Tokens, cur int) (*ScalarDefinitio
 	}
 
 	scalarDefinition := &ScalarDefinition{
-		Name: tokens[cur].Value,
+		Name:        tokens[cur].Value,
+		Description: descriptionBefore(tokens, cur-1),
 	}
 	cur++
 
@@ -260,7 +261,9 @@ func (p *Parser) parseExtendDefinition(schema *Schema, tokens Tokens, cur int) (
 }
 
 func (p *Parser) parseSchemaDefinition(tokens Tokens, cur int) (*SchemaDefinition, int, error) {
-	definition := new(SchemaDefinition)
+	definition := &SchemaDefinition{
+		Description: descriptionBefore(tokens, cur-1),
+	}
 	if tokens[cur].Type == At {
 		directives, newCur, err := p.parseDirectives(tokens, cur)
 		if err != nil {
@@ -325,8 +328,9 @@ func (p *Parser) parseSchemaDefinition(tokens Tokens, cur int) (*SchemaDefinitio
 
 func (p *Parser) parseTypeDefinition(schema *Schema, tokens Tokens, cur int) (*TypeDefinition, int, error) {
 	definition := &TypeDefinition{
-		Fields: make([]*FieldDefinition, 0),
-		Name:   tokens[cur].Value,
+		Fields:      make([]*FieldDefinition, 0),
+		Name:        tokens[cur].Value,
+		Description: descriptionBefore(tokens, cur-1),
 	}
 
 	cur++
@@ -372,7 +376,7 @@ func (p *Parser) parseTypeDefinition(schema *Schema, tokens Tokens, cur int) (*T
 	cur++
 	for cur < len(tokens) {
 		switch tokens[cur].Type {
-		case Comment:
+		case Comment, Description:
 			cur++
 			continue
 		case Field:
@@ -393,8 +397,9 @@ func (p *Parser) parseTypeDefinition(schema *Schema, tokens Tokens, cur int) (*T
 
 func (p *Parser) parseInputDefinition(tokens Tokens, cur int) (*InputDefinition, int, error) {
 	definition := &InputDefinition{
-		Fields: make([]*FieldDefinition, 0),
-		Name:   tokens[cur].Value,
+		Fields:      make([]*FieldDefinition, 0),
+		Name:        tokens[cur].Value,
+		Description: descriptionBefore(tokens, cur-1),
 	}
 
 	cur++
@@ -405,6 +410,9 @@ func (p *Parser) parseInputDefinition(tokens Tokens, cur int) (*InputDefinition,
 	cur++
 	for cur < len(tokens) {
 		switch tokens[cur].Type {
+		case Comment, Description:
+			cur++
+			continue
 		case Field:
 			fieldDefinitions, newCur, err := p.parseFieldDefinitions(tokens, cur, true)
 			if err != nil {
@@ -428,7 +436,8 @@ func (p *Parser) parseEnumDefinition(tokens Tokens, cur int) (*EnumDefinition, i
 	}
 
 	enumDefinition := &EnumDefinition{
-		Name: tokens[cur].Value,
+		Name:        tokens[cur].Value,
+		Description: descriptionBefore(tokens, cur-1),
 	}
 	cur++
 
@@ -448,6 +457,8 @@ func (p *Parser) parseEnumDefinition(tokens Tokens, cur int) (*EnumDefinition, i
 	cur++
 	for cur < len(tokens) {
 		switch tokens[cur].Type {
+		case Comment, Description:
+			cur++
 		case Identifier:
 			element, newCur, err := p.parseEnumElement(tokens, cur)
 			if err != nil {
@@ -473,8 +484,9 @@ func (p *Parser) parseEnumElement(tokens Tokens, cur int) (*EnumElement, int, er
 	}
 
 	element := &EnumElement{
-		Name:  tokens[cur].Value,
-		Value: tokens[cur].Value,
+		Name:        tokens[cur].Value,
+		Description: descriptionBefore(tokens, cur),
+		Value:       tokens[cur].Value,
 	}
 	cur++
 
@@ -520,13 +532,14 @@ func (p *Parser) parseOperationDefinition(tokens Tokens, cur int) (*OperationDef
 
 	operationDefinition := &OperationDefinition{
 		OperationType: operationType,
+		Description:   descriptionBefore(tokens, cur-2),
 		Fields:        make([]*FieldDefinition, 0),
 	}
 	cur++
 
 	for cur < len(tokens) {
 		switch tokens[cur].Type {
-		case Comment:
+		case Comment, Description:
 			cur++
 			continue
 		case Field:
@@ -546,7 +559,9 @@ func (p *Parser) parseOperationDefinition(tokens Tokens, cur int) (*OperationDef
 }
 
 func (p *Parser) parseDirectiveDefinition(tokens Tokens, cur int) (*DirectiveDefinition, int, error) {
-	definition := new(DirectiveDefinition)
+	definition := &DirectiveDefinition{
+		Description: descriptionBefore(tokens, cur-1),
+	}
 	if tokens[cur].Type != At {
 		return nil, 0, fmt.Errorf("expected '@' but got %s", string(tokens[cur].Value))
 	}
@@ -618,6 +633,9 @@ func (p *Parser) parseOperationFields(tokens Tokens, cur int) ([]*FieldDefinitio
 
 	for cur < len(tokens) {
 		switch tokens[cur].Type {
+		case Comment, Description:
+			cur++
+			continue
 		case Field:
 			fieldDefinition, newCur, err := p.parseOperationField(tokens, cur)
 			if err != nil {
@@ -638,10 +656,11 @@ func (p *Parser) parseOperationFields(tokens Tokens, cur int) ([]*FieldDefinitio
 
 func (p *Parser) parseOperationField(tokens Tokens, cur int) (*FieldDefinition, int, error) {
 	definition := &FieldDefinition{
-		Name:      tokens[cur].Value,
-		Arguments: make([]*ArgumentDefinition, 0),
-		Type:      nil,
-		Location:  &Location{Name: []byte("FIELD_DEFINITION")},
+		Name:        tokens[cur].Value,
+		Description: descriptionBefore(tokens, cur),
+		Arguments:   make([]*ArgumentDefinition, 0),
+		Type:        nil,
+		Location:    &Location{Name: []byte("FIELD_DEFINITION")},
 	}
 	cur++
 
@@ -760,9 +779,9 @@ func (p *Parser) parseArguments(tokens Tokens, cur int) ([]*ArgumentDefinition,
 	args := make([]*ArgumentDefinition, 0)
 	for cur < len(tokens) {
 		switch tokens[cur].Type {
-		case On:
+		case On, Repeatable:
 			return args, cur, nil
-		case ParenOpen, Comma:
+		case ParenOpen, Comma, Comment, Description:
 			cur++
 			continue
 		case Field:
@@ -777,6 +796,8 @@ func (p *Parser) parseArguments(tokens Tokens, cur int) ([]*ArgumentDefinition,
 		case ParenClose:
 			cur++
 			return args, cur, nil
+		default:
+			return nil, 0, fmt.Errorf("unexpected token %s", string(tokens[cur].Value))
 		}
 	}
 
@@ -785,7 +806,8 @@ func (p *Parser) parseArguments(tokens Tokens, cur int) ([]*ArgumentDefinition,
 
 func (p *Parser) parseArgument(tokens Tokens, cur int) (*ArgumentDefinition, int, error) {
 	arg := &ArgumentDefinition{
-		Name: tokens[cur].Value,
+		Name:        tokens[cur].Value,
+		Description: descriptionBefore(tokens, cur),
 	}
 	cur++
 
@@ -812,6 +834,15 @@ func (p *Parser) parseArgument(tokens Tokens, cur int) (*ArgumentDefinition, int
 		}
 	}
 
+	if tokens[cur].Type == At {
+		directives, newCur, err := p.parseDirectives(tokens, cur)
+		if err != nil {
+			return nil, 0, err
+		}
+		arg.Directives = directives
+		cur = newCur
+	}
+
 	return arg, cur, nil
 }
 
@@ -822,9 +853,10 @@ func (p *Parser) parseInterfaceDefinition(tokens Tokens, cur int, schema *Schema
 	}
 
 	interfaceDefinition := &InterfaceDefinition{
-		Name:       tokens[cur].Value,
-		Fields:     make([]*FieldDefinition, 0),
-		Interfaces: make([][]byte, 0),
+		Name:        tokens[cur].Value,
+		Description: descriptionBefore(tokens, cur-1),
+		Fields:      make([]*FieldDefinition, 0),
+		Interfaces:  make([][]byte, 0),
 	}
 	cur++
 
@@ -869,7 +901,7 @@ func (p *Parser) parseInterfaceDefinition(tokens Tokens, cur int, schema *Schema
 	cur++
 	for cur < len(tokens) {
 		switch tokens[cur].Type {
-		case Comment:
+		case Comment, Description:
 			cur++
 			continue
 		case Field:
@@ -895,7 +927,7 @@ func (p *Parser) parseFieldDefinitions(tokens Tokens, cur int, isInputField bool
 		switch tokens[cur].Type {
 		case CurlyOpen, ParenOpen:
 			cur++
-		case Comment:
+		case Comment, Description:
 			cur++
 			continue
 		case Field:
@@ -933,8 +965,9 @@ func (p *Parser) parseFieldDefinition(tokens Tokens, cur int, isInputField bool)
 	}
 
 	definition := &FieldDefinition{
-		Name:     tokens[cur].Value,
-		Location: location,
+		Name:        tokens[cur].Value,
+		Description: descriptionBefore(tokens, cur),
+		Location:    location,
 	}
 
 	cur++
@@ -1020,7 +1053,8 @@ func (p *Parser) parseUnionDefinition(tokens Tokens, cur int) (*UnionDefinition,
 	}
 
 	unionDefinition := &UnionDefinition{
-		Name: tokens[cur].Value,
+		Name:        tokens[cur].Value,
+		Description: descriptionBefore(tokens, cur-1),
 	}
 	cur++
 
@@ -1060,7 +1094,7 @@ func (p *Parser) parseUnionDefinition(tokens Tokens, cur int) (*UnionDefinition,
 			}
 
 			return unionDefinition, cur, nil
-		case ReservedType, Union, Enum, Interface, Input, Extend, ReservedSchema, Comment:
+		case ReservedType, Union, Enum, Interface, Input, Extend, ReservedSchema, Scalar, ReservedDirective, Comment, Description:
 			return unionDefinition, cur, nil
 		default:
 			return nil, 0, fmt.Errorf("unexpected token %s", string(tokens[cur].Value))
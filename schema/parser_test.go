@@ -2153,8 +2153,9 @@ func TestParser_Parse(t *testing.T) {
 						Description: []byte("Directs the executor to skip this field or fragment when the `if` argument is true."),
 						Arguments: []*schema.ArgumentDefinition{
 							{
-								Name: []byte("if"),
-								Type: &schema.FieldType{Name: []byte("Boolean"), Nullable: false},
+								Name:        []byte("if"),
+								Description: []byte("Skipped when true."),
+								Type:        &schema.FieldType{Name: []byte("Boolean"), Nullable: false},
 							},
 						},
 						Repeatable: false,
@@ -2175,8 +2176,9 @@ func TestParser_Parse(t *testing.T) {
 						Description: []byte("Directs the executor to include this field or fragment only when the `if` argument is true."),
 						Arguments: []*schema.ArgumentDefinition{
 							{
-								Name: []byte("if"),
-								Type: &schema.FieldType{Name: []byte("Boolean"), Nullable: false},
+								Name:        []byte("if"),
+								Description: []byte("Included when true."),
+								Type:        &schema.FieldType{Name: []byte("Boolean"), Nullable: false},
 							},
 						},
 						Repeatable: false,
@@ -2197,9 +2199,10 @@ func TestParser_Parse(t *testing.T) {
 						Description: []byte("Marks an element of a GraphQL schema as no longer supported."),
 						Arguments: []*schema.ArgumentDefinition{
 							{
-								Name:    []byte("reason"),
-								Type:    &schema.FieldType{Name: []byte("String"), Nullable: true},
-								Default: []byte("No longer supported"),
+								Name:        []byte("reason"),
+								Description: []byte("Explains why this element was deprecated, usually also including a suggestion for how to access supported similar data. Formatted using the Markdown syntax, as specified by [CommonMark](https://commonmark.org/)."),
+								Type:        &schema.FieldType{Name: []byte("String"), Nullable: true},
+								Default:     []byte(`"No longer supported"`),
 							},
 						},
 						Repeatable: false,
@@ -2207,6 +2210,12 @@ func TestParser_Parse(t *testing.T) {
 							{
 								Name: []byte("FIELD_DEFINITION"),
 							},
+							{
+								Name: []byte("ARGUMENT_DEFINITION"),
+							},
+							{
+								Name: []byte("INPUT_FIELD_DEFINITION"),
+							},
 							{
 								Name: []byte("ENUM_VALUE"),
 							},
@@ -2214,11 +2223,12 @@ func TestParser_Parse(t *testing.T) {
 					},
 					{
 						Name:        []byte("specifiedBy"),
-						Description: []byte("Exposes a URL that specifies the behaviour of this scalar."),
+						Description: []byte("Exposes a URL that specifies the behavior of this scalar."),
 						Arguments: []*schema.ArgumentDefinition{
 							{
-								Name: []byte("url"),
-								Type: &schema.FieldType{Name: []byte("String"), Nullable: false},
+								Name:        []byte("url"),
+								Description: []byte("The URL that specifies the behavior of this scalar."),
+								Type:        &schema.FieldType{Name: []byte("String"), Nullable: false},
 							},
 						},
 						Repeatable: false,
@@ -2309,6 +2319,66 @@ func TestParser_Parse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Directives on arguments",
+			input: []byte(`
+				type Query {
+					users(first: Int = 10 @max(value: 100), name: String @trim): [User]
+				}`),
+			want: &schema.Schema{
+				Definition: &schema.SchemaDefinition{
+					Query:        []byte("Query"),
+					Mutation:     []byte("Mutation"),
+					Subscription: []byte("Subscription"),
+				},
+				Directives: schema.NewBuildInDirectives(),
+				Operations: []*schema.OperationDefinition{
+					{
+						OperationType: schema.QueryOperation,
+						Fields: []*schema.FieldDefinition{
+							{
+								Name:       []byte("users"),
+								Type:       &schema.FieldType{IsList: true, Nullable: true, ListType: &schema.FieldType{Name: []byte("User"), Nullable: true}},
+								Location:   &schema.Location{Name: []byte("FIELD_DEFINITION")},
+								Directives: []*schema.Directive{},
+								Arguments: []*schema.ArgumentDefinition{
+									{
+										Name:    []byte("first"),
+										Type:    &schema.FieldType{Name: []byte("Int"), Nullable: true},
+										Default: []byte("10"),
+										Directives: []*schema.Directive{
+											{
+												Name: []byte("max"),
+												Arguments: []*schema.DirectiveArgument{
+													{Name: []byte("value"), Value: []byte("100")},
+												},
+											},
+										},
+									},
+									{
+										Name: []byte("name"),
+										Type: &schema.FieldType{Name: []byte("String"), Nullable: true},
+										Directives: []*schema.Directive{
+											{Name: []byte("trim")},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				Indexes: &schema.Indexes{
+					TypeIndex:        make(map[string]*schema.TypeDefinition),
+					OperationIndexes: make(map[schema.OperationType]map[string]*schema.OperationDefinition),
+					EnumIndex:        make(map[string]*schema.EnumDefinition),
+					UnionIndex:       make(map[string]*schema.UnionDefinition),
+					InterfaceIndex:   make(map[string]*schema.InterfaceDefinition),
+					InputIndex:       make(map[string]*schema.InputDefinition),
+					ScalarIndex:      make(map[string]*schema.ScalarDefinition),
+					ExtendIndex:      make(map[string]schema.ExtendDefinition),
+				},
+			},
+		},
 		{
 			name: "Directive definition with arguments, repeatable, multiple locations",
 			input: []byte(`
@@ -2366,6 +2436,48 @@ func TestParser_Parse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Repeatable directive definition without arguments and underscore names",
+			input: []byte(`
+				scalar _Any
+				directive @shareable repeatable on OBJECT | FIELD_DEFINITION
+			`),
+			want: &schema.Schema{
+				Definition: &schema.SchemaDefinition{
+					Query:        []byte("Query"),
+					Mutation:     []byte("Mutation"),
+					Subscription: []byte("Subscription"),
+				},
+				Directives: func() schema.DirectiveDefinitions {
+					directives := schema.NewBuildInDirectives()
+					directives = append(directives, &schema.DirectiveDefinition{
+						Name:       []byte("shareable"),
+						Arguments:  []*schema.ArgumentDefinition{},
+						Repeatable: true,
+						Locations: []*schema.Location{
+							{Name: []byte("OBJECT")},
+							{Name: []byte("FIELD_DEFINITION")},
+						},
+					})
+					return directives
+				}(),
+				Indexes: &schema.Indexes{
+					TypeIndex:        make(map[string]*schema.TypeDefinition),
+					OperationIndexes: make(map[schema.OperationType]map[string]*schema.OperationDefinition),
+					EnumIndex:        make(map[string]*schema.EnumDefinition),
+					UnionIndex:       make(map[string]*schema.UnionDefinition),
+					InterfaceIndex:   make(map[string]*schema.InterfaceDefinition),
+					InputIndex:       make(map[string]*schema.InputDefinition),
+					ScalarIndex:      make(map[string]*schema.ScalarDefinition),
+					ExtendIndex:      make(map[string]schema.ExtendDefinition),
+				},
+				Scalars: []*schema.ScalarDefinition{
+					{
+						Name: []byte("_Any"),
+					},
+				},
+			},
+		},
 		{
 			name: "Directive usage on enum value",
 			input: []byte(`enum Direction {
@@ -3147,7 +3259,8 @@ func TestParser_Parse(t *testing.T) {
 								Directives: []*schema.Directive{},
 							},
 							{
-								Name: []byte("hoge"),
+								Name:        []byte("hoge"),
+								Description: []byte("hoge"),
 								Type: &schema.FieldType{
 									Name:     []byte("String"),
 									Nullable: true,
@@ -3161,6 +3274,119 @@ func TestParser_Parse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Parse descriptions",
+			input: []byte(`"""
+			Represents a user.
+
+			  Indented line.
+			"""
+			type User {
+				"the user id"
+				id: ID!
+				posts(
+					"max number of posts"
+					first: Int
+				): [String]
+			}
+
+			"escaped \"quote\""
+			enum Role {
+				"""administrator"""
+				ADMIN
+				USER
+			}
+
+			"""Requires a role"""
+			directive @auth("the required role" role: Role) on FIELD_DEFINITION
+			`),
+			want: &schema.Schema{
+				Directives: func() schema.DirectiveDefinitions {
+					directives := schema.NewBuildInDirectives()
+					directives = append(directives, &schema.DirectiveDefinition{
+						Name:        []byte("auth"),
+						Description: []byte("Requires a role"),
+						Arguments: []*schema.ArgumentDefinition{
+							{
+								Name:        []byte("role"),
+								Description: []byte("the required role"),
+								Type: &schema.FieldType{
+									Name:     []byte("Role"),
+									Nullable: true,
+								},
+							},
+						},
+						Locations: []*schema.Location{
+							{Name: []byte("FIELD_DEFINITION")},
+						},
+					})
+					return directives
+				}(),
+				Definition: &schema.SchemaDefinition{
+					Query:        []byte("Query"),
+					Mutation:     []byte("Mutation"),
+					Subscription: []byte("Subscription"),
+				},
+				Types: []*schema.TypeDefinition{
+					{
+						Name:        []byte("User"),
+						Description: []byte("Represents a user.\n\n  Indented line."),
+						Fields: []*schema.FieldDefinition{
+							{
+								Name:        []byte("id"),
+								Description: []byte("the user id"),
+								Type: &schema.FieldType{
+									Name:     []byte("ID"),
+									Nullable: false,
+								},
+								Location:   &schema.Location{Name: []byte("FIELD_DEFINITION")},
+								Directives: []*schema.Directive{},
+							},
+							{
+								Name: []byte("posts"),
+								Arguments: []*schema.ArgumentDefinition{
+									{
+										Name:        []byte("first"),
+										Description: []byte("max number of posts"),
+										Type: &schema.FieldType{
+											Name:     []byte("Int"),
+											Nullable: true,
+										},
+									},
+								},
+								Type: &schema.FieldType{
+									Nullable: true,
+									IsList:   true,
+									ListType: &schema.FieldType{
+										Name:     []byte("String"),
+										Nullable: true,
+									},
+								},
+								Location:   &schema.Location{Name: []byte("FIELD_DEFINITION")},
+								Directives: []*schema.Directive{},
+							},
+						},
+					},
+				},
+				Enums: []*schema.EnumDefinition{
+					{
+						Name:        []byte("Role"),
+						Description: []byte(`escaped "quote"`),
+						Values: []*schema.EnumElement{
+							{
+								Name:        []byte("ADMIN"),
+								Description: []byte("administrator"),
+								Value:       []byte("ADMIN"),
+							},
+							{
+								Name:  []byte("USER"),
+								Value: []byte("USER"),
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -33,6 +33,7 @@ type CompositeType interface {
 
 type TypeDefinition struct {
 	Name              []byte
+	Description       []byte
 	Fields            FieldDefinitions
 	Required          map[*FieldDefinition]struct{}
 	PrimitiveTypeName []byte
@@ -146,6 +147,7 @@ func (f *FieldType) GetNestFieldType(nestCount, currentNestCount int) *FieldType
 type OperationDefinition struct {
 	OperationType OperationType
 	Name          []byte
+	Description   []byte
 	Fields        FieldDefinitions
 	Extentions    []*OperationDefinition
 }
@@ -256,6 +258,7 @@ func NewSchema(tokens Tokens) *Schema {
 			InputIndex:       make(map[string]*InputDefinition),
 			ScalarIndex:      make(map[string]*ScalarDefinition),
 			ExtendIndex:      make(map[string]ExtendDefinition),
+			DirectiveIndex:   make(map[string]*DirectiveDefinition),
 		},
 		Directives: NewBuildInDirectives(),
 	}
@@ -278,6 +281,7 @@ func (s *Schema) mergeOperation(newSchema *Schema) error {
 		newOp := new(OperationDefinition)
 		newOp.OperationType = t.OperationType
 		newOp.Name = t.Name
+		newOp.Description = t.Description
 		newOp.Fields = t.Fields
 
 		extendDefinitions := getOperationDefinitionsFromExtendDefinitions(t.OperationType, s.Extends)
@@ -328,6 +332,7 @@ func (s *Schema) mergeTypeDefinition(newSchema *Schema) error {
 	for _, t := range s.Types {
 		newType := new(TypeDefinition)
 		newType.Name = t.Name
+		newType.Description = t.Description
 		newType.Fields = t.Fields
 		newType.Interfaces = t.Interfaces
 		newType.Directives = t.Directives
@@ -335,7 +340,12 @@ func (s *Schema) mergeTypeDefinition(newSchema *Schema) error {
 
 		newFields := make(FieldDefinitions, 0)
 
-		field := s.extendTypeDefinitionFields(getTypeDefinitionsFromExtendDefinitions(s.Extends, string(newType.Name)))
+		extendDefinitions := getTypeDefinitionsFromExtendDefinitions(s.Extends, string(newType.Name))
+		for _, ext := range extendDefinitions {
+			newType.Directives = append(newType.Directives[:len(newType.Directives):len(newType.Directives)], ext.Directives...)
+		}
+
+		field := s.extendTypeDefinitionFields(extendDefinitions)
 
 		newFields = append(newFields, field...)
 
@@ -382,7 +392,9 @@ func (s *Schema) mergeInterfaceDefinition(newSchema *Schema) error {
 	for _, t := range s.Interfaces {
 		newInterface := new(InterfaceDefinition)
 		newInterface.Name = t.Name
+		newInterface.Description = t.Description
 		newInterface.Fields = t.Fields
+		newInterface.Interfaces = t.Interfaces
 		newInterface.Directives = t.Directives
 
 		newFields := make(FieldDefinitions, 0)
@@ -432,6 +444,7 @@ func (s *Schema) mergeUnionDefinition(newSchema *Schema) error {
 	for _, t := range s.Unions {
 		newUnion := new(UnionDefinition)
 		newUnion.Name = t.Name
+		newUnion.Description = t.Description
 		newUnion.Types = t.Types
 		newUnion.Directives = t.Directives
 
@@ -480,6 +493,7 @@ func (s *Schema) mergeEnumDefinition(newSchema *Schema) error {
 	for _, enum := range s.Enums {
 		newEnum := new(EnumDefinition)
 		newEnum.Name = enum.Name
+		newEnum.Description = enum.Description
 		newEnum.Directives = enum.Directives
 		newEnum.Values = enum.Values
 		newEnum.Type = enum.Type
@@ -519,6 +533,7 @@ func (s *Schema) mergeInputDefinition(newSchema *Schema) error {
 	for _, input := range s.Inputs {
 		newInput := new(InputDefinition)
 		newInput.Name = input.Name
+		newInput.Description = input.Description
 		newInput.Fields = input.Fields
 
 		newFields := make(FieldDefinitions, 0)
@@ -547,6 +562,45 @@ func (s *Schema) mergeInputDefinition(newSchema *Schema) error {
 	return nil
 }
 
+func (s *Schema) mergeScalarDefinition(newSchema *Schema) error {
+	for _, scalar := range s.Scalars {
+		newScalar := new(ScalarDefinition)
+		newScalar.Name = scalar.Name
+		newScalar.Description = scalar.Description
+		newScalar.Directives = scalar.Directives
+
+		for _, ext := range getScalarDefinitionFromExtendDefinitions(s.Extends, string(newScalar.Name)) {
+			newScalar.Directives = append(newScalar.Directives[:len(newScalar.Directives):len(newScalar.Directives)], ext.Directives...)
+		}
+
+		newSchema.Scalars = append(newSchema.Scalars, newScalar)
+		newSchema.Indexes.ScalarIndex[string(newScalar.Name)] = newScalar
+	}
+
+	return nil
+}
+
+func (s *Schema) mergeDirectiveDefinition(newSchema *Schema) {
+	if newSchema.Indexes.DirectiveIndex == nil {
+		newSchema.Indexes.DirectiveIndex = make(map[string]*DirectiveDefinition)
+	}
+
+	for _, directive := range s.Directives {
+		newSchema.Indexes.DirectiveIndex[string(directive.Name)] = directive
+	}
+}
+
+func getScalarDefinitionFromExtendDefinitions(extendDefinitions []ExtendDefinition, name string) []*ScalarDefinition {
+	ret := make([]*ScalarDefinition, 0, len(extendDefinitions))
+	for _, ext := range extendDefinitions {
+		if scalarDef, ok := ext.(*ScalarDefinition); ok && string(scalarDef.Name) == name {
+			ret = append(ret, scalarDef)
+		}
+	}
+
+	return ret
+}
+
 func getInputDefinitionFromExtendDefinitions(extendDefinitions []ExtendDefinition, name string) []*InputDefinition {
 	ret := make([]*InputDefinition, 0, len(extendDefinitions))
 	for _, ext := range extendDefinitions {
@@ -564,7 +618,6 @@ func (s *Schema) Merge() (*Schema, error) {
 	newSchema.Tokens = s.Tokens
 	newSchema.Indexes = s.Indexes
 	newSchema.Directives = s.Directives
-	newSchema.Scalars = s.Scalars
 
 	if err := s.mergeOperation(newSchema); err != nil {
 		return nil, err
@@ -590,6 +643,12 @@ func (s *Schema) Merge() (*Schema, error) {
 		return nil, err
 	}
 
+	if err := s.mergeScalarDefinition(newSchema); err != nil {
+		return nil, err
+	}
+
+	s.mergeDirectiveDefinition(newSchema)
+
 	newSchema = WithTypeIntrospection(newSchema)
 	newSchema = WithBuiltin(newSchema)
 
@@ -673,9 +732,10 @@ func get[T DefinitionType](indexes *Indexes, key string, t T) T {
 }
 
 type ScalarDefinition struct {
-	Name       []byte
-	Directives []*Directive
-	Extentions []*ScalarDefinition
+	Name        []byte
+	Description []byte
+	Directives  []*Directive
+	Extentions  []*ScalarDefinition
 }
 
 func (s *ScalarDefinition) IsDefinition() bool {
@@ -683,6 +743,7 @@ func (s *ScalarDefinition) IsDefinition() bool {
 }
 
 type SchemaDefinition struct {
+	Description  []byte
 	Query        []byte
 	Mutation     []byte
 	Subscription []byte
@@ -358,7 +358,8 @@ func TestSchema_Merge(t *testing.T) {
 				Directives: schema.NewBuildInDirectives(),
 				Interfaces: []*schema.InterfaceDefinition{
 					{
-						Name: []byte("Node"),
+						Name:       []byte("Node"),
+						Interfaces: [][]byte{},
 						Fields: []*schema.FieldDefinition{
 							{
 								Name: []byte("createdAt"),
@@ -418,7 +419,8 @@ func TestSchema_Merge(t *testing.T) {
 				Directives: schema.NewBuildInDirectives(),
 				Interfaces: []*schema.InterfaceDefinition{
 					{
-						Name: []byte("Node"),
+						Name:       []byte("Node"),
+						Interfaces: [][]byte{},
 						Fields: []*schema.FieldDefinition{
 							{
 								Name: []byte("createdAt"),
@@ -620,6 +622,43 @@ func TestSchema_Merge(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Merge extend scalar directives",
+			input: []byte(`scalar DateTime
+
+			extend scalar DateTime @specifiedBy(url: "https://scalars.graphql.org/andimarek/date-time")`),
+			want: &schema.Schema{
+				Indexes: &schema.Indexes{
+					TypeIndex:        make(map[string]*schema.TypeDefinition),
+					OperationIndexes: make(map[schema.OperationType]map[string]*schema.OperationDefinition),
+					EnumIndex:        make(map[string]*schema.EnumDefinition),
+					UnionIndex:       make(map[string]*schema.UnionDefinition),
+					InterfaceIndex:   make(map[string]*schema.InterfaceDefinition),
+					InputIndex:       make(map[string]*schema.InputDefinition),
+					ScalarIndex:      make(map[string]*schema.ScalarDefinition),
+					ExtendIndex:      make(map[string]schema.ExtendDefinition),
+				},
+				Definition: &schema.SchemaDefinition{
+					Query:        []byte("Query"),
+					Mutation:     []byte("Mutation"),
+					Subscription: []byte("Subscription"),
+				},
+				Directives: schema.NewBuildInDirectives(),
+				Scalars: []*schema.ScalarDefinition{
+					{
+						Name: []byte("DateTime"),
+						Directives: []*schema.Directive{
+							{
+								Name: []byte("specifiedBy"),
+								Arguments: []*schema.DirectiveArgument{
+									{Name: []byte("url"), Value: []byte(`"https://scalars.graphql.org/andimarek/date-time"`)},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
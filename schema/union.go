@@ -3,10 +3,11 @@ package schema
 import "bytes"
 
 type UnionDefinition struct {
-	Name       []byte
-	Types      [][]byte
-	Extentions []*UnionDefinition
-	Directives []*Directive
+	Name        []byte
+	Description []byte
+	Types       [][]byte
+	Extentions  []*UnionDefinition
+	Directives  []*Directive
 }
 
 func (u *UnionDefinition) GetFieldByName(name []byte) *FieldDefinition {
@@ -0,0 +1,260 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/n9te9/goliteql/executor"
+)
+
+const GraphQLTransportWSProtocol = "graphql-transport-ws"
+
+const (
+	messageConnectionInit = "connection_init"
+	messageConnectionAck  = "connection_ack"
+	messagePing           = "ping"
+	messagePong           = "pong"
+	messageSubscribe      = "subscribe"
+	messageNext           = "next"
+	messageError          = "error"
+	messageComplete       = "complete"
+)
+
+const (
+	CloseBadRequest                uint16 = 4400
+	CloseUnauthorized              uint16 = 4401
+	CloseConnectionInitTimeout     uint16 = 4408
+	CloseSubscriberAlreadyExists   uint16 = 4409
+	CloseTooManyInitialisationReqs uint16 = 4429
+)
+
+var ConnectionInitWaitTimeout = 10 * time.Second
+
+type Request struct {
+	OperationName string                     `json:"operationName"`
+	Query         string                     `json:"query"`
+	Variables     map[string]json.RawMessage `json:"variables"`
+	Extensions    map[string]json.RawMessage `json:"extensions"`
+}
+
+type SubscribeFunc func(ctx context.Context, request *Request) (<-chan *executor.GraphQLResponse, error)
+
+func toGraphQLErrors(err error) []executor.GraphQLError {
+	var gqlErrs executor.GraphQLErrors
+	if errors.As(err, &gqlErrs) {
+		return gqlErrs
+	}
+
+	return []executor.GraphQLError{executor.NewGraphQLError(err)}
+}
+
+type message struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+type wsSession struct {
+	ctx           context.Context
+	conn          *Conn
+	subscribe     SubscribeFunc
+	mu            sync.Mutex
+	initialised   bool
+	acknowledged  chan struct{}
+	subscriptions map[string]context.CancelFunc
+	wg            sync.WaitGroup
+}
+
+func ServeWebSocket(w http.ResponseWriter, r *http.Request, subscribe SubscribeFunc) {
+	conn, err := Upgrade(w, r, GraphQLTransportWSProtocol)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	s := &wsSession{
+		ctx:           ctx,
+		conn:          conn,
+		subscribe:     subscribe,
+		acknowledged:  make(chan struct{}),
+		subscriptions: make(map[string]context.CancelFunc),
+	}
+
+	go s.waitConnectionInit()
+	s.serve()
+
+	cancel()
+	s.wg.Wait()
+}
+
+func (s *wsSession) waitConnectionInit() {
+	timer := time.NewTimer(ConnectionInitWaitTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-s.acknowledged:
+	case <-s.ctx.Done():
+	case <-timer.C:
+		s.conn.Close(CloseConnectionInitTimeout, "Connection initialisation timeout")
+	}
+}
+
+func (s *wsSession) serve() {
+	for {
+		data, err := s.conn.ReadMessage()
+		if err != nil {
+			s.conn.Close(CloseNormalClosure, "")
+			return
+		}
+
+		var msg message
+		if err := json.Unmarshal(data, &msg); err != nil {
+			s.conn.Close(CloseBadRequest, "Invalid message received")
+			return
+		}
+
+		switch msg.Type {
+		case messageConnectionInit:
+			if s.initialised {
+				s.conn.Close(CloseTooManyInitialisationReqs, "Too many initialisation requests")
+				return
+			}
+			s.initialised = true
+			close(s.acknowledged)
+
+			if err := s.write(&message{Type: messageConnectionAck}); err != nil {
+				return
+			}
+		case messagePing:
+			if err := s.write(&message{Type: messagePong}); err != nil {
+				return
+			}
+		case messagePong:
+		case messageSubscribe:
+			if !s.initialised {
+				s.conn.Close(CloseUnauthorized, "Unauthorized")
+				return
+			}
+
+			if err := s.startSubscription(&msg); err != nil {
+				return
+			}
+		case messageComplete:
+			s.stopSubscription(msg.ID)
+		default:
+			s.conn.Close(CloseBadRequest, fmt.Sprintf("Invalid message type %q received", msg.Type))
+			return
+		}
+	}
+}
+
+func (s *wsSession) startSubscription(msg *message) error {
+	if msg.ID == "" {
+		s.conn.Close(CloseBadRequest, "Subscribe message requires an id")
+		return errors.New("missing subscription id")
+	}
+
+	var request Request
+	if err := json.Unmarshal(msg.Payload, &request); err != nil {
+		s.conn.Close(CloseBadRequest, "Invalid subscribe payload")
+		return err
+	}
+
+	s.mu.Lock()
+	if _, ok := s.subscriptions[msg.ID]; ok {
+		s.mu.Unlock()
+		s.conn.Close(CloseSubscriberAlreadyExists, fmt.Sprintf("Subscriber for %s already exists", msg.ID))
+		return errors.New("duplicated subscription id")
+	}
+	ctx, cancel := context.WithCancel(s.ctx)
+	s.subscriptions[msg.ID] = cancel
+	s.mu.Unlock()
+
+	events, err := s.subscribe(ctx, &request)
+	if err != nil {
+		s.removeSubscription(msg.ID)
+		cancel()
+
+		return s.writeErrors(msg.ID, err)
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer cancel()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-events:
+				if !ok {
+					if s.removeSubscription(msg.ID) {
+						s.write(&message{ID: msg.ID, Type: messageComplete})
+					}
+					return
+				}
+
+				payload, err := json.Marshal(event)
+				if err != nil {
+					s.writeErrors(msg.ID, err)
+					continue
+				}
+
+				if err := s.write(&message{ID: msg.ID, Type: messageNext, Payload: payload}); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (s *wsSession) stopSubscription(id string) {
+	s.mu.Lock()
+	cancel, ok := s.subscriptions[id]
+	delete(s.subscriptions, id)
+	s.mu.Unlock()
+
+	if ok {
+		cancel()
+	}
+}
+
+func (s *wsSession) removeSubscription(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subscriptions[id]; !ok {
+		return false
+	}
+	delete(s.subscriptions, id)
+
+	return true
+}
+
+func (s *wsSession) writeErrors(id string, err error) error {
+	payload, err := json.Marshal(toGraphQLErrors(err))
+	if err != nil {
+		return err
+	}
+
+	return s.write(&message{ID: id, Type: messageError, Payload: payload})
+}
+
+func (s *wsSession) write(msg *message) error {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	return s.conn.WriteMessage(b)
+}
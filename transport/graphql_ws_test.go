@@ -0,0 +1,341 @@
+package transport_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor"
+	"github.com/n9te9/goliteql/transport"
+)
+
+type testClient struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func dial(t *testing.T, url string, protocol string, origin string) (*testClient, *http.Response) {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(url, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	req.Header.Set("Sec-WebSocket-Protocol", protocol)
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+
+	if err := req.Write(conn); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testClient{conn: conn, reader: reader}, resp
+}
+
+func (c *testClient) send(t *testing.T, msg string) {
+	t.Helper()
+
+	c.sendFrame(t, 0x1, msg)
+}
+
+func (c *testClient) sendFrame(t *testing.T, op byte, msg string) {
+	t.Helper()
+
+	mask := [4]byte{1, 2, 3, 4}
+	frame := []byte{0x80 | op}
+	if len(msg) < 126 {
+		frame = append(frame, 0x80|byte(len(msg)))
+	} else {
+		frame = append(frame, 0x80|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(len(msg)))
+	}
+	frame = append(frame, mask[:]...)
+	for i := range len(msg) {
+		frame = append(frame, msg[i]^mask[i%4])
+	}
+
+	if _, err := c.conn.Write(frame); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (c *testClient) receive(t *testing.T) (byte, string) {
+	t.Helper()
+
+	c.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	var header [2]byte
+	if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+		t.Fatal(err)
+	}
+
+	length := int(header[1] & 0x7f)
+	if length == 126 {
+		var ext [2]byte
+		if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+			t.Fatal(err)
+		}
+		length = int(binary.BigEndian.Uint16(ext[:]))
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		t.Fatal(err)
+	}
+
+	return header[0] & 0x0f, string(payload)
+}
+
+func TestServeWebSocket(t *testing.T) {
+	subscribe := func(ctx context.Context, request *transport.Request) (<-chan *executor.GraphQLResponse, error) {
+		if request.Query == "subscription { postAdded { nickname } }" {
+			return nil, executor.GraphQLErrors{
+				{
+					Message:    `cannot query field "nickname" on type "Post"`,
+					Locations:  []executor.Location{{Line: 1, Column: 28}},
+					Path:       []any{"postAdded", "nickname"},
+					Extensions: map[string]any{"code": "GRAPHQL_VALIDATION_FAILED"},
+				},
+			}
+		}
+
+		if request.Query != "subscription { postAdded { id } }" {
+			return nil, errors.New("unknown subscription")
+		}
+
+		ch := make(chan *executor.GraphQLResponse)
+		go func() {
+			defer close(ch)
+			for _, id := range []string{"1", "2"} {
+				data := executor.NewObject()
+				data.Set("postAdded", map[string]any{"id": id})
+				ch <- &executor.GraphQLResponse{Data: data}
+			}
+		}()
+
+		return ch, nil
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		transport.ServeWebSocket(w, r, subscribe)
+	}))
+	defer server.Close()
+
+	t.Run("subscribe and receive events", func(t *testing.T) {
+		client, resp := dial(t, server.URL, transport.GraphQLTransportWSProtocol, "")
+		defer client.conn.Close()
+
+		if resp.StatusCode != http.StatusSwitchingProtocols {
+			t.Fatalf("unexpected status code: %d", resp.StatusCode)
+		}
+
+		if got := resp.Header.Get("Sec-WebSocket-Accept"); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+			t.Fatalf("unexpected Sec-WebSocket-Accept: %s", got)
+		}
+
+		if got := resp.Header.Get("Sec-WebSocket-Protocol"); got != transport.GraphQLTransportWSProtocol {
+			t.Fatalf("unexpected Sec-WebSocket-Protocol: %s", got)
+		}
+
+		client.send(t, `{"type":"connection_init"}`)
+		client.send(t, `{"type":"ping"}`)
+		client.send(t, `{"id":"1","type":"subscribe","payload":{"query":"subscription { postAdded { id } }"}}`)
+		client.send(t, `{"id":"2","type":"subscribe","payload":{"query":"subscription { unknown }"}}`)
+		client.send(t, `{"id":"3","type":"subscribe","payload":{"query":"subscription { postAdded { nickname } }"}}`)
+
+		got := make([]string, 0)
+		for range 7 {
+			_, msg := client.receive(t)
+			got = append(got, msg)
+		}
+
+		want := []string{
+			`{"type":"connection_ack"}`,
+			`{"type":"pong"}`,
+			`{"id":"1","type":"next","payload":{"data":{"postAdded":{"id":"1"}}}}`,
+			`{"id":"1","type":"next","payload":{"data":{"postAdded":{"id":"2"}}}}`,
+			`{"id":"1","type":"complete"}`,
+			`{"id":"2","type":"error","payload":[{"message":"unknown subscription"}]}`,
+			`{"id":"3","type":"error","payload":[{"message":"cannot query field \"nickname\" on type \"Post\"","locations":[{"line":1,"column":28}],"path":["postAdded","nickname"],"extensions":{"code":"GRAPHQL_VALIDATION_FAILED"}}]}`,
+		}
+
+		sortByID := func(msgs []string) []string {
+			ret := make([]string, 0, len(msgs))
+			for _, prefix := range []string{`{"type"`, `{"id":"1"`, `{"id":"2"`, `{"id":"3"`} {
+				for _, msg := range msgs {
+					if strings.HasPrefix(msg, prefix) {
+						ret = append(ret, msg)
+					}
+				}
+			}
+			return ret
+		}
+
+		if diff := cmp.Diff(sortByID(want), sortByID(got)); diff != "" {
+			t.Errorf("messages mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("subscribe before connection_init", func(t *testing.T) {
+		client, _ := dial(t, server.URL, transport.GraphQLTransportWSProtocol, "")
+		defer client.conn.Close()
+
+		client.send(t, `{"id":"1","type":"subscribe","payload":{"query":"subscription { postAdded { id } }"}}`)
+
+		op, msg := client.receive(t)
+		if op != 0x8 {
+			t.Fatalf("expected close frame, got opcode %d", op)
+		}
+
+		var want [2]byte
+		binary.BigEndian.PutUint16(want[:], transport.CloseUnauthorized)
+		if msg[:2] != string(want[:]) {
+			t.Fatalf("unexpected close code: %v", []byte(msg[:2]))
+		}
+	})
+
+	t.Run("close without a status code", func(t *testing.T) {
+		client, _ := dial(t, server.URL, transport.GraphQLTransportWSProtocol, "")
+		defer client.conn.Close()
+
+		client.sendFrame(t, 0x8, "")
+
+		op, msg := client.receive(t)
+		if op != 0x8 {
+			t.Fatalf("expected close frame, got opcode %d", op)
+		}
+
+		var want [2]byte
+		binary.BigEndian.PutUint16(want[:], transport.CloseNormalClosure)
+		if msg != string(want[:]) {
+			t.Fatalf("unexpected close payload: %v", []byte(msg))
+		}
+	})
+
+	t.Run("unsupported subprotocol", func(t *testing.T) {
+		client, resp := dial(t, server.URL, "graphql-ws", "")
+		defer client.conn.Close()
+
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("unexpected status code: %d", resp.StatusCode)
+		}
+	})
+}
+
+func TestUpgrade_CheckOrigin(t *testing.T) {
+	subscribe := func(ctx context.Context, request *transport.Request) (<-chan *executor.GraphQLResponse, error) {
+		return nil, errors.New("unknown subscription")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		transport.ServeWebSocket(w, r, subscribe)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		origin      string
+		checkOrigin func(r *http.Request) bool
+		want        int
+	}{
+		{
+			name: "without origin",
+			want: http.StatusSwitchingProtocols,
+		},
+		{
+			name:   "same origin",
+			origin: server.URL,
+			want:   http.StatusSwitchingProtocols,
+		},
+		{
+			name:   "cross origin",
+			origin: "http://example.com",
+			want:   http.StatusForbidden,
+		},
+		{
+			name:   "cross origin allowed by CheckOrigin",
+			origin: "http://example.com",
+			checkOrigin: func(r *http.Request) bool {
+				return r.Header.Get("Origin") == "http://example.com"
+			},
+			want: http.StatusSwitchingProtocols,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.checkOrigin != nil {
+				checkOrigin := transport.CheckOrigin
+				transport.CheckOrigin = tt.checkOrigin
+				t.Cleanup(func() { transport.CheckOrigin = checkOrigin })
+			}
+
+			client, resp := dial(t, server.URL, transport.GraphQLTransportWSProtocol, tt.origin)
+			defer client.conn.Close()
+
+			if resp.StatusCode != tt.want {
+				t.Errorf("unexpected status code: %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsWebSocketUpgrade(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		want   bool
+	}{
+		{
+			name: "upgrade request",
+			header: http.Header{
+				"Connection": []string{"keep-alive, Upgrade"},
+				"Upgrade":    []string{"websocket"},
+			},
+			want: true,
+		},
+		{
+			name: "plain request",
+			header: http.Header{
+				"Connection": []string{"keep-alive"},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header = tt.header
+
+			if got := transport.IsWebSocketUpgrade(req); got != tt.want {
+				t.Errorf("IsWebSocketUpgrade() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
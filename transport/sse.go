@@ -0,0 +1,135 @@
+package transport
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/n9te9/goliteql/executor"
+)
+
+const eventStreamContentType = "text/event-stream"
+
+var SSEKeepAliveInterval = 12 * time.Second
+
+func IsEventStreamRequest(r *http.Request) bool {
+	for _, accept := range headerTokens(r.Header, "Accept") {
+		mediaType, _, err := mime.ParseMediaType(accept)
+		if err != nil {
+			continue
+		}
+
+		if mediaType == eventStreamContentType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func ServeSSE(w http.ResponseWriter, r *http.Request, subscribe SubscribeFunc) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported by this server", http.StatusInternalServerError)
+		return
+	}
+
+	request, err := decodeSSERequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	events, err := subscribe(ctx, request)
+
+	w.Header().Set("Content-Type", eventStreamContentType+"; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	if err != nil {
+		writeSSEEvent(w, messageNext, executor.NewErrorResponse(toGraphQLErrors(err)))
+		writeSSEEvent(w, messageComplete, nil)
+		flusher.Flush()
+
+		return
+	}
+
+	keepAlive := time.NewTicker(SSEKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ":\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case event, ok := <-events:
+			if !ok {
+				writeSSEEvent(w, messageComplete, nil)
+				flusher.Flush()
+				return
+			}
+
+			if err := writeSSEEvent(w, messageNext, event); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func decodeSSERequest(r *http.Request) (*Request, error) {
+	request := &Request{}
+
+	if r.Method == http.MethodGet {
+		params := r.URL.Query()
+		request.Query = params.Get("query")
+		request.OperationName = params.Get("operationName")
+
+		if v := params.Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &request.Variables); err != nil {
+				return nil, fmt.Errorf("invalid variables: %w", err)
+			}
+		}
+
+		if v := params.Get("extensions"); v != "" {
+			if err := json.Unmarshal([]byte(v), &request.Extensions); err != nil {
+				return nil, fmt.Errorf("invalid extensions: %w", err)
+			}
+		}
+	} else {
+		if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+	}
+
+	if strings.TrimSpace(request.Query) == "" {
+		return nil, fmt.Errorf("missing query")
+	}
+
+	return request, nil
+}
+
+func writeSSEEvent(w http.ResponseWriter, event string, data any) error {
+	if data == nil {
+		_, err := fmt.Fprintf(w, "event: %s\ndata:\n\n", event)
+		return err
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, b)
+	return err
+}
@@ -0,0 +1,151 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor"
+	"github.com/n9te9/goliteql/transport"
+)
+
+func TestServeSSE(t *testing.T) {
+	subscribe := func(ctx context.Context, request *transport.Request) (<-chan *executor.GraphQLResponse, error) {
+		if request.Query != "subscription { postAdded { id } }" {
+			return nil, errors.New("unknown subscription")
+		}
+
+		ch := make(chan *executor.GraphQLResponse)
+		go func() {
+			defer close(ch)
+			for _, id := range []string{"1", "2"} {
+				data := executor.NewObject()
+				data.Set("postAdded", map[string]any{"id": id})
+				ch <- &executor.GraphQLResponse{Data: data}
+			}
+		}()
+
+		return ch, nil
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		transport.ServeSSE(w, r, subscribe)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		newRequest func() *http.Request
+		wantStatus int
+		want       string
+	}{
+		{
+			name: "POST request",
+			newRequest: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"query":"subscription { postAdded { id } }"}`))
+				return req
+			},
+			wantStatus: http.StatusOK,
+			want: "event: next\ndata: {\"data\":{\"postAdded\":{\"id\":\"1\"}}}\n\n" +
+				"event: next\ndata: {\"data\":{\"postAdded\":{\"id\":\"2\"}}}\n\n" +
+				"event: complete\ndata:\n\n",
+		},
+		{
+			name: "GET request",
+			newRequest: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodGet, server.URL+"?query="+url.QueryEscape("subscription { postAdded { id } }"), nil)
+				return req
+			},
+			wantStatus: http.StatusOK,
+			want: "event: next\ndata: {\"data\":{\"postAdded\":{\"id\":\"1\"}}}\n\n" +
+				"event: next\ndata: {\"data\":{\"postAdded\":{\"id\":\"2\"}}}\n\n" +
+				"event: complete\ndata:\n\n",
+		},
+		{
+			name: "subscribe error",
+			newRequest: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"query":"subscription { unknown }"}`))
+				return req
+			},
+			wantStatus: http.StatusOK,
+			want: "event: next\ndata: {\"data\":null,\"errors\":[{\"message\":\"unknown subscription\"}]}\n\n" +
+				"event: complete\ndata:\n\n",
+		},
+		{
+			name: "missing query",
+			newRequest: func() *http.Request {
+				req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{}`))
+				return req
+			},
+			wantStatus: http.StatusBadRequest,
+			want:       "missing query\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.newRequest()
+			req.Header.Set("Accept", "text/event-stream")
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("unexpected status code: %d", resp.StatusCode)
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tt.want, string(body)); diff != "" {
+				t.Errorf("body mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsEventStreamRequest(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		want   bool
+	}{
+		{
+			name:   "event stream",
+			accept: "text/event-stream",
+			want:   true,
+		},
+		{
+			name:   "event stream with other media types",
+			accept: "application/json, text/event-stream; charset=utf-8",
+			want:   true,
+		},
+		{
+			name:   "json",
+			accept: "application/json",
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.Header.Set("Accept", tt.accept)
+
+			if got := transport.IsEventStreamRequest(req); got != tt.want {
+				t.Errorf("IsEventStreamRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
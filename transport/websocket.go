@@ -0,0 +1,374 @@
+package transport
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+const maxMessageSize = 1 << 20
+
+const (
+	opContinuation byte = 0x0
+	opText         byte = 0x1
+	opBinary       byte = 0x2
+	opClose        byte = 0x8
+	opPing         byte = 0x9
+	opPong         byte = 0xA
+)
+
+const (
+	CloseNormalClosure    uint16 = 1000
+	CloseProtocolError    uint16 = 1002
+	CloseMessageTooBig    uint16 = 1009
+	CloseInternalError    uint16 = 1011
+	CloseNoStatusReceived uint16 = 1005
+)
+
+var (
+	ErrNotWebSocket        = errors.New("request is not a websocket upgrade")
+	ErrUnsupportedProtocol = errors.New("unsupported websocket subprotocol")
+	ErrMessageTooBig       = errors.New("websocket message is too big")
+	ErrBadOrigin           = errors.New("websocket origin is not allowed")
+)
+
+// CheckOrigin reports whether Upgrade accepts a request by its Origin header.
+// The default accepts same origin requests only, so that another site cannot open a connection with the cookies of its visitors.
+var CheckOrigin = IsSameOrigin
+
+// IsSameOrigin reports whether the Origin header of r is absent, as with non-browser clients, or names the host r is sent to.
+func IsSameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(u.Host, r.Host)
+}
+
+type CloseError struct {
+	Code   uint16
+	Reason string
+}
+
+func (e *CloseError) Error() string {
+	return fmt.Sprintf("websocket closed: %d %s", e.Code, e.Reason)
+}
+
+type Conn struct {
+	conn        net.Conn
+	reader      *bufio.Reader
+	writer      *bufio.Writer
+	mu          sync.Mutex
+	closed      bool
+	Subprotocol string
+}
+
+func IsWebSocketUpgrade(r *http.Request) bool {
+	return headerContainsToken(r.Header, "Connection", "upgrade") && headerContainsToken(r.Header, "Upgrade", "websocket")
+}
+
+func Upgrade(w http.ResponseWriter, r *http.Request, subprotocols ...string) (*Conn, error) {
+	if r.Method != http.MethodGet || !IsWebSocketUpgrade(r) {
+		http.Error(w, "websocket upgrade required", http.StatusBadRequest)
+		return nil, ErrNotWebSocket
+	}
+
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "unsupported websocket version", http.StatusUpgradeRequired)
+		return nil, ErrNotWebSocket
+	}
+
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		http.Error(w, "missing Sec-WebSocket-Key", http.StatusBadRequest)
+		return nil, ErrNotWebSocket
+	}
+
+	if CheckOrigin != nil && !CheckOrigin(r) {
+		http.Error(w, "websocket origin is not allowed", http.StatusForbidden)
+		return nil, ErrBadOrigin
+	}
+
+	subprotocol := selectSubprotocol(r, subprotocols)
+	if len(subprotocols) > 0 && subprotocol == "" {
+		http.Error(w, "unsupported websocket subprotocol", http.StatusBadRequest)
+		return nil, ErrUnsupportedProtocol
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket is not supported by this server", http.StatusInternalServerError)
+		return nil, errors.New("response writer does not implement http.Hijacker")
+	}
+
+	netConn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, fmt.Errorf("failed to hijack connection: %w", err)
+	}
+
+	var b strings.Builder
+	b.WriteString("HTTP/1.1 101 Switching Protocols\r\n")
+	b.WriteString("Upgrade: websocket\r\n")
+	b.WriteString("Connection: Upgrade\r\n")
+	b.WriteString("Sec-WebSocket-Accept: " + computeAcceptKey(key) + "\r\n")
+	if subprotocol != "" {
+		b.WriteString("Sec-WebSocket-Protocol: " + subprotocol + "\r\n")
+	}
+	b.WriteString("\r\n")
+
+	if _, err := rw.Writer.WriteString(b.String()); err != nil {
+		netConn.Close()
+		return nil, fmt.Errorf("failed to write handshake: %w", err)
+	}
+
+	if err := rw.Writer.Flush(); err != nil {
+		netConn.Close()
+		return nil, fmt.Errorf("failed to write handshake: %w", err)
+	}
+
+	return &Conn{
+		conn:        netConn,
+		reader:      rw.Reader,
+		writer:      rw.Writer,
+		Subprotocol: subprotocol,
+	}, nil
+}
+
+func (c *Conn) ReadMessage() ([]byte, error) {
+	var message []byte
+	started := false
+
+	for {
+		fin, op, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+
+		switch op {
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil {
+				return nil, err
+			}
+		case opPong:
+		case opClose:
+			closeErr := &CloseError{Code: CloseNoStatusReceived}
+			if len(payload) >= 2 {
+				closeErr.Code = binary.BigEndian.Uint16(payload[:2])
+				closeErr.Reason = string(payload[2:])
+			}
+			// 1005 only reports a close frame without a code and must not be sent back
+			code := closeErr.Code
+			if code == CloseNoStatusReceived {
+				code = CloseNormalClosure
+			}
+			c.Close(code, "")
+
+			return nil, closeErr
+		case opText, opBinary:
+			if started {
+				c.Close(CloseProtocolError, "unexpected data frame")
+				return nil, &CloseError{Code: CloseProtocolError, Reason: "unexpected data frame"}
+			}
+			started = true
+			message = append(message, payload...)
+		case opContinuation:
+			if !started {
+				c.Close(CloseProtocolError, "unexpected continuation frame")
+				return nil, &CloseError{Code: CloseProtocolError, Reason: "unexpected continuation frame"}
+			}
+			message = append(message, payload...)
+		default:
+			c.Close(CloseProtocolError, "unknown opcode")
+			return nil, &CloseError{Code: CloseProtocolError, Reason: "unknown opcode"}
+		}
+
+		if len(message) > maxMessageSize {
+			c.Close(CloseMessageTooBig, "")
+			return nil, ErrMessageTooBig
+		}
+
+		if fin && started {
+			return message, nil
+		}
+	}
+}
+
+func (c *Conn) WriteMessage(data []byte) error {
+	return c.writeFrame(opText, data)
+}
+
+func (c *Conn) Close(code uint16, reason string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+
+	payload := make([]byte, 2, 2+len(reason))
+	binary.BigEndian.PutUint16(payload, code)
+	payload = append(payload, reason...)
+
+	if err := c.writeFrameLocked(opClose, payload); err != nil {
+		c.conn.Close()
+		return err
+	}
+
+	return c.conn.Close()
+}
+
+func (c *Conn) readFrame() (bool, byte, []byte, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+		return false, 0, nil, err
+	}
+
+	fin := header[0]&0x80 != 0
+	op := header[0] & 0x0f
+	masked := header[1]&0x80 != 0
+	length := uint64(header[1] & 0x7f)
+
+	if header[0]&0x70 != 0 {
+		c.Close(CloseProtocolError, "reserved bits are set")
+		return false, 0, nil, &CloseError{Code: CloseProtocolError, Reason: "reserved bits are set"}
+	}
+
+	if !masked {
+		c.Close(CloseProtocolError, "client frames must be masked")
+		return false, 0, nil, &CloseError{Code: CloseProtocolError, Reason: "client frames must be masked"}
+	}
+
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+
+	if length > maxMessageSize {
+		c.Close(CloseMessageTooBig, "")
+		return false, 0, nil, ErrMessageTooBig
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.reader, mask[:]); err != nil {
+		return false, 0, nil, err
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return false, 0, nil, err
+	}
+
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+
+	return fin, op, payload, nil
+}
+
+func (c *Conn) writeFrame(op byte, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return net.ErrClosed
+	}
+
+	return c.writeFrameLocked(op, payload)
+}
+
+func (c *Conn) writeFrameLocked(op byte, payload []byte) error {
+	header := make([]byte, 0, 10)
+	header = append(header, 0x80|op)
+
+	switch length := len(payload); {
+	case length < 126:
+		header = append(header, byte(length))
+	case length <= 0xffff:
+		header = append(header, 126)
+		header = binary.BigEndian.AppendUint16(header, uint16(length))
+	default:
+		header = append(header, 127)
+		header = binary.BigEndian.AppendUint64(header, uint64(length))
+	}
+
+	if _, err := c.writer.Write(header); err != nil {
+		return err
+	}
+
+	if _, err := c.writer.Write(payload); err != nil {
+		return err
+	}
+
+	return c.writer.Flush()
+}
+
+func computeAcceptKey(key string) string {
+	h := sha1.New()
+	h.Write([]byte(key + websocketGUID))
+
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+func selectSubprotocol(r *http.Request, subprotocols []string) string {
+	for _, requested := range headerTokens(r.Header, "Sec-WebSocket-Protocol") {
+		for _, supported := range subprotocols {
+			if requested == supported {
+				return supported
+			}
+		}
+	}
+
+	return ""
+}
+
+func headerTokens(header http.Header, name string) []string {
+	tokens := make([]string, 0)
+	for _, value := range header.Values(name) {
+		for _, token := range strings.Split(value, ",") {
+			if token = strings.TrimSpace(token); token != "" {
+				tokens = append(tokens, token)
+			}
+		}
+	}
+
+	return tokens
+}
+
+func headerContainsToken(header http.Header, name, token string) bool {
+	for _, t := range headerTokens(header, name) {
+		if strings.EqualFold(t, token) {
+			return true
+		}
+	}
+
+	return false
+}
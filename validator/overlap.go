@@ -0,0 +1,238 @@
+package validator
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/n9te9/goliteql/query"
+	"github.com/n9te9/goliteql/schema"
+)
+
+type fieldAndParent struct {
+	parent *typeInfo
+	field  *query.Field
+	def    *schema.FieldDefinition
+}
+
+type collectedFields struct {
+	keys   []string
+	fields map[string][]*fieldAndParent
+}
+
+func (c *collectedFields) add(key string, f *fieldAndParent) {
+	if _, exists := c.fields[key]; !exists {
+		c.keys = append(c.keys, key)
+	}
+	c.fields[key] = append(c.fields[key], f)
+}
+
+func (c *validationContext) collectFields(parent *typeInfo, selections []query.Selection, visited map[string]struct{}, isQueryRoot bool) *collectedFields {
+	ret := &collectedFields{fields: make(map[string][]*fieldAndParent)}
+	c.collectFieldsInto(ret, parent, selections, visited, isQueryRoot)
+	return ret
+}
+
+func (c *validationContext) collectFieldsInto(ret *collectedFields, parent *typeInfo, selections []query.Selection, visited map[string]struct{}, isQueryRoot bool) {
+	for _, sel := range selections {
+		switch s := sel.(type) {
+		case *query.Field:
+			ret.add(string(s.ResponseName()), &fieldAndParent{
+				parent: parent,
+				field:  s,
+				def:    lookupField(parent, s.Name, isQueryRoot),
+			})
+		case *query.FragmentSpread:
+			if _, ok := visited[string(s.Name)]; ok {
+				continue
+			}
+			visited[string(s.Name)] = struct{}{}
+
+			fd, exists := c.fragments[string(s.Name)]
+			if !exists {
+				continue
+			}
+
+			fragmentType := lookupType(c.schema, fd.BasedTypeName)
+			if fragmentType == nil || !fragmentType.isComposite() {
+				continue
+			}
+
+			c.collectFieldsInto(ret, fragmentType, fd.Selections, visited, false)
+		case *query.InlineFragment:
+			fragmentType := parent
+			if len(s.TypeCondition) > 0 {
+				fragmentType = lookupType(c.schema, s.TypeCondition)
+				if fragmentType == nil || !fragmentType.isComposite() {
+					continue
+				}
+			}
+
+			c.collectFieldsInto(ret, fragmentType, s.Selections, visited, isQueryRoot && bytes.Equal(fragmentType.name, parent.name))
+		}
+	}
+}
+
+func (c *validationContext) validateOverlappingFields() {
+	for _, fd := range c.doc.FragmentDefinitions {
+		if t := lookupType(c.schema, fd.BasedTypeName); t != nil && t.isComposite() {
+			c.validateFieldsCanBeMerged(t, fd.Selections, false)
+		}
+	}
+
+	for _, op := range c.doc.Operations {
+		if t := lookupType(c.schema, rootTypeName(c.schema, op.OperationType)); t != nil {
+			c.validateFieldsCanBeMerged(t, op.Selections, op.OperationType == query.QueryOperation)
+		}
+	}
+}
+
+func (c *validationContext) validateFieldsCanBeMerged(parent *typeInfo, selections []query.Selection, isQueryRoot bool) {
+	collected := c.collectFields(parent, selections, make(map[string]struct{}), isQueryRoot)
+	for _, key := range collected.keys {
+		fields := collected.fields[key]
+		for i := 0; i < len(fields); i++ {
+			for j := i + 1; j < len(fields); j++ {
+				c.findConflict(key, fields[i], fields[j], false)
+			}
+		}
+	}
+
+	c.validateNestedFieldsCanBeMerged(parent, selections)
+}
+
+func (c *validationContext) validateNestedFieldsCanBeMerged(parent *typeInfo, selections []query.Selection) {
+	for _, sel := range selections {
+		switch s := sel.(type) {
+		case *query.Field:
+			if len(s.Selections) == 0 {
+				continue
+			}
+
+			def := lookupField(parent, s.Name, false)
+			if def == nil {
+				continue
+			}
+
+			fieldType := lookupType(c.schema, def.Type.GetRootType().Name)
+			if fieldType == nil || !fieldType.isComposite() {
+				continue
+			}
+
+			c.validateFieldsCanBeMerged(fieldType, s.Selections, false)
+		case *query.InlineFragment:
+			fragmentType := parent
+			if len(s.TypeCondition) > 0 {
+				fragmentType = lookupType(c.schema, s.TypeCondition)
+				if fragmentType == nil || !fragmentType.isComposite() {
+					continue
+				}
+			}
+
+			c.validateNestedFieldsCanBeMerged(fragmentType, s.Selections)
+		}
+	}
+}
+
+func (c *validationContext) findConflict(key string, a, b *fieldAndParent, parentFieldsAreMutuallyExclusive bool) {
+	if a.field == b.field {
+		return
+	}
+
+	mutuallyExclusive := parentFieldsAreMutuallyExclusive ||
+		(!bytes.Equal(a.parent.name, b.parent.name) && a.parent.kind == objectKind && b.parent.kind == objectKind)
+
+	if !mutuallyExclusive {
+		if !bytes.Equal(a.field.Name, b.field.Name) {
+			c.reportConflict(a, b, "fields %q conflict because %s and %s are different fields", key, a.field.Name, b.field.Name)
+			return
+		}
+
+		if !sameArguments(a.field.Arguments, b.field.Arguments) {
+			c.reportConflict(a, b, "fields %q conflict because they have differing arguments", key)
+			return
+		}
+	}
+
+	if a.def == nil || b.def == nil {
+		return
+	}
+
+	if c.doTypesConflict(a.def.Type, b.def.Type) {
+		c.reportConflict(a, b, "fields %q conflict because they return conflicting types %q and %q", key, typeString(a.def.Type), typeString(b.def.Type))
+		return
+	}
+
+	if len(a.field.Selections) == 0 || len(b.field.Selections) == 0 {
+		return
+	}
+
+	aType := lookupType(c.schema, a.def.Type.GetRootType().Name)
+	bType := lookupType(c.schema, b.def.Type.GetRootType().Name)
+	if aType == nil || bType == nil {
+		return
+	}
+
+	aFields := c.collectFields(aType, a.field.Selections, make(map[string]struct{}), false)
+	bFields := c.collectFields(bType, b.field.Selections, make(map[string]struct{}), false)
+	for _, subKey := range aFields.keys {
+		for _, subA := range aFields.fields[subKey] {
+			for _, subB := range bFields.fields[subKey] {
+				c.findConflict(subKey, subA, subB, mutuallyExclusive)
+			}
+		}
+	}
+}
+
+func (c *validationContext) reportConflict(a, b *fieldAndParent, format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	if _, reported := c.conflicts[msg]; reported {
+		return
+	}
+	c.conflicts[msg] = struct{}{}
+
+	c.report(at(nil, a.field.Position, b.field.Position), "%s", msg)
+}
+
+func sameArguments(a, b []*query.Argument) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for _, argA := range a {
+		found := false
+		for _, argB := range b {
+			if bytes.Equal(argA.Name, argB.Name) {
+				found = bytes.Equal(argA.Value, argB.Value)
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *validationContext) doTypesConflict(a, b *schema.FieldType) bool {
+	if a.Nullable != b.Nullable {
+		return true
+	}
+
+	if a.IsList || b.IsList {
+		if !a.IsList || !b.IsList {
+			return true
+		}
+
+		return c.doTypesConflict(a.ListType, b.ListType)
+	}
+
+	aType := lookupType(c.schema, a.Name)
+	bType := lookupType(c.schema, b.Name)
+	if (aType != nil && aType.isLeaf()) || (bType != nil && bType.isLeaf()) {
+		return !bytes.Equal(a.Name, b.Name)
+	}
+
+	return false
+}
@@ -0,0 +1,758 @@
+package validator
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/n9te9/goliteql/executor"
+	"github.com/n9te9/goliteql/query"
+	"github.com/n9te9/goliteql/schema"
+)
+
+type errorSite struct {
+	positions []query.Position
+	path      []any
+}
+
+func at(path []any, positions ...query.Position) errorSite {
+	return errorSite{
+		positions: positions,
+		path:      path,
+	}
+}
+
+func appendPath(path []any, key []byte) []any {
+	ret := make([]any, len(path), len(path)+1)
+	copy(ret, path)
+	return append(ret, string(key))
+}
+
+type variableUsage struct {
+	name               string
+	locationType       *schema.FieldType
+	hasLocationDefault bool
+	site               errorSite
+}
+
+type scope struct {
+	variableUsages  []*variableUsage
+	fragmentSpreads []*query.FragmentSpread
+}
+
+type validationContext struct {
+	schema    *schema.Schema
+	doc       *query.Document
+	fragments map[string]*query.FragmentDefinition
+	scopes    map[any]*scope
+	conflicts map[string]struct{}
+	errors    []executor.GraphQLError
+
+	hasFragmentCycle bool
+}
+
+func newValidationContext(s *schema.Schema, doc *query.Document) *validationContext {
+	fragments := make(map[string]*query.FragmentDefinition, len(doc.FragmentDefinitions))
+	for _, fd := range doc.FragmentDefinitions {
+		if _, exists := fragments[string(fd.Name)]; !exists {
+			fragments[string(fd.Name)] = fd
+		}
+	}
+
+	return &validationContext{
+		schema:    s,
+		doc:       doc,
+		fragments: fragments,
+		scopes:    make(map[any]*scope),
+		conflicts: make(map[string]struct{}),
+	}
+}
+
+func (c *validationContext) report(site errorSite, format string, args ...any) {
+	err := executor.GraphQLError{
+		Message: fmt.Sprintf(format, args...),
+		Path:    site.path,
+		Extensions: map[string]any{
+			"code": ValidationFailedCode,
+		},
+	}
+
+	for _, pos := range site.positions {
+		if pos.Line > 0 {
+			err.Locations = append(err.Locations, executor.Location{Line: pos.Line, Column: pos.Column})
+		}
+	}
+
+	c.errors = append(c.errors, err)
+}
+
+func (c *validationContext) validateDocument() []executor.GraphQLError {
+	if len(c.doc.Operations) == 0 {
+		c.report(errorSite{}, "document must contain at least one operation")
+	}
+
+	c.validateUniqueOperationNames()
+	c.validateLoneAnonymousOperation()
+	c.validateUniqueFragmentNames()
+
+	for _, fd := range c.doc.FragmentDefinitions {
+		c.validateFragmentDefinition(fd)
+	}
+
+	for _, op := range c.doc.Operations {
+		c.validateOperation(op)
+	}
+
+	c.validateNoFragmentCycles()
+	c.validateNoUnusedFragments()
+
+	if !c.hasFragmentCycle {
+		c.validateOverlappingFields()
+	}
+
+	for _, op := range c.doc.Operations {
+		c.validateOperationVariables(op)
+	}
+
+	return c.errors
+}
+
+func operationLabel(op *query.Operation) string {
+	if op.Name == "" {
+		return "anonymous operation"
+	}
+
+	return fmt.Sprintf("operation %q", op.Name)
+}
+
+func (c *validationContext) validateUniqueOperationNames() {
+	seen := make(map[string]struct{})
+	for _, op := range c.doc.Operations {
+		if op.Name == "" {
+			continue
+		}
+
+		if _, exists := seen[op.Name]; exists {
+			c.report(at(nil, op.Position), "there can be only one operation named %q", op.Name)
+			continue
+		}
+		seen[op.Name] = struct{}{}
+	}
+}
+
+func (c *validationContext) validateLoneAnonymousOperation() {
+	if len(c.doc.Operations) < 2 {
+		return
+	}
+
+	for _, op := range c.doc.Operations {
+		if op.Name == "" {
+			c.report(at(nil, op.Position), "this anonymous operation must be the only defined operation")
+		}
+	}
+}
+
+func (c *validationContext) validateUniqueFragmentNames() {
+	seen := make(map[string]struct{})
+	for _, fd := range c.doc.FragmentDefinitions {
+		if _, exists := seen[string(fd.Name)]; exists {
+			c.report(at(nil, fd.Position), "there can be only one fragment named %q", fd.Name)
+			continue
+		}
+		seen[string(fd.Name)] = struct{}{}
+	}
+}
+
+func (c *validationContext) validateOperation(op *query.Operation) {
+	sc := &scope{}
+	c.scopes[op] = sc
+
+	site := at(nil, op.Position)
+	c.validateDirectives(sc, op.Directives, operationDirectiveLocation(op.OperationType), site)
+	c.validateVariableDefinitions(op)
+
+	rootType := lookupType(c.schema, rootTypeName(c.schema, op.OperationType))
+	if rootType == nil || rootOperation(c.schema, op.OperationType) == nil {
+		c.report(site, "schema does not support %s operations", op.OperationType)
+		return
+	}
+
+	if op.OperationType == query.SubscriptionOperation {
+		c.validateSingleFieldSubscription(op, rootType)
+	}
+
+	c.validateSelectionSet(sc, rootType, op.Selections, op.OperationType == query.QueryOperation, nil)
+}
+
+func operationDirectiveLocation(operationType query.OperationType) string {
+	switch operationType {
+	case query.MutationOperation:
+		return "MUTATION"
+	case query.SubscriptionOperation:
+		return "SUBSCRIPTION"
+	}
+
+	return "QUERY"
+}
+
+func (c *validationContext) validateSingleFieldSubscription(op *query.Operation, rootType *typeInfo) {
+	fields := c.collectFields(rootType, op.Selections, make(map[string]struct{}), true)
+	if len(fields.keys) != 1 {
+		c.report(at(nil, op.Position), "%s must select only one top level field", subscriptionLabel(op))
+		return
+	}
+
+	if bytes.HasPrefix([]byte(fields.keys[0]), []byte("__")) {
+		c.report(at(nil, op.Position), "%s must not select an introspection top level field", subscriptionLabel(op))
+	}
+}
+
+func subscriptionLabel(op *query.Operation) string {
+	if op.Name == "" {
+		return "anonymous subscription"
+	}
+
+	return fmt.Sprintf("subscription %q", op.Name)
+}
+
+func (c *validationContext) validateFragmentDefinition(fd *query.FragmentDefinition) {
+	sc := &scope{}
+	c.scopes[fd] = sc
+
+	t := lookupType(c.schema, fd.BasedTypeName)
+	if t == nil {
+		c.report(at(nil, fd.Position), "unknown type %q", fd.BasedTypeName)
+		return
+	}
+
+	if !t.isComposite() {
+		c.report(at(nil, fd.Position), "fragment %q cannot condition on non composite type %q", fd.Name, fd.BasedTypeName)
+		return
+	}
+
+	c.validateSelectionSet(sc, t, fd.Selections, false, nil)
+}
+
+func (c *validationContext) validateSelectionSet(sc *scope, parent *typeInfo, selections []query.Selection, isQueryRoot bool, path []any) {
+	for _, sel := range selections {
+		switch s := sel.(type) {
+		case *query.Field:
+			c.validateFieldSelection(sc, parent, s, isQueryRoot, appendPath(path, s.Name))
+		case *query.FragmentSpread:
+			site := at(path, s.Position)
+			c.validateDirectives(sc, s.Directives, "FRAGMENT_SPREAD", site)
+			sc.fragmentSpreads = append(sc.fragmentSpreads, s)
+
+			fd, exists := c.fragments[string(s.Name)]
+			if !exists {
+				c.report(site, "unknown fragment %q", s.Name)
+				continue
+			}
+
+			fragmentType := lookupType(c.schema, fd.BasedTypeName)
+			if fragmentType == nil || !fragmentType.isComposite() {
+				continue
+			}
+
+			if !c.typesOverlap(parent, fragmentType) {
+				c.report(site, "fragment %q cannot be spread here as objects of type %q can never be of type %q", s.Name, parent.name, fragmentType.name)
+			}
+		case *query.InlineFragment:
+			site := at(path, s.Position)
+			c.validateDirectives(sc, s.Directives, "INLINE_FRAGMENT", site)
+
+			fragmentType := parent
+			if len(s.TypeCondition) > 0 {
+				fragmentType = lookupType(c.schema, s.TypeCondition)
+				if fragmentType == nil {
+					c.report(site, "unknown type %q", s.TypeCondition)
+					continue
+				}
+
+				if !fragmentType.isComposite() {
+					c.report(site, "fragment cannot condition on non composite type %q", s.TypeCondition)
+					continue
+				}
+
+				if !c.typesOverlap(parent, fragmentType) {
+					c.report(site, "fragment cannot be spread here as objects of type %q can never be of type %q", parent.name, fragmentType.name)
+					continue
+				}
+			}
+
+			c.validateSelectionSet(sc, fragmentType, s.Selections, isQueryRoot && bytes.Equal(fragmentType.name, parent.name), path)
+		}
+	}
+}
+
+func (c *validationContext) validateFieldSelection(sc *scope, parent *typeInfo, field *query.Field, isQueryRoot bool, path []any) {
+	site := at(path, field.Position)
+	c.validateDirectives(sc, field.Directives, "FIELD", site)
+
+	def := lookupField(parent, field.Name, isQueryRoot)
+	if def == nil {
+		c.report(site, "cannot query field %q on type %q", field.Name, parent.name)
+		return
+	}
+
+	c.validateArguments(sc, fmt.Sprintf("field \"%s.%s\"", parent.name, field.Name), def.Arguments, field.Arguments, site)
+
+	fieldType := lookupType(c.schema, def.Type.GetRootType().Name)
+	if fieldType == nil {
+		return
+	}
+
+	if fieldType.isLeaf() {
+		if len(field.Selections) > 0 {
+			c.report(site, "field %q must not have a selection since type %q has no subfields", field.Name, typeString(def.Type))
+		}
+		return
+	}
+
+	if len(field.Selections) == 0 {
+		c.report(site, "field %q of type %q must have a selection of subfields", field.Name, typeString(def.Type))
+		return
+	}
+
+	c.validateSelectionSet(sc, fieldType, field.Selections, false, path)
+}
+
+func (c *validationContext) typesOverlap(a, b *typeInfo) bool {
+	bTypes := possibleTypes(c.schema, b)
+	for name := range possibleTypes(c.schema, a) {
+		if _, ok := bTypes[name]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *validationContext) validateArguments(sc *scope, owner string, defs []*schema.ArgumentDefinition, args []*query.Argument, site errorSite) {
+	seen := make(map[string]struct{}, len(args))
+	for _, arg := range args {
+		argSite := at(site.path, arg.Position)
+		if _, exists := seen[string(arg.Name)]; exists {
+			c.report(argSite, "there can be only one argument named %q", arg.Name)
+			continue
+		}
+		seen[string(arg.Name)] = struct{}{}
+
+		def := findArgumentDefinition(defs, arg.Name)
+		if def == nil {
+			c.report(argSite, "unknown argument %q on %s", arg.Name, owner)
+			continue
+		}
+
+		v, err := parseValue(arg.Value)
+		if err != nil {
+			c.report(argSite, "argument %q on %s has invalid value: %v", arg.Name, owner, err)
+			continue
+		}
+
+		c.validateValue(sc, v, def.Type, def.Default != nil, argSite, fmt.Sprintf("argument %q on %s", arg.Name, owner))
+	}
+
+	for _, def := range defs {
+		if def.Type.Nullable || def.Default != nil {
+			continue
+		}
+
+		if _, exists := seen[string(def.Name)]; !exists {
+			c.report(site, "%s argument %q of type %q is required, but it was not provided", owner, def.Name, typeString(def.Type))
+		}
+	}
+}
+
+func findArgumentDefinition(defs []*schema.ArgumentDefinition, name []byte) *schema.ArgumentDefinition {
+	for _, def := range defs {
+		if bytes.Equal(def.Name, name) {
+			return def
+		}
+	}
+
+	return nil
+}
+
+func (c *validationContext) validateValue(sc *scope, v *value, t *schema.FieldType, hasLocationDefault bool, site errorSite, position string) {
+	if v.kind == variableValue {
+		sc.variableUsages = append(sc.variableUsages, &variableUsage{
+			name:               v.variableName(),
+			locationType:       t,
+			hasLocationDefault: hasLocationDefault,
+			site:               site,
+		})
+		return
+	}
+
+	if v.kind == nullValue {
+		if !t.Nullable {
+			c.report(site, "%s expected value of type %q, found null", position, typeString(t))
+		}
+		return
+	}
+
+	if t.IsList {
+		if v.kind != listValue {
+			c.validateValue(sc, v, t.ListType, false, site, position)
+			return
+		}
+
+		for _, item := range v.list {
+			c.validateValue(sc, item, t.ListType, false, site, position)
+		}
+		return
+	}
+
+	named := lookupType(c.schema, t.Name)
+	if named == nil {
+		return
+	}
+
+	switch named.kind {
+	case scalarKind:
+		if !isValidScalarLiteral(named.name, v) {
+			c.report(site, "%s expected value of type %q, found %s", position, typeString(t), v.raw)
+		}
+	case enumKind:
+		if v.kind != enumValue || !hasEnumValue(named.enum, v.raw) {
+			c.report(site, "%s expected value of type %q, found %s", position, typeString(t), v.raw)
+		}
+	case inputObjectKind:
+		if v.kind != objectValue {
+			c.report(site, "%s expected value of type %q, found %s", position, typeString(t), v.raw)
+			return
+		}
+
+		c.validateInputObjectValue(sc, v, named.input, site, position)
+	}
+}
+
+func (c *validationContext) validateInputObjectValue(sc *scope, v *value, input *schema.InputDefinition, site errorSite, position string) {
+	seen := make(map[string]struct{}, len(v.fields))
+	for _, f := range v.fields {
+		if _, exists := seen[string(f.name)]; exists {
+			c.report(site, "%s there can be only one input field named %q", position, f.name)
+			continue
+		}
+		seen[string(f.name)] = struct{}{}
+
+		fd := input.Fields.Last(string(f.name))
+		if fd == nil {
+			c.report(site, "%s field %q is not defined by type %q", position, f.name, input.Name)
+			continue
+		}
+
+		c.validateValue(sc, f.value, fd.Type, fd.Default != nil, site, position)
+	}
+
+	for _, fd := range input.Fields {
+		if fd.Type.Nullable || fd.Default != nil {
+			continue
+		}
+
+		if _, exists := seen[string(fd.Name)]; !exists {
+			c.report(site, "%s field \"%s.%s\" of required type %q was not provided", position, input.Name, fd.Name, typeString(fd.Type))
+		}
+	}
+}
+
+func isValidScalarLiteral(name []byte, v *value) bool {
+	switch string(name) {
+	case "Int":
+		if v.kind != intValue {
+			return false
+		}
+
+		n, err := strconv.ParseInt(string(v.raw), 10, 64)
+		return err == nil && n >= math.MinInt32 && n <= math.MaxInt32
+	case "Float":
+		return v.kind == intValue || v.kind == floatValue
+	case "String":
+		return v.kind == stringValue
+	case "Boolean":
+		return v.kind == booleanValue
+	case "ID":
+		return v.kind == stringValue || v.kind == intValue
+	}
+
+	return true
+}
+
+func hasEnumValue(enum *schema.EnumDefinition, name []byte) bool {
+	for _, element := range enum.Values {
+		if bytes.Equal(element.Value, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *validationContext) validateDirectives(sc *scope, directives []*query.Directive, location string, site errorSite) {
+	seen := make(map[string]struct{}, len(directives))
+	for _, directive := range directives {
+		directiveSite := at(site.path, directive.Position)
+		def := c.schema.Directives.Get(directive.Name)
+		if def == nil {
+			c.report(directiveSite, "unknown directive \"@%s\"", directive.Name)
+			continue
+		}
+
+		if !hasDirectiveLocation(def, location) {
+			c.report(directiveSite, "directive \"@%s\" may not be used on %s", directive.Name, location)
+		}
+
+		if _, exists := seen[string(directive.Name)]; exists && !def.Repeatable {
+			c.report(directiveSite, "the directive \"@%s\" can only be used once at this location", directive.Name)
+		}
+		seen[string(directive.Name)] = struct{}{}
+
+		c.validateDirectiveArguments(sc, directive, def, directiveSite)
+	}
+}
+
+func hasDirectiveLocation(def *schema.DirectiveDefinition, location string) bool {
+	for _, l := range def.Locations {
+		if string(l.Name) == location {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *validationContext) validateDirectiveArguments(sc *scope, directive *query.Directive, def *schema.DirectiveDefinition, site errorSite) {
+	owner := fmt.Sprintf("directive \"@%s\"", directive.Name)
+
+	seen := make(map[string]struct{}, len(directive.Arguments))
+	for _, arg := range directive.Arguments {
+		argSite := at(site.path, arg.Position)
+		if _, exists := seen[string(arg.Name)]; exists {
+			c.report(argSite, "there can be only one argument named %q", arg.Name)
+			continue
+		}
+		seen[string(arg.Name)] = struct{}{}
+
+		argDef := findArgumentDefinition(def.Arguments, arg.Name)
+		if argDef == nil {
+			c.report(argSite, "unknown argument %q on %s", arg.Name, owner)
+			continue
+		}
+
+		if arg.IsVariable {
+			sc.variableUsages = append(sc.variableUsages, &variableUsage{
+				name:               string(arg.Value),
+				locationType:       argDef.Type,
+				hasLocationDefault: argDef.Default != nil,
+				site:               argSite,
+			})
+			continue
+		}
+
+		v, err := parseValue(arg.Value)
+		if err != nil {
+			c.report(argSite, "argument %q on %s has invalid value: %v", arg.Name, owner, err)
+			continue
+		}
+
+		c.validateValue(sc, v, argDef.Type, argDef.Default != nil, argSite, fmt.Sprintf("argument %q on %s", arg.Name, owner))
+	}
+
+	for _, argDef := range def.Arguments {
+		if argDef.Type.Nullable || argDef.Default != nil {
+			continue
+		}
+
+		if _, exists := seen[string(argDef.Name)]; !exists {
+			c.report(site, "%s argument %q of type %q is required, but it was not provided", owner, argDef.Name, typeString(argDef.Type))
+		}
+	}
+}
+
+func (c *validationContext) validateVariableDefinitions(op *query.Operation) {
+	seen := make(map[string]struct{}, len(op.Variables))
+	for _, variable := range op.Variables {
+		site := at(nil, variable.Position)
+		if _, exists := seen[string(variable.Name)]; exists {
+			c.report(site, "there can be only one variable named \"$%s\"", variable.Name)
+			continue
+		}
+		seen[string(variable.Name)] = struct{}{}
+
+		variableType := toSchemaFieldType(variable.Type)
+		named := lookupType(c.schema, variableType.GetRootType().Name)
+		if named == nil {
+			c.report(site, "unknown type %q", variableType.GetRootType().Name)
+			continue
+		}
+
+		if !named.isInput() {
+			c.report(site, "variable \"$%s\" cannot be non-input type %q", variable.Name, typeString(variableType))
+			continue
+		}
+
+		if variable.DefaultValue == nil {
+			continue
+		}
+
+		v, err := parseValue(variable.DefaultValue)
+		if err != nil {
+			c.report(site, "variable \"$%s\" has invalid default value: %v", variable.Name, err)
+			continue
+		}
+
+		defaultScope := &scope{}
+		c.validateValue(defaultScope, v, variableType, false, site, fmt.Sprintf("variable \"$%s\" default value", variable.Name))
+		if len(defaultScope.variableUsages) > 0 {
+			c.report(site, "variable \"$%s\" default value must be constant", variable.Name)
+		}
+	}
+}
+
+func (c *validationContext) reachableFragments(sc *scope, visited map[string]struct{}) []*scope {
+	ret := make([]*scope, 0)
+	for _, spread := range sc.fragmentSpreads {
+		if _, ok := visited[string(spread.Name)]; ok {
+			continue
+		}
+		visited[string(spread.Name)] = struct{}{}
+
+		fd, exists := c.fragments[string(spread.Name)]
+		if !exists {
+			continue
+		}
+
+		if fragmentScope, ok := c.scopes[fd]; ok {
+			ret = append(ret, fragmentScope)
+			ret = append(ret, c.reachableFragments(fragmentScope, visited)...)
+		}
+	}
+
+	return ret
+}
+
+func (c *validationContext) validateOperationVariables(op *query.Operation) {
+	sc, ok := c.scopes[op]
+	if !ok {
+		return
+	}
+
+	usages := append([]*variableUsage{}, sc.variableUsages...)
+	for _, fragmentScope := range c.reachableFragments(sc, make(map[string]struct{})) {
+		usages = append(usages, fragmentScope.variableUsages...)
+	}
+
+	definitions := make(map[string]*query.Variable, len(op.Variables))
+	for _, variable := range op.Variables {
+		definitions[string(variable.Name)] = variable
+	}
+
+	used := make(map[string]struct{}, len(usages))
+	for _, usage := range usages {
+		def, defined := definitions[usage.name]
+		if !defined {
+			if _, reported := used[usage.name]; !reported {
+				c.report(errorSite{positions: append(usage.site.positions, op.Position), path: usage.site.path}, "variable \"$%s\" is not defined by %s", usage.name, operationLabel(op))
+			}
+			used[usage.name] = struct{}{}
+			continue
+		}
+		used[usage.name] = struct{}{}
+
+		if !isVariableUsageAllowed(def, usage) {
+			c.report(usage.site, "variable \"$%s\" of type %q used in position expecting type %q", usage.name, typeString(toSchemaFieldType(def.Type)), typeString(usage.locationType))
+		}
+	}
+
+	for _, variable := range op.Variables {
+		if _, ok := used[string(variable.Name)]; !ok {
+			c.report(at(nil, variable.Position), "variable \"$%s\" is never used in %s", variable.Name, operationLabel(op))
+		}
+	}
+}
+
+func isVariableUsageAllowed(def *query.Variable, usage *variableUsage) bool {
+	variableType := toSchemaFieldType(def.Type)
+	locationType := usage.locationType
+
+	if !locationType.Nullable && variableType.Nullable {
+		hasNonNullDefault := def.DefaultValue != nil && string(def.DefaultValue) != "null"
+		if !hasNonNullDefault && !usage.hasLocationDefault {
+			return false
+		}
+
+		return isTypeSubTypeOf(variableType, nullableType(locationType))
+	}
+
+	return isTypeSubTypeOf(variableType, locationType)
+}
+
+func (c *validationContext) validateNoFragmentCycles() {
+	visited := make(map[string]struct{})
+	for _, fd := range c.doc.FragmentDefinitions {
+		c.detectFragmentCycle(fd, visited, nil, nil, make(map[string]int))
+	}
+}
+
+func (c *validationContext) detectFragmentCycle(fd *query.FragmentDefinition, visited map[string]struct{}, path [][]byte, spreadPath []*query.FragmentSpread, pathIndex map[string]int) {
+	name := string(fd.Name)
+	if _, ok := visited[name]; ok {
+		return
+	}
+	visited[name] = struct{}{}
+
+	sc, ok := c.scopes[fd]
+	if !ok {
+		return
+	}
+
+	pathIndex[name] = len(path)
+	for _, spread := range sc.fragmentSpreads {
+		if idx, inPath := pathIndex[string(spread.Name)]; inPath {
+			c.hasFragmentCycle = true
+			cyclePath := append(append([][]byte{}, path[idx:]...), fd.Name)
+			via := bytes.Join(cyclePath[1:], []byte(", "))
+
+			positions := make([]query.Position, 0, len(spreadPath)-idx+1)
+			for _, s := range spreadPath[idx:] {
+				positions = append(positions, s.Position)
+			}
+			positions = append(positions, spread.Position)
+
+			if len(via) > 0 {
+				c.report(at(nil, positions...), "cannot spread fragment %q within itself via %s", spread.Name, via)
+			} else {
+				c.report(at(nil, positions...), "cannot spread fragment %q within itself", spread.Name)
+			}
+			continue
+		}
+
+		next, exists := c.fragments[string(spread.Name)]
+		if !exists {
+			continue
+		}
+
+		c.detectFragmentCycle(next, visited, append(path, fd.Name), append(spreadPath, spread), pathIndex)
+	}
+	delete(pathIndex, name)
+}
+
+func (c *validationContext) validateNoUnusedFragments() {
+	used := make(map[string]struct{})
+	for _, op := range c.doc.Operations {
+		sc, ok := c.scopes[op]
+		if !ok {
+			continue
+		}
+
+		c.reachableFragments(sc, used)
+	}
+
+	for _, fd := range c.doc.FragmentDefinitions {
+		if _, ok := used[string(fd.Name)]; !ok {
+			c.report(at(nil, fd.Position), "fragment %q is never used", fd.Name)
+		}
+	}
+}
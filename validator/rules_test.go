@@ -0,0 +1,466 @@
+package validator_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/n9te9/goliteql/executor"
+	"github.com/n9te9/goliteql/query"
+	"github.com/n9te9/goliteql/schema"
+	"github.com/n9te9/goliteql/validator"
+)
+
+var rulesTestSchema = []byte(`type Query {
+	user(id: ID!): User
+	users(limit: Int = 10, role: Role, filter: UserFilter): [User!]!
+	node(id: ID!): Node
+	search(text: String!): [SearchResult]
+	pet: Pet
+}
+
+type Mutation {
+	createUser(input: CreateUserInput!): User
+}
+
+type Subscription {
+	userCreated: User
+	userDeleted: User
+}
+
+interface Node {
+	id: ID!
+}
+
+type User implements Node {
+	id: ID!
+	name: String
+	age: Int
+	role: Role
+	friends(first: Int): [User]
+}
+
+type Post implements Node {
+	id: ID!
+	title: String!
+}
+
+type Dog {
+	name: String
+	barkVolume: Int
+}
+
+type Cat {
+	name: String
+	meowVolume: Int
+}
+
+union SearchResult = User | Post
+
+union Pet = Dog | Cat
+
+enum Role {
+	ADMIN
+	MEMBER
+}
+
+input UserFilter {
+	name: String
+	role: Role
+}
+
+input CreateUserInput {
+	name: String!
+	age: Int
+}
+
+directive @deprecated(reason: String) on FIELD_DEFINITION`)
+
+func TestValidator_ValidateRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		query []byte
+		want  []string
+	}{
+		{
+			name: "unique operation names",
+			query: []byte(`query Q { pet { ... on Dog { name } } }
+			query Q { pet { ... on Cat { name } } }`),
+			want: []string{`there can be only one operation named "Q"`},
+		},
+		{
+			name: "lone anonymous operation",
+			query: []byte(`query { user(id: 1) { id } }
+			query Named { user(id: 2) { id } }`),
+			want: []string{`this anonymous operation must be the only defined operation`},
+		},
+		{
+			name: "unique fragment names",
+			query: []byte(`query { user(id: 1) { ...F } }
+			fragment F on User { id }
+			fragment F on User { name }`),
+			want: []string{`there can be only one fragment named "F"`},
+		},
+		{
+			name: "single field subscriptions",
+			query: []byte(`query Q { user(id: 1) { id } }
+			subscription S { userCreated { id } userDeleted { id } }`),
+			want: []string{`subscription "S" must select only one top level field`},
+		},
+		{
+			name: "valid subscription",
+			query: []byte(`query Q { user(id: 1) { id } }
+			subscription S { userCreated { id } }`),
+		},
+		{
+			name: "fields on correct type",
+			query: []byte(`query Q { user(id: 1) { id } }
+			fragment F on User { nickname }`),
+			want: []string{`cannot query field "nickname" on type "User"`, `fragment "F" is never used`},
+		},
+		{
+			name:  "fields on union require fragments",
+			query: []byte(`query { pet { name } }`),
+			want:  []string{`cannot query field "name" on type "Pet"`},
+		},
+		{
+			name:  "known argument names",
+			query: []byte(`query { user(id: 1, name: "x") { id } }`),
+			want:  []string{`unknown argument "name" on field "Query.user"`},
+		},
+		{
+			name:  "unique argument names",
+			query: []byte(`query { user(id: 1, id: 2) { id } }`),
+			want:  []string{`there can be only one argument named "id"`},
+		},
+		{
+			name:  "values of correct type for enum",
+			query: []byte(`query { users(role: OWNER) { id } }`),
+			want:  []string{`argument "role" on field "Query.users" expected value of type "Role", found OWNER`},
+		},
+		{
+			name:  "values of correct type for int",
+			query: []byte(`query { users(limit: "10") { id } }`),
+			want:  []string{`argument "limit" on field "Query.users" expected value of type "Int", found "10"`},
+		},
+		{
+			name:  "values of correct type for int out of range",
+			query: []byte(`query { users(limit: 3000000000) { id } }`),
+			want:  []string{`argument "limit" on field "Query.users" expected value of type "Int", found 3000000000`},
+		},
+		{
+			name:  "values of correct type for input object",
+			query: []byte(`query { users(filter: {name: "x" nickname: "y"}) { id } }`),
+			want:  []string{`argument "filter" on field "Query.users" field "nickname" is not defined by type "UserFilter"`},
+		},
+		{
+			name:  "valid input object value",
+			query: []byte(`query { users(filter: {name: "x" role: ADMIN}) { id } }`),
+		},
+		{
+			name:  "scalar leafs must not have selections",
+			query: []byte(`query { user(id: 1) { name { id } } }`),
+			want:  []string{`field "name" must not have a selection since type "String" has no subfields`},
+		},
+		{
+			name:  "composite fields must have selections",
+			query: []byte(`query { user(id: 1) { id friends } }`),
+			want:  []string{`field "friends" of type "[User]" must have a selection of subfields`},
+		},
+		{
+			name:  "known directives",
+			query: []byte(`query { user(id: 1) { id @unknown } }`),
+			want:  []string{`unknown directive "@unknown"`},
+		},
+		{
+			name:  "directives in valid locations",
+			query: []byte(`query @skip(if: true) { user(id: 1) { id } }`),
+			want:  []string{`directive "@skip" may not be used on QUERY`},
+		},
+		{
+			name:  "unique directives per location",
+			query: []byte(`query { user(id: 1) { id @skip(if: true) @skip(if: false) } }`),
+			want:  []string{`the directive "@skip" can only be used once at this location`},
+		},
+		{
+			name:  "directive with variable argument",
+			query: []byte(`query Q($hide: Boolean!) { user(id: 1) { id name @skip(if: $hide) } }`),
+		},
+		{
+			name: "fragment on composite type",
+			query: []byte(`query { user(id: 1) { ...F } }
+			fragment F on Role { id }`),
+			want: []string{`fragment "F" cannot condition on non composite type "Role"`},
+		},
+		{
+			name: "no unused fragments",
+			query: []byte(`query { user(id: 1) { id } }
+			fragment F on User { id }`),
+			want: []string{`fragment "F" is never used`},
+		},
+		{
+			name: "no fragment cycles",
+			query: []byte(`query { user(id: 1) { ...A } }
+			fragment A on User { friends { ...B } }
+			fragment B on User { friends { ...A } }`),
+			want: []string{`cannot spread fragment "A" within itself via B`},
+		},
+		{
+			name:  "possible fragment spreads",
+			query: []byte(`query { pet { ... on User { id } } }`),
+			want:  []string{`fragment cannot be spread here as objects of type "Pet" can never be of type "User"`},
+		},
+		{
+			name:  "valid abstract spread",
+			query: []byte(`query { node(id: 1) { id ... on User { name } ... on Post { title } } }`),
+		},
+		{
+			name:  "unique variable names",
+			query: []byte(`query Q($id: ID!, $id: ID!) { user(id: $id) { id } }`),
+			want:  []string{`there can be only one variable named "$id"`},
+		},
+		{
+			name:  "variables are input types",
+			query: []byte(`query Q($id: ID!, $u: User) { user(id: $id) { id } }`),
+			want:  []string{`variable "$u" cannot be non-input type "User"`, `variable "$u" is never used in operation "Q"`},
+		},
+		{
+			name:  "no undefined variables",
+			query: []byte(`query Q { user(id: $id) { id } }`),
+			want:  []string{`variable "$id" is not defined by operation "Q"`},
+		},
+		{
+			name:  "no unused variables",
+			query: []byte(`query Q($id: ID!, $limit: Int) { user(id: $id) { id } }`),
+			want:  []string{`variable "$limit" is never used in operation "Q"`},
+		},
+		{
+			name: "variables used in fragments",
+			query: []byte(`query Q($first: Int) { user(id: 1) { ...F } }
+			fragment F on User { friends(first: $first) { id } }`),
+		},
+		{
+			name:  "variables in allowed position",
+			query: []byte(`query Q($id: ID) { user(id: $id) { id } }`),
+			want:  []string{`variable "$id" of type "ID" used in position expecting type "ID!"`},
+		},
+		{
+			name:  "variable with default in non-null position",
+			query: []byte(`query Q($id: ID = 1) { user(id: $id) { id } }`),
+		},
+		{
+			name:  "variable default value of correct type",
+			query: []byte(`query Q($limit: Int = "ten") { users(limit: $limit) { id } }`),
+			want:  []string{`variable "$limit" default value expected value of type "Int", found "ten"`},
+		},
+		{
+			name:  "overlapping fields with different arguments",
+			query: []byte(`query { user(id: 1) { id friends(first: 1) { id } friends(first: 2) { id } } }`),
+			want:  []string{`fields "friends" conflict because they have differing arguments`},
+		},
+		{
+			name:  "aliased fields with different arguments",
+			query: []byte(`query { user(id: 1) { first: friends(first: 1) { id } second: friends(first: 2) { id } } }`),
+		},
+		{
+			name:  "alias shared by different fields",
+			query: []byte(`query { user(id: 1) { value: id value: name } }`),
+			want:  []string{`fields "value" conflict because id and name are different fields`},
+		},
+		{
+			name:  "overlapping fields with conflicting types",
+			query: []byte(`query { search(text: "x") { ... on User { id } ... on Post { id } } pet { ... on Dog { name } ... on Cat { name } } }`),
+		},
+		{
+			name:  "introspection fields on query root",
+			query: []byte(`query { __typename __schema { queryType { name } } __type(name: "User") { name } users { __typename id } }`),
+		},
+		{
+			name:  "__typename on every composite type",
+			query: []byte(`query { node(id: 1) { __typename ... on Post { __typename } } search(text: "x") { __typename } pet { __typename ... on Dog { __typename name } } }`),
+		},
+		{
+			name:  "__typename on mutation root",
+			query: []byte(`mutation M { __typename createUser(input: {name: "x"}) { __typename } }`),
+		},
+		{
+			name:  "introspection fields on mutation root",
+			query: []byte(`mutation M { __schema { queryType { name } } }`),
+			want:  []string{`cannot query field "__schema" on type "Mutation"`},
+		},
+		{
+			name:  "mutation only document",
+			query: []byte(`mutation M { createUser(input: {name: "x"}) { id name } }`),
+		},
+		{
+			name:  "mutation fields are validated",
+			query: []byte(`mutation M { createUser(input: {name: "x"}) { nickname } }`),
+			want:  []string{`cannot query field "nickname" on type "User"`},
+		},
+		{
+			name:  "subscription fields are validated",
+			query: []byte(`subscription S { userCreated { nickname } }`),
+			want:  []string{`cannot query field "nickname" on type "User"`},
+		},
+		{
+			name:  "mutation input",
+			query: []byte(`query Q { user(id: 1) { id } } mutation M { createUser(input: {age: 1}) { id } }`),
+			want:  []string{`argument "input" on field "Mutation.createUser" field "CreateUserInput.name" of required type "String!" was not provided`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := schema.NewParser(schema.NewLexer()).Parse(rulesTestSchema)
+			if err != nil {
+				t.Fatal(err)
+			}
+			mergedSchema, err := s.Merge()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			v := validator.NewValidator(mergedSchema, query.NewParser(query.NewLexer()))
+
+			errs := v.Validate(tt.query)
+
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Message)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Validate() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		query []byte
+		want  []executor.GraphQLError
+	}{
+		{
+			name: "collects every violation with locations and path",
+			query: []byte(`query Q {
+	user(id: 1) {
+		nickname
+		friends
+	}
+}`),
+			want: []executor.GraphQLError{
+				{
+					Message:    `cannot query field "nickname" on type "User"`,
+					Locations:  []executor.Location{{Line: 3, Column: 3}},
+					Path:       []any{"user", "nickname"},
+					Extensions: map[string]any{"code": validator.ValidationFailedCode},
+				},
+				{
+					Message:    `field "friends" of type "[User]" must have a selection of subfields`,
+					Locations:  []executor.Location{{Line: 4, Column: 3}},
+					Path:       []any{"user", "friends"},
+					Extensions: map[string]any{"code": validator.ValidationFailedCode},
+				},
+			},
+		},
+		{
+			name: "fragment and variable errors are located at their definitions",
+			query: []byte(`query Q($limit: Int) { user(id: 1) { id } }
+fragment F on User { id }`),
+			want: []executor.GraphQLError{
+				{
+					Message:    `fragment "F" is never used`,
+					Locations:  []executor.Location{{Line: 2, Column: 1}},
+					Extensions: map[string]any{"code": validator.ValidationFailedCode},
+				},
+				{
+					Message:    `variable "$limit" is never used in operation "Q"`,
+					Locations:  []executor.Location{{Line: 1, Column: 9}},
+					Extensions: map[string]any{"code": validator.ValidationFailedCode},
+				},
+			},
+		},
+		{
+			name: "argument and directive errors are located at the offending node",
+			query: []byte(`query Q($id: ID) {
+	user(id: $id, name: "x") {
+		id @skip(if: 1)
+	}
+}`),
+			want: []executor.GraphQLError{
+				{
+					Message:    `unknown argument "name" on field "Query.user"`,
+					Locations:  []executor.Location{{Line: 2, Column: 16}},
+					Path:       []any{"user"},
+					Extensions: map[string]any{"code": validator.ValidationFailedCode},
+				},
+				{
+					Message:    `argument "if" on directive "@skip" expected value of type "Boolean!", found 1`,
+					Locations:  []executor.Location{{Line: 3, Column: 12}},
+					Path:       []any{"user", "id"},
+					Extensions: map[string]any{"code": validator.ValidationFailedCode},
+				},
+				{
+					Message:    `variable "$id" of type "ID" used in position expecting type "ID!"`,
+					Locations:  []executor.Location{{Line: 2, Column: 7}},
+					Path:       []any{"user"},
+					Extensions: map[string]any{"code": validator.ValidationFailedCode},
+				},
+			},
+		},
+		{
+			name: "fragment cycles are located at each spread",
+			query: []byte(`query { user(id: 1) { ...A } }
+fragment A on User { friends { ...B } }
+fragment B on User { friends { ...A } }`),
+			want: []executor.GraphQLError{
+				{
+					Message:    `cannot spread fragment "A" within itself via B`,
+					Locations:  []executor.Location{{Line: 2, Column: 32}, {Line: 3, Column: 32}},
+					Extensions: map[string]any{"code": validator.ValidationFailedCode},
+				},
+			},
+		},
+		{
+			name:  "conflicting fields are located at both fields",
+			query: []byte(`query { user(id: 1) { friends(first: 1) { id } friends(first: 2) { id } } }`),
+			want: []executor.GraphQLError{
+				{
+					Message:    `fields "friends" conflict because they have differing arguments`,
+					Locations:  []executor.Location{{Line: 1, Column: 23}, {Line: 1, Column: 48}},
+					Extensions: map[string]any{"code": validator.ValidationFailedCode},
+				},
+			},
+		},
+		{
+			name:  "parse failure",
+			query: []byte(`query { user(id: 1) { id }`),
+			want: []executor.GraphQLError{
+				{
+					Message:    "expected field but got  at 1 row, 27 col",
+					Extensions: map[string]any{"code": validator.ParseFailedCode},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := schema.NewParser(schema.NewLexer()).Parse(rulesTestSchema)
+			if err != nil {
+				t.Fatal(err)
+			}
+			mergedSchema, err := s.Merge()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			v := validator.NewValidator(mergedSchema, query.NewParser(query.NewLexer()))
+
+			if diff := cmp.Diff(tt.want, v.Validate(tt.query)); diff != "" {
+				t.Errorf("Validate() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
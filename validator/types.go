@@ -0,0 +1,244 @@
+package validator
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/n9te9/goliteql/query"
+	"github.com/n9te9/goliteql/schema"
+)
+
+type typeKind int
+
+const (
+	scalarKind typeKind = iota
+	objectKind
+	interfaceKind
+	unionKind
+	enumKind
+	inputObjectKind
+)
+
+type fieldsDefinition interface {
+	GetFieldByName(name []byte) *schema.FieldDefinition
+}
+
+type typeInfo struct {
+	name   []byte
+	kind   typeKind
+	fields fieldsDefinition
+	enum   *schema.EnumDefinition
+	input  *schema.InputDefinition
+	union  *schema.UnionDefinition
+	iface  *schema.InterfaceDefinition
+	object *schema.TypeDefinition
+}
+
+func (t *typeInfo) isComposite() bool {
+	return t.kind == objectKind || t.kind == interfaceKind || t.kind == unionKind
+}
+
+func (t *typeInfo) isLeaf() bool {
+	return t.kind == scalarKind || t.kind == enumKind
+}
+
+func (t *typeInfo) isInput() bool {
+	return t.kind == scalarKind || t.kind == enumKind || t.kind == inputObjectKind
+}
+
+var builtinScalars = map[string]struct{}{
+	"Int":     {},
+	"Float":   {},
+	"String":  {},
+	"Boolean": {},
+	"ID":      {},
+}
+
+var typeNameFieldDefinition = &schema.FieldDefinition{
+	Name: []byte("__typename"),
+	Type: &schema.FieldType{Name: []byte("String"), Nullable: false},
+}
+
+var schemaFieldDefinition = &schema.FieldDefinition{
+	Name: []byte("__schema"),
+	Type: &schema.FieldType{Name: []byte("__Schema"), Nullable: false},
+}
+
+var typeFieldDefinition = &schema.FieldDefinition{
+	Name: []byte("__type"),
+	Arguments: []*schema.ArgumentDefinition{
+		{
+			Name: []byte("name"),
+			Type: &schema.FieldType{Name: []byte("String"), Nullable: false},
+		},
+	},
+	Type: &schema.FieldType{Name: []byte("__Type"), Nullable: true},
+}
+
+func rootTypeName(s *schema.Schema, operationType query.OperationType) []byte {
+	switch operationType {
+	case query.MutationOperation:
+		return s.Definition.Mutation
+	case query.SubscriptionOperation:
+		return s.Definition.Subscription
+	}
+
+	return s.Definition.Query
+}
+
+func rootOperation(s *schema.Schema, operationType query.OperationType) *schema.OperationDefinition {
+	switch operationType {
+	case query.MutationOperation:
+		return s.GetMutation()
+	case query.SubscriptionOperation:
+		return s.GetSubscription()
+	}
+
+	return s.GetQuery()
+}
+
+func lookupType(s *schema.Schema, name []byte) *typeInfo {
+	for _, operationType := range []query.OperationType{query.QueryOperation, query.MutationOperation, query.SubscriptionOperation} {
+		if bytes.Equal(rootTypeName(s, operationType), name) {
+			if op := rootOperation(s, operationType); op != nil {
+				return &typeInfo{name: name, kind: objectKind, fields: op}
+			}
+		}
+	}
+
+	key := string(name)
+	if t := s.Indexes.GetTypeDefinition(key); t != nil {
+		return &typeInfo{name: t.Name, kind: objectKind, fields: t, object: t}
+	}
+
+	if i := s.Indexes.GetInterfaceDefinition(key); i != nil {
+		return &typeInfo{name: i.Name, kind: interfaceKind, fields: i, iface: i}
+	}
+
+	if u := s.Indexes.GetUnionDefinition(key); u != nil {
+		return &typeInfo{name: u.Name, kind: unionKind, union: u}
+	}
+
+	if e, ok := s.Indexes.EnumIndex[key]; ok {
+		return &typeInfo{name: e.Name, kind: enumKind, enum: e}
+	}
+
+	if i, ok := s.Indexes.InputIndex[key]; ok {
+		return &typeInfo{name: i.Name, kind: inputObjectKind, input: i}
+	}
+
+	if _, ok := builtinScalars[key]; ok {
+		return &typeInfo{name: name, kind: scalarKind}
+	}
+
+	if _, ok := s.Indexes.ScalarIndex[key]; ok {
+		return &typeInfo{name: name, kind: scalarKind}
+	}
+
+	return nil
+}
+
+func lookupField(parent *typeInfo, name []byte, isQueryRoot bool) *schema.FieldDefinition {
+	if bytes.Equal(name, typeNameFieldDefinition.Name) {
+		return typeNameFieldDefinition
+	}
+
+	if isQueryRoot {
+		if bytes.Equal(name, schemaFieldDefinition.Name) {
+			return schemaFieldDefinition
+		}
+
+		if bytes.Equal(name, typeFieldDefinition.Name) {
+			return typeFieldDefinition
+		}
+	}
+
+	if parent.fields == nil {
+		return nil
+	}
+
+	return parent.fields.GetFieldByName(name)
+}
+
+func possibleTypes(s *schema.Schema, t *typeInfo) map[string]struct{} {
+	ret := make(map[string]struct{})
+
+	switch t.kind {
+	case objectKind:
+		ret[string(t.name)] = struct{}{}
+	case interfaceKind:
+		for _, td := range s.Indexes.GetImplementedType(t.iface) {
+			ret[string(td.Name)] = struct{}{}
+		}
+	case unionKind:
+		for _, member := range t.union.Types {
+			ret[string(member)] = struct{}{}
+		}
+	}
+
+	return ret
+}
+
+func toSchemaFieldType(t *query.FieldType) *schema.FieldType {
+	if t == nil {
+		return nil
+	}
+
+	return &schema.FieldType{
+		Name:     t.Name,
+		Nullable: t.Nullable,
+		IsList:   t.IsList,
+		ListType: toSchemaFieldType(t.ListType),
+	}
+}
+
+func typeString(t *schema.FieldType) string {
+	var sb strings.Builder
+	if t.IsList {
+		sb.WriteString("[")
+		sb.WriteString(typeString(t.ListType))
+		sb.WriteString("]")
+	} else {
+		sb.Write(t.Name)
+	}
+
+	if !t.Nullable {
+		sb.WriteString("!")
+	}
+
+	return sb.String()
+}
+
+func nullableType(t *schema.FieldType) *schema.FieldType {
+	ret := *t
+	ret.Nullable = true
+	return &ret
+}
+
+func isTypeSubTypeOf(varType, locationType *schema.FieldType) bool {
+	if !locationType.Nullable {
+		if varType.Nullable {
+			return false
+		}
+
+		return isTypeSubTypeOf(nullableType(varType), nullableType(locationType))
+	}
+
+	if !varType.Nullable {
+		return isTypeSubTypeOf(nullableType(varType), locationType)
+	}
+
+	if locationType.IsList {
+		if !varType.IsList {
+			return false
+		}
+
+		return isTypeSubTypeOf(varType.ListType, locationType.ListType)
+	}
+
+	if varType.IsList {
+		return false
+	}
+
+	return bytes.Equal(varType.Name, locationType.Name)
+}
@@ -3,8 +3,7 @@ package validator_test
 import (
 	"testing"
 
-	"errors"
-
+	"github.com/google/go-cmp/cmp"
 	"github.com/n9te9/goliteql/query"
 	"github.com/n9te9/goliteql/schema"
 	"github.com/n9te9/goliteql/validator"
@@ -15,7 +14,7 @@ func TestValidator_Validate(t *testing.T) {
 		name       string
 		schemaFunc func(parser *schema.Parser) *schema.Schema
 		query      []byte
-		want       error
+		want       []string
 	}{
 		{
 			name: "Validate query with missing operation",
@@ -45,7 +44,7 @@ func TestValidator_Validate(t *testing.T) {
 					age
 				}
 			}`),
-			want: errors.New("error validating operations: field user is not defined in schema"),
+			want: []string{`cannot query field "user" on type "Query"`},
 		},
 		{
 			name: "Validate query with missing operation arguments",
@@ -76,7 +75,7 @@ func TestValidator_Validate(t *testing.T) {
 					age
 				}
 			}`),
-			want: errors.New("error validating operations: error validating field user: missing required arguments: [id]"),
+			want: []string{`field "Query.user" argument "id" of type "ID!" is required, but it was not provided`},
 		},
 		{
 			name: "Validate query with missing subfields",
@@ -107,7 +106,7 @@ func TestValidator_Validate(t *testing.T) {
 					posts
 				}
 			}`),
-			want: errors.New("error validating operations: error validating field users: field posts is not defined on User in schema"),
+			want: []string{`cannot query field "posts" on type "User"`},
 		},
 		{
 			name: "Validate simple query",
@@ -202,7 +201,7 @@ func TestValidator_Validate(t *testing.T) {
 					unknownField
 				}
 			}`),
-			want: errors.New("error validating operations: error validating field users: field unknownField is not defined on User in schema"),
+			want: []string{`cannot query field "unknownField" on type "User"`},
 		},
 		{
 			name: "Validate query with missing required argument",
@@ -228,7 +227,7 @@ func TestValidator_Validate(t *testing.T) {
 					name
 				}
 			}`),
-			want: errors.New("error validating operations: error validating field user: missing required arguments: [id]"),
+			want: []string{`field "Query.user" argument "id" of type "ID!" is required, but it was not provided`},
 		},
 		{
 			name: "Validate query with type mismatch in argument",
@@ -320,7 +319,7 @@ func TestValidator_Validate(t *testing.T) {
 					}
 				}
 			}`),
-			want: errors.New("error validating operations: error validating field users: error validating field posts: field unknownField is not defined on Post in schema"),
+			want: []string{`cannot query field "unknownField" on type "Post"`},
 		},
 		{
 			name: "Validate valid nested query",
@@ -392,7 +391,7 @@ func TestValidator_Validate(t *testing.T) {
 				id
 				title
 			}`),
-			want: errors.New(`error validating operations: error validating field user: fragment PostFragment is based on type Post, but field is of type User`),
+			want: []string{`fragment "PostFragment" cannot be spread here as objects of type "User" can never be of type "Post"`},
 		},
 		{
 			name: "Validate query with valid fragment spread",
@@ -499,7 +498,7 @@ func TestValidator_Validate(t *testing.T) {
 			query: []byte(`query {
 				searchResults {}
 			}`),
-			want: errors.New("error validating operations: union type SearchResult must have subfields"),
+			want: []string{`field "searchResults" of type "[SearchResult]" must have a selection of subfields`},
 		},
 		{
 			name: "Validate query with invalid inline fragment type",
@@ -533,7 +532,7 @@ func TestValidator_Validate(t *testing.T) {
 					}
 				}
 			}`),
-			want: errors.New("error validating operations: error validating field searchResults: type InvalidType is not defined in schema"),
+			want: []string{`unknown type "InvalidType"`},
 		},
 		{
 			name: "Validate query with nested inline fragment",
@@ -634,7 +633,7 @@ func TestValidator_Validate(t *testing.T) {
 					}
 				}
 			}`),
-			want: errors.New("error validating operations: error validating field searchResults: error validating field posts: type InvalidType is not defined in schema"),
+			want: []string{`unknown type "InvalidType"`},
 		},
 		{
 			name: "Validate query with valid inline fragment on Interface type",
@@ -747,7 +746,7 @@ func TestValidator_Validate(t *testing.T) {
 					}
 				}
 			}`),
-			want: errors.New("error validating operations: error validating field searchResults: type InvalidType is not defined in schema"),
+			want: []string{`unknown type "InvalidType"`},
 		},
 		{
 			name: "Validate query with nested inline fragment on Interface type",
@@ -828,7 +827,7 @@ func TestValidator_Validate(t *testing.T) {
 					}
 				}
 			}`),
-			want: errors.New("error validating operations: error validating field searchResults: field unknownField is not defined on User in schema"),
+			want: []string{`cannot query field "unknownField" on type "User"`},
 		},
 		{
 			name: "Validate query with extended field",
@@ -1053,7 +1052,7 @@ func TestValidator_Validate(t *testing.T) {
 					name @skip(if: "not a boolean")
 				}
 			}`),
-			want: errors.New(`error validating operations: error validating field user: error validating directive skip: error validating argument if: error validating value for argument if: expected boolean value, got "not a boolean"`),
+			want: []string{`argument "if" on directive "@skip" expected value of type "Boolean!", found "not a boolean"`},
 		},
 		{
 			name: "Validate query with @include directive (invalid argument type)",
@@ -1079,7 +1078,61 @@ func TestValidator_Validate(t *testing.T) {
 					name @include(if: 123)
 				}
 			}`),
-			want: errors.New(`error validating operations: error validating field user: error validating directive include: error validating argument if: error validating value for argument if: expected boolean value, got 123`),
+			want: []string{`argument "if" on directive "@include" expected value of type "Boolean!", found 123`},
+		},
+		{
+			name: "Validate mutation without schema mutation",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+				}
+
+				type User {
+					id: ID!
+					name: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`mutation {
+				createUser(name: "foo") {
+					id
+				}
+			}`),
+			want: []string{"schema does not support mutation operations"},
+		},
+		{
+			name: "Validate mutation with undefined field",
+			schemaFunc: func(parser *schema.Parser) *schema.Schema {
+				input := []byte(`type Query {
+					user(id: ID!): User
+				}
+
+				type Mutation {
+					createUser(name: String!): User
+				}
+
+				type User {
+					id: ID!
+					name: String
+				}`)
+				s, err := parser.Parse(input)
+				if err != nil {
+					panic(err)
+				}
+
+				return s
+			},
+			query: []byte(`mutation {
+				deleteUser(id: 1) {
+					id
+				}
+			}`),
+			want: []string{`cannot query field "deleteUser" on type "Mutation"`},
 		},
 	}
 
@@ -1094,25 +1147,15 @@ func TestValidator_Validate(t *testing.T) {
 
 			v := validator.NewValidator(mergedSchema, queryParser)
 
-			err := v.Validate(tt.query)
-
-			if tt.want == nil && err != nil {
-				t.Errorf("Parse() error %v", err)
-				return
-			}
-
-			if tt.want != nil && err == nil {
-				t.Errorf("Parse() error = %v, wantErr %v", err, tt.want)
-				return
-			}
+			errs := v.Validate(tt.query)
 
-			if tt.want != nil && err.Error() != tt.want.Error() {
-				t.Errorf("Parse() error = %v, wantErr %v", err, tt.want)
-				return
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Message)
 			}
 
-			if tt.want == nil && err == nil {
-				return
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Validate() mismatch (-want +got):\n%s", diff)
 			}
 		})
 	}
@@ -0,0 +1,265 @@
+package validator
+
+import (
+	"fmt"
+)
+
+type valueKind int
+
+const (
+	variableValue valueKind = iota
+	intValue
+	floatValue
+	stringValue
+	booleanValue
+	nullValue
+	enumValue
+	listValue
+	objectValue
+)
+
+type value struct {
+	kind   valueKind
+	raw    []byte
+	list   []*value
+	fields []*objectField
+}
+
+type objectField struct {
+	name  []byte
+	value *value
+}
+
+func (v *value) variableName() string {
+	if v.kind != variableValue {
+		return ""
+	}
+
+	return string(v.raw[1:])
+}
+
+func parseValue(raw []byte) (*value, error) {
+	p := &valueParser{input: raw}
+	v, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipIgnored()
+	if p.cur < len(p.input) {
+		return nil, fmt.Errorf("unexpected %q in value %s", p.input[p.cur], raw)
+	}
+
+	return v, nil
+}
+
+type valueParser struct {
+	input []byte
+	cur   int
+}
+
+func (p *valueParser) skipIgnored() {
+	for p.cur < len(p.input) {
+		switch p.input[p.cur] {
+		case ' ', '\t', '\n', '\r', ',':
+			p.cur++
+		default:
+			return
+		}
+	}
+}
+
+func (p *valueParser) parseValue() (*value, error) {
+	p.skipIgnored()
+	if p.cur >= len(p.input) {
+		return nil, fmt.Errorf("unexpected end of value %s", p.input)
+	}
+
+	switch c := p.input[p.cur]; {
+	case c == '$':
+		p.cur++
+		name := p.readName()
+		if len(name) == 0 {
+			return nil, fmt.Errorf("expected variable name in value %s", p.input)
+		}
+
+		return &value{kind: variableValue, raw: p.input[p.cur-len(name)-1 : p.cur]}, nil
+	case c == '"':
+		return p.parseString()
+	case c == '[':
+		return p.parseList()
+	case c == '{':
+		return p.parseObject()
+	case c == '-' || isDigit(c):
+		return p.parseNumber()
+	case isNameStart(c):
+		name := p.readName()
+		switch string(name) {
+		case "true", "false":
+			return &value{kind: booleanValue, raw: name}, nil
+		case "null":
+			return &value{kind: nullValue, raw: name}, nil
+		}
+
+		return &value{kind: enumValue, raw: name}, nil
+	}
+
+	return nil, fmt.Errorf("unexpected %q in value %s", p.input[p.cur], p.input)
+}
+
+func (p *valueParser) readName() []byte {
+	start := p.cur
+	for p.cur < len(p.input) && (isNameStart(p.input[p.cur]) || isDigit(p.input[p.cur])) {
+		p.cur++
+	}
+
+	return p.input[start:p.cur]
+}
+
+func (p *valueParser) parseString() (*value, error) {
+	start := p.cur
+	if p.cur+2 < len(p.input) && p.input[p.cur+1] == '"' && p.input[p.cur+2] == '"' {
+		p.cur += 3
+		for p.cur+2 < len(p.input) {
+			if p.input[p.cur] == '"' && p.input[p.cur+1] == '"' && p.input[p.cur+2] == '"' {
+				p.cur += 3
+				return &value{kind: stringValue, raw: p.input[start:p.cur]}, nil
+			}
+			p.cur++
+		}
+
+		return nil, fmt.Errorf("unterminated string in value %s", p.input)
+	}
+
+	p.cur++
+	for p.cur < len(p.input) {
+		switch p.input[p.cur] {
+		case '\\':
+			p.cur += 2
+			continue
+		case '"':
+			p.cur++
+			return &value{kind: stringValue, raw: p.input[start:p.cur]}, nil
+		}
+		p.cur++
+	}
+
+	return nil, fmt.Errorf("unterminated string in value %s", p.input)
+}
+
+func (p *valueParser) parseNumber() (*value, error) {
+	start := p.cur
+	kind := intValue
+
+	if p.input[p.cur] == '-' {
+		p.cur++
+	}
+
+	digits := p.readDigits()
+	if digits == 0 {
+		return nil, fmt.Errorf("invalid number in value %s", p.input)
+	}
+
+	if p.cur < len(p.input) && p.input[p.cur] == '.' {
+		kind = floatValue
+		p.cur++
+		if p.readDigits() == 0 {
+			return nil, fmt.Errorf("invalid number in value %s", p.input)
+		}
+	}
+
+	if p.cur < len(p.input) && (p.input[p.cur] == 'e' || p.input[p.cur] == 'E') {
+		kind = floatValue
+		p.cur++
+		if p.cur < len(p.input) && (p.input[p.cur] == '+' || p.input[p.cur] == '-') {
+			p.cur++
+		}
+		if p.readDigits() == 0 {
+			return nil, fmt.Errorf("invalid number in value %s", p.input)
+		}
+	}
+
+	if p.cur < len(p.input) && isNameStart(p.input[p.cur]) {
+		return nil, fmt.Errorf("invalid number in value %s", p.input)
+	}
+
+	return &value{kind: kind, raw: p.input[start:p.cur]}, nil
+}
+
+func (p *valueParser) readDigits() int {
+	start := p.cur
+	for p.cur < len(p.input) && isDigit(p.input[p.cur]) {
+		p.cur++
+	}
+
+	return p.cur - start
+}
+
+func (p *valueParser) parseList() (*value, error) {
+	start := p.cur
+	p.cur++
+
+	v := &value{kind: listValue}
+	for {
+		p.skipIgnored()
+		if p.cur >= len(p.input) {
+			return nil, fmt.Errorf("unterminated list in value %s", p.input)
+		}
+
+		if p.input[p.cur] == ']' {
+			p.cur++
+			v.raw = p.input[start:p.cur]
+			return v, nil
+		}
+
+		item, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		v.list = append(v.list, item)
+	}
+}
+
+func (p *valueParser) parseObject() (*value, error) {
+	start := p.cur
+	p.cur++
+
+	v := &value{kind: objectValue}
+	for {
+		p.skipIgnored()
+		if p.cur >= len(p.input) {
+			return nil, fmt.Errorf("unterminated object in value %s", p.input)
+		}
+
+		if p.input[p.cur] == '}' {
+			p.cur++
+			v.raw = p.input[start:p.cur]
+			return v, nil
+		}
+
+		name := p.readName()
+		if len(name) == 0 {
+			return nil, fmt.Errorf("expected object field name in value %s", p.input)
+		}
+
+		p.skipIgnored()
+		if p.cur >= len(p.input) || p.input[p.cur] != ':' {
+			return nil, fmt.Errorf("expected : after object field %s in value %s", name, p.input)
+		}
+		p.cur++
+
+		fieldValue, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		v.fields = append(v.fields, &objectField{name: name, value: fieldValue})
+	}
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
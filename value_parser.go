@@ -75,6 +75,10 @@ func (v *ValueParserLiteral) BoolValue() bool {
 	return string(v.Value) == "true"
 }
 
+func (v *ValueParserLiteral) IsEnum() bool {
+	return v.TokenType == IDENT
+}
+
 func (v *ValueParserLiteral) IsNull() bool {
 	return v.TokenType == NULL
 }
@@ -129,8 +133,10 @@ func (vp *ValueParser) parseLiteral(tokens []*ValueLexerToken) (ValueParserExpr,
 		return nil, -1, fmt.Errorf("expected value token")
 	}
 
-	if tokens[0].Type == IDENT {
-		return nil, -1, fmt.Errorf("expected Scalar token, got IDENT: %s", tokens[0].Value)
+	switch tokens[0].Type {
+	case IDENT, INT, FLOAT, STRING, ID, BOOL, NULL:
+	default:
+		return nil, -1, fmt.Errorf("expected value token, got %s", tokens[0].Value)
 	}
 
 	return &ValueParserLiteral{
@@ -68,6 +68,17 @@ func TestValueParser_Parse(t *testing.T) {
 			want:    &goliteql.ValueParserLiteral{Value: []byte(`"hello\nworld"`), TokenType: goliteql.STRING},
 			wantErr: false,
 		},
+		{
+			name:    "enum value",
+			input:   []byte("ADMIN"),
+			want:    &goliteql.ValueParserLiteral{Value: []byte("ADMIN"), TokenType: goliteql.IDENT},
+			wantErr: false,
+		},
+		{
+			name:    "unexpected closing brace",
+			input:   []byte("}"),
+			wantErr: true,
+		},
 		{
 			name:    "empty object",
 			input:   []byte("{}"),